@@ -0,0 +1,1018 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ProjectStatus is the status of a project.
+//
+// https://platform.openai.com/docs/api-reference/projects/object
+type ProjectStatus string
+
+const (
+	ProjectStatusActive   ProjectStatus = "active"
+	ProjectStatusArchived ProjectStatus = "archived"
+)
+
+// https://platform.openai.com/docs/api-reference/projects/object
+type Project struct {
+	Object     string        `json:"object"`
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	CreatedAt  int           `json:"created_at"`
+	ArchivedAt int           `json:"archived_at,omitempty"`
+	Status     ProjectStatus `json:"status"`
+}
+
+// https://platform.openai.com/docs/api-reference/projects/list
+type ListProjectsRequest struct {
+	// https://platform.openai.com/docs/api-reference/projects/list#projects-list-limit
+	//
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/projects/list#projects-list-after
+	//
+	// Optional.
+	After string `json:"-"`
+
+	// Include archived projects in the list.
+	//
+	// https://platform.openai.com/docs/api-reference/projects/list#projects-list-include_archived
+	//
+	// Optional. Defaults to false.
+	IncludeArchived bool `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/projects/list
+type ListProjectsResponse = Page[Project]
+
+// ListProjects lists the projects in the organization.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/projects/list
+func (c *Client) ListProjects(ctx context.Context, req *ListProjectsRequest) (*ListProjectsResponse, error) {
+	q := url.Values{}
+
+	if req.Limit != 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if req.After != "" {
+		q.Set("after", req.After)
+	}
+
+	if req.IncludeArchived {
+		q.Set("include_archived", "true")
+	}
+
+	path := "/organization/projects"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var res ListProjectsResponse
+	err := c.do(ctx, http.MethodGet, path, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListProjectsRequest, after string) { r.After = after }, c.ListProjects)
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/projects/create
+type CreateProjectRequest struct {
+	// The friendly name of the project, shown in the dashboard.
+	//
+	// https://platform.openai.com/docs/api-reference/projects/create#projects-create-name
+	//
+	// Required.
+	Name string `json:"name"`
+}
+
+// CreateProject creates a new project in the organization.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/projects/create
+func (c *Client) CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error) {
+	var res Project
+	err := c.do(ctx, http.MethodPost, "/organization/projects", req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/projects/retrieve
+type GetProjectRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+}
+
+// GetProject retrieves a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/projects/retrieve
+func (c *Client) GetProject(ctx context.Context, req *GetProjectRequest) (*Project, error) {
+	var res Project
+	err := c.do(ctx, http.MethodGet, "/organization/projects/"+req.ProjectID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/projects/modify
+type UpdateProjectRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/projects/modify#projects-modify-name
+	//
+	// Required.
+	Name string `json:"name"`
+}
+
+// UpdateProject renames a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/projects/modify
+func (c *Client) UpdateProject(ctx context.Context, req *UpdateProjectRequest) (*Project, error) {
+	var res Project
+	err := c.do(ctx, http.MethodPost, "/organization/projects/"+req.ProjectID, req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/projects/archive
+type ArchiveProjectRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+}
+
+// ArchiveProject archives a project. Archived projects can't be used or
+// updated, and can't be unarchived.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/projects/archive
+func (c *Client) ArchiveProject(ctx context.Context, req *ArchiveProjectRequest) (*Project, error) {
+	var res Project
+	err := c.do(ctx, http.MethodPost, "/organization/projects/"+req.ProjectID+"/archive", nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ProjectRole is the role of a user or service account within a project.
+//
+// https://platform.openai.com/docs/api-reference/project-users/object
+type ProjectRole string
+
+const (
+	ProjectRoleOwner  ProjectRole = "owner"
+	ProjectRoleMember ProjectRole = "member"
+)
+
+// https://platform.openai.com/docs/api-reference/project-users/object
+type ProjectUser struct {
+	Object  string      `json:"object"`
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Email   string      `json:"email"`
+	Role    ProjectRole `json:"role"`
+	AddedAt int         `json:"added_at"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-users/list
+type ListProjectUsersRequest struct {
+	// https://platform.openai.com/docs/api-reference/project-users/list#project-users-list-project_id
+	//
+	// Required.
+	ProjectID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/project-users/list#project-users-list-limit
+	//
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/project-users/list#project-users-list-after
+	//
+	// Optional.
+	After string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-users/list
+type ListProjectUsersResponse = Page[ProjectUser]
+
+// ListProjectUsers lists the users in a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-users/list
+func (c *Client) ListProjectUsers(ctx context.Context, req *ListProjectUsersRequest) (*ListProjectUsersResponse, error) {
+	var res ListProjectUsersResponse
+	err := c.do(ctx, http.MethodGet, "/organization/projects/"+req.ProjectID+"/users"+listQuery(req.Limit, "", req.After, ""), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListProjectUsersRequest, after string) { r.After = after }, c.ListProjectUsers)
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-users/creeate
+type CreateProjectUserRequest struct {
+	// https://platform.openai.com/docs/api-reference/project-users/creeate#project-users-creeate-project_id
+	//
+	// Required.
+	ProjectID string `json:"-"`
+
+	// The ID of the organization user to add to the project.
+	//
+	// https://platform.openai.com/docs/api-reference/project-users/creeate#project-users-creeate-user_id
+	//
+	// Required.
+	UserID string `json:"user_id"`
+
+	// https://platform.openai.com/docs/api-reference/project-users/creeate#project-users-creeate-role
+	//
+	// Required. Either "owner" or "member".
+	Role ProjectRole `json:"role"`
+}
+
+// CreateProjectUser adds an organization user to a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-users/creeate
+func (c *Client) CreateProjectUser(ctx context.Context, req *CreateProjectUserRequest) (*ProjectUser, error) {
+	var res ProjectUser
+	err := c.do(ctx, http.MethodPost, "/organization/projects/"+req.ProjectID+"/users", req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-users/retrieve
+type GetProjectUserRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Required.
+	UserID string `json:"-"`
+}
+
+// GetProjectUser retrieves a user in a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-users/retrieve
+func (c *Client) GetProjectUser(ctx context.Context, req *GetProjectUserRequest) (*ProjectUser, error) {
+	var res ProjectUser
+	err := c.do(ctx, http.MethodGet, "/organization/projects/"+req.ProjectID+"/users/"+req.UserID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-users/modify
+type UpdateProjectUserRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Required.
+	UserID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/project-users/modify#project-users-modify-role
+	//
+	// Required. Either "owner" or "member".
+	Role ProjectRole `json:"role"`
+}
+
+// UpdateProjectUser modifies the role of a user in a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-users/modify
+func (c *Client) UpdateProjectUser(ctx context.Context, req *UpdateProjectUserRequest) (*ProjectUser, error) {
+	var res ProjectUser
+	err := c.do(ctx, http.MethodPost, "/organization/projects/"+req.ProjectID+"/users/"+req.UserID, req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-users/delete
+type DeleteProjectUserRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Required.
+	UserID string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-users/delete
+type DeleteProjectUserResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// DeleteProjectUser removes a user from a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-users/delete
+func (c *Client) DeleteProjectUser(ctx context.Context, req *DeleteProjectUserRequest) (*DeleteProjectUserResponse, error) {
+	var res DeleteProjectUserResponse
+	err := c.do(ctx, http.MethodDelete, "/organization/projects/"+req.ProjectID+"/users/"+req.UserID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ProjectServiceAccountAPIKey is the API key issued to a newly created
+// service account. The Value is only ever returned once, on creation.
+//
+// https://platform.openai.com/docs/api-reference/project-service-accounts/create
+type ProjectServiceAccountAPIKey struct {
+	Object    string `json:"object"`
+	Value     string `json:"value"`
+	Name      string `json:"name"`
+	CreatedAt int    `json:"created_at"`
+	ID        string `json:"id"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-service-accounts/object
+type ProjectServiceAccount struct {
+	Object    string      `json:"object"`
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Role      ProjectRole `json:"role"`
+	CreatedAt int         `json:"created_at"`
+
+	// APIKey is only set in the response to CreateProjectServiceAccount.
+	APIKey *ProjectServiceAccountAPIKey `json:"api_key,omitempty"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-service-accounts/list
+type ListProjectServiceAccountsRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// Optional.
+	After string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-service-accounts/list
+type ListProjectServiceAccountsResponse = Page[ProjectServiceAccount]
+
+// ListProjectServiceAccounts lists the service accounts in a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-service-accounts/list
+func (c *Client) ListProjectServiceAccounts(ctx context.Context, req *ListProjectServiceAccountsRequest) (*ListProjectServiceAccountsResponse, error) {
+	var res ListProjectServiceAccountsResponse
+	err := c.do(ctx, http.MethodGet, "/organization/projects/"+req.ProjectID+"/service_accounts"+listQuery(req.Limit, "", req.After, ""), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListProjectServiceAccountsRequest, after string) { r.After = after }, c.ListProjectServiceAccounts)
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-service-accounts/create
+type CreateProjectServiceAccountRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/project-service-accounts/create#project-service-accounts-create-name
+	//
+	// Required.
+	Name string `json:"name"`
+}
+
+// CreateProjectServiceAccount creates a new service account in a project, which
+// also returns an unredacted API key for the service account.
+//
+// # Example
+//
+//	sa, _ := client.CreateProjectServiceAccount(ctx, &openai.CreateProjectServiceAccountRequest{
+//		ProjectID: "proj_abc",
+//		Name:      "deploy-bot",
+//	})
+//
+//	fmt.Println(sa.APIKey.Value)
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-service-accounts/create
+func (c *Client) CreateProjectServiceAccount(ctx context.Context, req *CreateProjectServiceAccountRequest) (*ProjectServiceAccount, error) {
+	var res ProjectServiceAccount
+	err := c.do(ctx, http.MethodPost, "/organization/projects/"+req.ProjectID+"/service_accounts", req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-service-accounts/retrieve
+type GetProjectServiceAccountRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Required.
+	ServiceAccountID string `json:"-"`
+}
+
+// GetProjectServiceAccount retrieves a service account in a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-service-accounts/retrieve
+func (c *Client) GetProjectServiceAccount(ctx context.Context, req *GetProjectServiceAccountRequest) (*ProjectServiceAccount, error) {
+	var res ProjectServiceAccount
+	err := c.do(ctx, http.MethodGet, "/organization/projects/"+req.ProjectID+"/service_accounts/"+req.ServiceAccountID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-service-accounts/delete
+type DeleteProjectServiceAccountRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Required.
+	ServiceAccountID string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-service-accounts/delete
+type DeleteProjectServiceAccountResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// DeleteProjectServiceAccount deletes a service account from a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-service-accounts/delete
+func (c *Client) DeleteProjectServiceAccount(ctx context.Context, req *DeleteProjectServiceAccountRequest) (*DeleteProjectServiceAccountResponse, error) {
+	var res DeleteProjectServiceAccountResponse
+	err := c.do(ctx, http.MethodDelete, "/organization/projects/"+req.ProjectID+"/service_accounts/"+req.ServiceAccountID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ProjectAPIKeyOwner is the owner of a project API key, either a user or a
+// service account, as indicated by Type.
+//
+// https://platform.openai.com/docs/api-reference/project-api-keys/object
+type ProjectAPIKeyOwner struct {
+	// Type is either "user" or "service_account".
+	Type string `json:"type"`
+
+	// User is set if the key is owned by a user.
+	User *ProjectUser `json:"user,omitempty"`
+
+	// ServiceAccount is set if the key is owned by a service account.
+	ServiceAccount *ProjectServiceAccount `json:"service_account,omitempty"`
+}
+
+// ProjectAPIKey is the metadata of an API key in a project. The key itself
+// is never returned, only its redacted value.
+//
+// https://platform.openai.com/docs/api-reference/project-api-keys/object
+type ProjectAPIKey struct {
+	Object        string             `json:"object"`
+	RedactedValue string             `json:"redacted_value"`
+	Name          string             `json:"name"`
+	CreatedAt     int                `json:"created_at"`
+	LastUsedAt    int                `json:"last_used_at,omitempty"`
+	ID            string             `json:"id"`
+	Owner         ProjectAPIKeyOwner `json:"owner"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-api-keys/list
+type ListProjectAPIKeysRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// Optional.
+	After string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-api-keys/list
+type ListProjectAPIKeysResponse = Page[ProjectAPIKey]
+
+// ListProjectAPIKeys lists the API keys in a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-api-keys/list
+func (c *Client) ListProjectAPIKeys(ctx context.Context, req *ListProjectAPIKeysRequest) (*ListProjectAPIKeysResponse, error) {
+	var res ListProjectAPIKeysResponse
+	err := c.do(ctx, http.MethodGet, "/organization/projects/"+req.ProjectID+"/api_keys"+listQuery(req.Limit, "", req.After, ""), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListProjectAPIKeysRequest, after string) { r.After = after }, c.ListProjectAPIKeys)
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-api-keys/retrieve
+type GetProjectAPIKeyRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Required.
+	KeyID string `json:"-"`
+}
+
+// GetProjectAPIKey retrieves the metadata of an API key in a project.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-api-keys/retrieve
+func (c *Client) GetProjectAPIKey(ctx context.Context, req *GetProjectAPIKeyRequest) (*ProjectAPIKey, error) {
+	var res ProjectAPIKey
+	err := c.do(ctx, http.MethodGet, "/organization/projects/"+req.ProjectID+"/api_keys/"+req.KeyID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/project-api-keys/delete
+type DeleteProjectAPIKeyRequest struct {
+	// Required.
+	ProjectID string `json:"-"`
+
+	// Required.
+	KeyID string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/project-api-keys/delete
+type DeleteProjectAPIKeyResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// DeleteProjectAPIKey revokes an API key in a project.
+//
+// Keys owned by service accounts cannot be deleted this way, delete the
+// service account instead.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/project-api-keys/delete
+func (c *Client) DeleteProjectAPIKey(ctx context.Context, req *DeleteProjectAPIKeyRequest) (*DeleteProjectAPIKeyResponse, error) {
+	var res DeleteProjectAPIKeyResponse
+	err := c.do(ctx, http.MethodDelete, "/organization/projects/"+req.ProjectID+"/api_keys/"+req.KeyID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// OrganizationRole is the role of a user within an organization.
+//
+// https://platform.openai.com/docs/api-reference/users/object
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner  OrganizationRole = "owner"
+	OrganizationRoleReader OrganizationRole = "reader"
+)
+
+// https://platform.openai.com/docs/api-reference/users/object
+type OrganizationUser struct {
+	Object  string           `json:"object"`
+	ID      string           `json:"id"`
+	Name    string           `json:"name"`
+	Email   string           `json:"email"`
+	Role    OrganizationRole `json:"role"`
+	AddedAt int              `json:"added_at"`
+}
+
+// https://platform.openai.com/docs/api-reference/users/list
+type ListOrganizationUsersRequest struct {
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// Optional.
+	After string `json:"-"`
+
+	// Filter the users to those with the given email addresses.
+	//
+	// https://platform.openai.com/docs/api-reference/users/list#users-list-emails
+	//
+	// Optional.
+	Emails []string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/users/list
+type ListOrganizationUsersResponse = Page[OrganizationUser]
+
+// ListOrganizationUsers lists the users in the organization.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/users/list
+func (c *Client) ListOrganizationUsers(ctx context.Context, req *ListOrganizationUsersRequest) (*ListOrganizationUsersResponse, error) {
+	q := url.Values{}
+
+	if req.Limit != 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if req.After != "" {
+		q.Set("after", req.After)
+	}
+
+	for _, email := range req.Emails {
+		q.Add("emails[]", email)
+	}
+
+	path := "/organization/users"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var res ListOrganizationUsersResponse
+	err := c.do(ctx, http.MethodGet, path, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListOrganizationUsersRequest, after string) { r.After = after }, c.ListOrganizationUsers)
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/users/retrieve
+type GetOrganizationUserRequest struct {
+	// Required.
+	UserID string `json:"-"`
+}
+
+// GetOrganizationUser retrieves a user in the organization.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/users/retrieve
+func (c *Client) GetOrganizationUser(ctx context.Context, req *GetOrganizationUserRequest) (*OrganizationUser, error) {
+	var res OrganizationUser
+	err := c.do(ctx, http.MethodGet, "/organization/users/"+req.UserID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/users/modify
+type UpdateOrganizationUserRequest struct {
+	// Required.
+	UserID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/users/modify#users-modify-role
+	//
+	// Required. Either "owner" or "reader".
+	Role OrganizationRole `json:"role"`
+}
+
+// UpdateOrganizationUser changes the role of a user in the organization.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/users/modify
+func (c *Client) UpdateOrganizationUser(ctx context.Context, req *UpdateOrganizationUserRequest) (*OrganizationUser, error) {
+	var res OrganizationUser
+	err := c.do(ctx, http.MethodPost, "/organization/users/"+req.UserID, req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/users/delete
+type DeleteOrganizationUserRequest struct {
+	// Required.
+	UserID string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/users/delete
+type DeleteOrganizationUserResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// DeleteOrganizationUser removes a user from the organization.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/users/delete
+func (c *Client) DeleteOrganizationUser(ctx context.Context, req *DeleteOrganizationUserRequest) (*DeleteOrganizationUserResponse, error) {
+	var res DeleteOrganizationUserResponse
+	err := c.do(ctx, http.MethodDelete, "/organization/users/"+req.UserID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// InviteStatus is the status of an invite.
+//
+// https://platform.openai.com/docs/api-reference/invite/object
+type InviteStatus string
+
+const (
+	InviteStatusPending  InviteStatus = "pending"
+	InviteStatusAccepted InviteStatus = "accepted"
+	InviteStatusExpired  InviteStatus = "expired"
+)
+
+// InviteProject is a project that an invited user is added to once they
+// accept the invite.
+//
+// https://platform.openai.com/docs/api-reference/invite/object
+type InviteProject struct {
+	ID   string      `json:"id"`
+	Role ProjectRole `json:"role"`
+}
+
+// https://platform.openai.com/docs/api-reference/invite/object
+type Invite struct {
+	Object     string           `json:"object"`
+	ID         string           `json:"id"`
+	Email      string           `json:"email"`
+	Role       OrganizationRole `json:"role"`
+	Status     InviteStatus     `json:"status"`
+	InvitedAt  int              `json:"invited_at"`
+	ExpiresAt  int              `json:"expires_at"`
+	AcceptedAt int              `json:"accepted_at,omitempty"`
+	Projects   []InviteProject  `json:"projects,omitempty"`
+}
+
+// https://platform.openai.com/docs/api-reference/invite/list
+type ListInvitesRequest struct {
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// Optional.
+	After string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/invite/list
+type ListInvitesResponse = Page[Invite]
+
+// ListInvites lists the invites to the organization.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/invite/list
+func (c *Client) ListInvites(ctx context.Context, req *ListInvitesRequest) (*ListInvitesResponse, error) {
+	var res ListInvitesResponse
+	err := c.do(ctx, http.MethodGet, "/organization/invites"+listQuery(req.Limit, "", req.After, ""), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListInvitesRequest, after string) { r.After = after }, c.ListInvites)
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/invite/create
+type CreateInviteRequest struct {
+	// https://platform.openai.com/docs/api-reference/invite/create#invite-create-email
+	//
+	// Required.
+	Email string `json:"email"`
+
+	// https://platform.openai.com/docs/api-reference/invite/create#invite-create-role
+	//
+	// Required. Either "owner" or "reader".
+	Role OrganizationRole `json:"role"`
+
+	// The projects to add the user to once they accept the invite.
+	//
+	// https://platform.openai.com/docs/api-reference/invite/create#invite-create-projects
+	//
+	// Optional.
+	Projects []InviteProject `json:"projects,omitempty"`
+}
+
+// CreateInvite invites a user to the organization by email.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/invite/create
+func (c *Client) CreateInvite(ctx context.Context, req *CreateInviteRequest) (*Invite, error) {
+	var res Invite
+	err := c.do(ctx, http.MethodPost, "/organization/invites", req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/invite/retrieve
+type GetInviteRequest struct {
+	// Required.
+	InviteID string `json:"-"`
+}
+
+// GetInvite retrieves an invite.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/invite/retrieve
+func (c *Client) GetInvite(ctx context.Context, req *GetInviteRequest) (*Invite, error) {
+	var res Invite
+	err := c.do(ctx, http.MethodGet, "/organization/invites/"+req.InviteID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/invite/delete
+type DeleteInviteRequest struct {
+	// Required.
+	InviteID string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/invite/delete
+type DeleteInviteResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// DeleteInvite deletes a pending invite. Accepted invites can't be deleted.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/invite/delete
+func (c *Client) DeleteInvite(ctx context.Context, req *DeleteInviteRequest) (*DeleteInviteResponse, error) {
+	var res DeleteInviteResponse
+	err := c.do(ctx, http.MethodDelete, "/organization/invites/"+req.InviteID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AdminAPIKeyOwner is the user or service account that owns an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/admin-api-keys/object
+type AdminAPIKeyOwner struct {
+	Type      string           `json:"type"`
+	Object    string           `json:"object"`
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	CreatedAt int              `json:"created_at"`
+	Role      OrganizationRole `json:"role"`
+}
+
+// AdminAPIKey is an organization admin API key, used to access the
+// organization management endpoints.
+//
+// https://platform.openai.com/docs/api-reference/admin-api-keys/object
+type AdminAPIKey struct {
+	Object        string           `json:"object"`
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	RedactedValue string           `json:"redacted_value"`
+	CreatedAt     int              `json:"created_at"`
+	LastUsedAt    int              `json:"last_used_at,omitempty"`
+	Owner         AdminAPIKeyOwner `json:"owner"`
+
+	// Value is the unredacted key, only set in the response to CreateAdminAPIKey.
+	Value string `json:"value,omitempty"`
+}
+
+// https://platform.openai.com/docs/api-reference/admin-api-keys/list
+type ListAdminAPIKeysRequest struct {
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// Optional. Defaults to "asc".
+	Order SortOrder `json:"-"`
+
+	// Optional.
+	After string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/admin-api-keys/list
+type ListAdminAPIKeysResponse = Page[AdminAPIKey]
+
+// ListAdminAPIKeys lists the admin API keys in the organization.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/admin-api-keys/list
+func (c *Client) ListAdminAPIKeys(ctx context.Context, req *ListAdminAPIKeysRequest) (*ListAdminAPIKeysResponse, error) {
+	var res ListAdminAPIKeysResponse
+	err := c.do(ctx, http.MethodGet, "/organization/admin_api_keys"+listQuery(req.Limit, req.Order, req.After, ""), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListAdminAPIKeysRequest, after string) { r.After = after }, c.ListAdminAPIKeys)
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/admin-api-keys/create
+type CreateAdminAPIKeyRequest struct {
+	// https://platform.openai.com/docs/api-reference/admin-api-keys/create#admin-api-keys-create-name
+	//
+	// Required.
+	Name string `json:"name"`
+}
+
+// CreateAdminAPIKey creates a new admin API key. The unredacted key is only
+// returned once, in the Value field of the response.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/admin-api-keys/create
+func (c *Client) CreateAdminAPIKey(ctx context.Context, req *CreateAdminAPIKeyRequest) (*AdminAPIKey, error) {
+	var res AdminAPIKey
+	err := c.do(ctx, http.MethodPost, "/organization/admin_api_keys", req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/admin-api-keys/listget
+type GetAdminAPIKeyRequest struct {
+	// Required.
+	KeyID string `json:"-"`
+}
+
+// GetAdminAPIKey retrieves the metadata of an admin API key.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/admin-api-keys/listget
+func (c *Client) GetAdminAPIKey(ctx context.Context, req *GetAdminAPIKeyRequest) (*AdminAPIKey, error) {
+	var res AdminAPIKey
+	err := c.do(ctx, http.MethodGet, "/organization/admin_api_keys/"+req.KeyID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/admin-api-keys/delete
+type DeleteAdminAPIKeyRequest struct {
+	// Required.
+	KeyID string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/admin-api-keys/delete
+type DeleteAdminAPIKeyResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// DeleteAdminAPIKey revokes an admin API key.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/admin-api-keys/delete
+func (c *Client) DeleteAdminAPIKey(ctx context.Context, req *DeleteAdminAPIKeyRequest) (*DeleteAdminAPIKeyResponse, error) {
+	var res DeleteAdminAPIKeyResponse
+	err := c.do(ctx, http.MethodDelete, "/organization/admin_api_keys/"+req.KeyID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,350 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestProjectUsers(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/organization/projects/proj_abc/users":
+			if r.URL.Query().Get("limit") != "2" || r.URL.Query().Get("after") != "user_a" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"object":"list","data":[{"object":"organization.project.user","id":"user_b","role":"owner"}],"first_id":"user_b","last_id":"user_b","has_more":false}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/organization/projects/proj_abc/users/user_b":
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			fmt.Fprintf(w, `{"object":"organization.project.user","id":"user_b","role":%q}`, body["role"])
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/organization/projects/proj_abc/users/user_b":
+			fmt.Fprint(w, `{"object":"organization.project.user.deleted","id":"user_b","deleted":true}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	list, err := c.ListProjectUsers(ctx, &openai.ListProjectUsersRequest{ProjectID: "proj_abc", Limit: 2, After: "user_a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Data) != 1 || list.Data[0].Role != openai.ProjectRoleOwner {
+		t.Fatalf("unexpected users: %#+v", list.Data)
+	}
+
+	user, err := c.UpdateProjectUser(ctx, &openai.UpdateProjectUserRequest{ProjectID: "proj_abc", UserID: "user_b", Role: openai.ProjectRoleMember})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Role != openai.ProjectRoleMember {
+		t.Fatalf("expected role %q, got %q", openai.ProjectRoleMember, user.Role)
+	}
+
+	deleted, err := c.DeleteProjectUser(ctx, &openai.DeleteProjectUserRequest{ProjectID: "proj_abc", UserID: "user_b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !deleted.Deleted {
+		t.Fatal("expected user to be deleted")
+	}
+}
+
+func TestCreateProjectServiceAccount(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/organization/projects/proj_abc/service_accounts" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{
+			"object": "organization.project.service_account",
+			"id": "svc_acct_abc",
+			"name": "deploy-bot",
+			"role": "member",
+			"created_at": 1711471533,
+			"api_key": {"object": "organization.project.service_account.api_key", "value": "sk-abcdefghijklmnop123", "name": "Secret Key", "created_at": 1711471533, "id": "key_abc"}
+		}`)
+	})
+
+	sa, err := c.CreateProjectServiceAccount(testCtx(t), &openai.CreateProjectServiceAccountRequest{
+		ProjectID: "proj_abc",
+		Name:      "deploy-bot",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sa.APIKey == nil || sa.APIKey.Value != "sk-abcdefghijklmnop123" {
+		t.Fatalf("expected service account API key, got %#+v", sa.APIKey)
+	}
+}
+
+func TestListProjectAPIKeys(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/organization/projects/proj_abc/api_keys" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{
+			"object": "list",
+			"data": [
+				{"object": "organization.project.api_key", "redacted_value": "sk-abc...def", "name": "My API Key", "created_at": 1711471533, "id": "key_abc", "owner": {"type": "user", "user": {"object": "organization.project.user", "id": "user_abc", "email": "user@example.com", "role": "owner"}}},
+				{"object": "organization.project.api_key", "redacted_value": "sk-ghi...jkl", "name": "Bot Key", "created_at": 1711471533, "id": "key_ghi", "owner": {"type": "service_account", "service_account": {"object": "organization.project.service_account", "id": "svc_acct_abc", "name": "deploy-bot", "role": "member"}}}
+			],
+			"first_id": "key_abc",
+			"last_id": "key_ghi",
+			"has_more": false
+		}`)
+	})
+
+	resp, err := c.ListProjectAPIKeys(testCtx(t), &openai.ListProjectAPIKeysRequest{ProjectID: "proj_abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(resp.Data))
+	}
+
+	if owner := resp.Data[0].Owner; owner.Type != "user" || owner.User == nil || owner.User.Email != "user@example.com" {
+		t.Fatalf("unexpected owner: %#+v", owner)
+	}
+
+	if owner := resp.Data[1].Owner; owner.Type != "service_account" || owner.ServiceAccount == nil || owner.ServiceAccount.ID != "svc_acct_abc" {
+		t.Fatalf("unexpected owner: %#+v", owner)
+	}
+}
+
+func TestListOrganizationUsers(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/organization/users" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		emails := r.URL.Query()["emails[]"]
+		if len(emails) != 2 {
+			t.Errorf("expected 2 emails, got %v", emails)
+		}
+
+		fmt.Fprint(w, `{"object":"list","data":[{"object":"organization.user","id":"user_abc","email":"a@example.com","role":"owner"},{"object":"organization.user","id":"user_def","email":"b@example.com","role":"reader"}],"has_more":false}`)
+	})
+
+	resp, err := c.ListOrganizationUsers(testCtx(t), &openai.ListOrganizationUsersRequest{
+		Emails: []string{"a@example.com", "b@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data) != 2 || resp.Data[1].Role != openai.OrganizationRoleReader {
+		t.Fatalf("unexpected users: %#+v", resp.Data)
+	}
+}
+
+func TestAdminAPIKeyRotation(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/organization/admin_api_keys":
+			fmt.Fprint(w, `{"object":"organization.admin_api_key","id":"key_new","name":"rotated","redacted_value":"sk-admin...xyz","value":"sk-admin-full-xyz","owner":{"type":"user","id":"user_abc","role":"owner"}}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/organization/admin_api_keys/key_old":
+			fmt.Fprint(w, `{"object":"organization.admin_api_key.deleted","id":"key_old","deleted":true}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	key, err := c.CreateAdminAPIKey(ctx, &openai.CreateAdminAPIKeyRequest{Name: "rotated"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key.Value != "sk-admin-full-xyz" || key.Owner.Role != openai.OrganizationRoleOwner {
+		t.Fatalf("unexpected key: %#+v", key)
+	}
+
+	deleted, err := c.DeleteAdminAPIKey(ctx, &openai.DeleteAdminAPIKeyRequest{KeyID: "key_old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !deleted.Deleted {
+		t.Fatal("expected key to be deleted")
+	}
+}
+
+func TestWithAdminKey(t *testing.T) {
+	var auth = map[string]string{}
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		auth[r.URL.Path] = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	c := openai.NewClient("sk-project", openai.WithAdminKey("sk-admin"), openai.WithHTTPClient(testClient(t, h).HTTPClient))
+
+	ctx := testCtx(t)
+
+	_, err := c.ListOrganizationUsers(ctx, &openai.ListOrganizationUsersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.ListModels(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := auth["/v1/organization/users"]; got != "Bearer sk-admin" {
+		t.Errorf("expected admin key for organization endpoint, got %q", got)
+	}
+
+	if got := auth["/v1/models"]; got != "Bearer sk-project" {
+		t.Errorf("expected project key for inference endpoint, got %q", got)
+	}
+}
+
+func TestNewAdminClient(t *testing.T) {
+	var requests int
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.Header.Get("Authorization"); got != "Bearer sk-admin" {
+			t.Errorf("unexpected authorization header: %q", got)
+		}
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	c := openai.NewAdminClient("sk-admin", openai.WithHTTPClient(testClient(t, h).HTTPClient))
+
+	ctx := testCtx(t)
+
+	_, err := c.ListOrganizationUsers(ctx, &openai.ListOrganizationUsersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.ListModels(ctx)
+	if err == nil {
+		t.Fatal("expected admin client to refuse non-organization request")
+	}
+
+	_, err = c.GetOrganizationUser(ctx, &openai.GetOrganizationUserRequest{UserID: "../../models"})
+	if err == nil {
+		t.Fatal("expected admin client to refuse path traversal out of organization endpoints")
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected 1 request to be sent, got %d", requests)
+	}
+}
+
+func TestProjects(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/organization/projects":
+			if r.URL.Query().Get("include_archived") != "true" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"object":"list","data":[{"object":"organization.project","id":"proj_abc","name":"Old","status":"archived"}],"first_id":"proj_abc","last_id":"proj_abc","has_more":false}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/organization/projects":
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			fmt.Fprintf(w, `{"object":"organization.project","id":"proj_def","name":%q,"status":"active"}`, body["name"])
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/organization/projects/proj_def/archive":
+			fmt.Fprint(w, `{"object":"organization.project","id":"proj_def","name":"New","status":"archived","archived_at":1711471533}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	list, err := c.ListProjects(ctx, &openai.ListProjectsRequest{IncludeArchived: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Data) != 1 || list.Data[0].Status != openai.ProjectStatusArchived {
+		t.Fatalf("unexpected projects: %#+v", list.Data)
+	}
+
+	project, err := c.CreateProject(ctx, &openai.CreateProjectRequest{Name: "New"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if project.ID != "proj_def" || project.Name != "New" || project.Status != openai.ProjectStatusActive {
+		t.Fatalf("unexpected project: %#+v", project)
+	}
+
+	archived, err := c.ArchiveProject(ctx, &openai.ArchiveProjectRequest{ProjectID: project.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if archived.Status != openai.ProjectStatusArchived || archived.ArchivedAt == 0 {
+		t.Fatalf("unexpected archived project: %#+v", archived)
+	}
+}
+
+func TestInvites(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/organization/invites":
+			var req openai.CreateInviteRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatal(err)
+			}
+			if len(req.Projects) != 1 || req.Projects[0].ID != "proj_abc" || req.Projects[0].Role != openai.ProjectRoleMember {
+				t.Errorf("unexpected projects: %#+v", req.Projects)
+			}
+			fmt.Fprintf(w, `{"object":"organization.invite","id":"invite-abc","email":%q,"role":%q,"status":"pending"}`, req.Email, req.Role)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/organization/invites/invite-abc":
+			fmt.Fprint(w, `{"object":"organization.invite.deleted","id":"invite-abc","deleted":true}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	invite, err := c.CreateInvite(ctx, &openai.CreateInviteRequest{
+		Email:    "gopher@example.com",
+		Role:     openai.OrganizationRoleReader,
+		Projects: []openai.InviteProject{{ID: "proj_abc", Role: openai.ProjectRoleMember}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if invite.Email != "gopher@example.com" || invite.Status != openai.InviteStatusPending {
+		t.Fatalf("unexpected invite: %#+v", invite)
+	}
+
+	deleted, err := c.DeleteInvite(ctx, &openai.DeleteInviteRequest{InviteID: invite.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !deleted.Deleted {
+		t.Fatal("expected invite to be deleted")
+	}
+}
@@ -0,0 +1,277 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Agent has a conversation with an assistant on a thread of its own, created
+// when first asked, handling the assistant's tool calls with a ToolRegistry.
+//
+// Questions are asked one at a time, as a thread can only have one active
+// run. An Agent is safe for concurrent use.
+//
+// # Example
+//
+//	tools := openai.NewToolRegistry()
+//	openai.RegisterTool(tools, "get_weather", "Get the weather in a city.", getWeather)
+//
+//	agent := openai.NewAgent(client, assistant.ID, tools)
+//	defer agent.Close(ctx)
+//
+//	reply, err := agent.Ask(ctx, "What's the weather in Paris?")
+//	if err != nil {
+//		return err
+//	}
+//
+//	fmt.Println(reply.Text)
+type Agent struct {
+	client      *Client
+	assistantID string
+	tools       *ToolRegistry
+
+	// ReportErrors sends the errors of failed tool calls back to the
+	// assistant as their output, so it can recover, instead of cancelling
+	// the run and returning the error.
+	//
+	// Optional. Defaults to false.
+	ReportErrors bool
+
+	// OnToolCall is called with each tool call and its result message before
+	// the outputs are submitted, such as for logging.
+	//
+	// Optional.
+	OnToolCall func(call ToolCall, result ChatMessage)
+
+	// ResolveCitations sets the FileName of the citations of each reply,
+	// retrieving the info of the files cited.
+	//
+	// Optional. Defaults to false.
+	ResolveCitations bool
+
+	// Wait configures how runs are waited for. Its OnRequiresAction is
+	// ignored, as the agent handles tool calls itself.
+	//
+	// Optional.
+	Wait WaitForRunOptions
+
+	// mu serializes questions, and guards threadID.
+	mu       sync.Mutex
+	threadID string
+}
+
+// AgentReply is the reply of an assistant to a question asked by Agent.Ask.
+type AgentReply struct {
+	// Text is the text of the reply, with its annotations replaced by
+	// numbered citations, such as "[1]".
+	Text string
+
+	// Citations are the files cited by the reply, in order of their numbers.
+	Citations []Citation
+
+	// Run is the completed run that replied.
+	Run *Run
+}
+
+// NewAgent returns an Agent for the assistant with the given ID, handling its
+// tool calls with the given registry, which may be nil if the assistant has no
+// function tools.
+func NewAgent(c *Client, assistantID string, tools *ToolRegistry) *Agent {
+	return &Agent{client: c, assistantID: assistantID, tools: tools}
+}
+
+// ThreadID returns the ID of the agent's thread, or an empty string if it
+// hasn't been created yet.
+func (a *Agent) ThreadID() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.threadID
+}
+
+// Ask adds the text to the thread as a user message, runs the assistant on it,
+// handling its tool calls until it completes, and returns its reply. If the
+// run doesn't complete, such as when the context is done, it's cancelled.
+func (a *Agent) Ask(ctx context.Context, text string) (*AgentReply, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.threadID == "" {
+		thread, err := a.client.CreateThread(ctx, &CreateThreadRequest{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create thread: %w", err)
+		}
+		a.threadID = thread.ID
+	}
+
+	_, err := a.client.CreateMessage(ctx, &CreateMessageRequest{
+		ThreadID: a.threadID,
+		Role:     ChatRoleUser,
+		Content:  text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	run, err := a.client.CreateRun(ctx, &CreateRunRequest{
+		ThreadID:    a.threadID,
+		AssistantID: a.assistantID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run: %w", err)
+	}
+
+	opts := a.Wait
+	opts.OnRequiresAction = a.submitToolOutputs
+
+	runID := run.ID
+
+	run, err = WaitForRun(ctx, a.client, a.threadID, runID, &opts)
+	if err != nil {
+		return nil, errors.Join(err, a.cancelRun(ctx, runID, run))
+	}
+
+	return a.reply(ctx, run)
+}
+
+// Close deletes the agent's thread, if it was created. The agent can still be
+// asked questions after, on a new thread.
+func (a *Agent) Close(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.threadID == "" {
+		return nil
+	}
+
+	if err := a.client.DeleteThread(ctx, &DeleteThreadRequest{ID: a.threadID}); err != nil {
+		return err
+	}
+
+	a.threadID = ""
+	return nil
+}
+
+// cancelRun cancels the run that failed to complete, unless it's known to
+// have ended, so the thread can be asked again. The run is the last one
+// retrieved, or nil if there is none. It's cancelled even if the context is
+// done.
+func (a *Agent) cancelRun(ctx context.Context, runID string, run *Run) error {
+	if run != nil {
+		switch run.Status {
+		case RunStatusQueued, RunStatusInProgress, RunStatusRequiresAction:
+		default:
+			return nil
+		}
+	}
+
+	_, err := a.client.CancelRun(context.WithoutCancel(ctx), &CancelRunRequest{ThreadID: a.threadID, RunID: runID})
+	if err != nil {
+		return fmt.Errorf("failed to cancel run: %w", err)
+	}
+	return nil
+}
+
+// submitToolOutputs handles the tool calls the run requires, and submits
+// their outputs.
+func (a *Agent) submitToolOutputs(ctx context.Context, run *Run) error {
+	calls := run.ToolCalls()
+	if len(calls) == 0 {
+		return fmt.Errorf("run %q requires an unsupported action", run.ID)
+	}
+
+	if a.tools == nil {
+		return errors.New("run requires tool calls, but the agent has no tools")
+	}
+
+	opts := &RunToolsOptions{ReportErrors: a.ReportErrors, OnToolCall: a.OnToolCall}
+
+	outputs := make([]*AssistantToolOutput, 0, len(calls))
+	for _, call := range calls {
+		result, err := runTool(ctx, a.tools, call, opts)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, &AssistantToolOutput{CallID: call.ID, Output: result.Content})
+	}
+
+	_, err := a.client.SubmitToolOutputs(ctx, &SubmitToolOutputsRequest{
+		ThreadID:   run.ThreadID,
+		RunID:      run.ID,
+		ToolOuputs: outputs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to submit tool outputs: %w", err)
+	}
+	return nil
+}
+
+// reply returns the reply of the completed run, from the assistant messages it
+// added to the thread.
+func (a *Agent) reply(ctx context.Context, run *Run) (*AgentReply, error) {
+	resp, err := a.client.ListMessages(ctx, &ListMessagesRequest{
+		ThreadID: a.threadID,
+		Order:    SortOrderDesc,
+		Limit:    20,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages: %w", err)
+	}
+
+	var (
+		texts       []string
+		annotations []MessageAnnotation
+		offset      int
+	)
+
+	// Messages are listed newest first.
+	for _, msg := range slices.Backward(resp.Data) {
+		if msg.RunID != run.ID || msg.Role != ChatRoleAssistant {
+			continue
+		}
+
+		for _, content := range msg.Content {
+			text := content.Text()
+			if content["type"] != "text" || text == "" {
+				continue
+			}
+
+			if len(texts) > 0 {
+				offset += len("\n\n")
+			}
+			texts = append(texts, text)
+
+			// The indices of annotations are relative to their own part, so
+			// they're shifted by its offset in the joined text.
+			for _, a := range content.Annotations() {
+				a.StartIndex += offset
+				a.EndIndex += offset
+				annotations = append(annotations, a)
+			}
+			offset += utf8.RuneCountInString(text)
+		}
+	}
+
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("run %q has no reply", run.ID)
+	}
+
+	// Citations are numbered across all the text of the reply.
+	text, citations := ThreadMessageContent{
+		"type": "text",
+		"text": map[string]any{"value": strings.Join(texts, "\n\n"), "annotations": annotations},
+	}.Citations()
+
+	if a.ResolveCitations {
+		if err := a.client.ResolveCitations(ctx, citations); err != nil {
+			return nil, err
+		}
+	}
+
+	return &AgentReply{Text: text, Citations: citations, Run: run}, nil
+}
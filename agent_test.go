@@ -0,0 +1,173 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestAgent(t *testing.T) {
+	var (
+		polls   int
+		outputs []*openai.AssistantToolOutput
+	)
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/threads":
+			fmt.Fprint(w, `{"id": "thread_abc", "object": "thread"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/threads/thread_abc/messages":
+			fmt.Fprint(w, `{"id": "msg_user", "object": "thread.message", "role": "user"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/threads/thread_abc/runs":
+			fmt.Fprint(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": "queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/threads/thread_abc/runs/run_abc":
+			polls++
+			if outputs == nil {
+				fmt.Fprint(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": "requires_action", "required_action": {"type": "submit_tool_outputs", "submit_tool_outputs": {"tool_calls": [
+					{"id": "call_1", "type": "function", "function": {"name": "add", "arguments": "{\"a\": 1, \"b\": 2}"}}
+				]}}}`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": "completed"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/threads/thread_abc/runs/run_abc/submit_tool_outputs":
+			var req openai.SubmitToolOutputsRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatal(err)
+			}
+			outputs = req.ToolOuputs
+			fmt.Fprint(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": "queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/threads/thread_abc/messages":
+			fmt.Fprint(w, `{"object": "list", "data": [
+				{"id": "msg_2", "role": "assistant", "run_id": "run_abc", "content": [{"type": "text", "text": {"value": "【4:0†source】 has more.", "annotations": [
+					{"type": "file_citation", "text": "【4:0†source】", "start_index": 0, "end_index": 12, "file_citation": {"file_id": "file_abc"}}
+				]}}]},
+				{"id": "msg_1", "role": "assistant", "run_id": "run_abc", "content": [{"type": "text", "text": {"value": "1 + 2 = 3【1:0†source】.", "annotations": [
+					{"type": "file_citation", "text": "【1:0†source】", "start_index": 9, "end_index": 21, "file_citation": {"file_id": "file_abc"}}
+				]}}]},
+				{"id": "msg_user", "role": "user", "content": [{"type": "text", "text": {"value": "What's 1 + 2?", "annotations": []}}]}
+			]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/files/file_abc":
+			fmt.Fprint(w, `{"id": "file_abc", "object": "file", "filename": "notes.md"}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/threads/thread_abc":
+			fmt.Fprint(w, `{"id": "thread_abc", "deleted": true}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	tools := openai.NewToolRegistry()
+
+	type addArgs struct {
+		A int `json:"a"`
+		B int `json:"b"`
+	}
+
+	err := openai.RegisterTool(tools, "add", "Add two numbers.", func(ctx context.Context, args addArgs) (int, error) {
+		return args.A + args.B, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agent := openai.NewAgent(c, "asst_abc", tools)
+	agent.ResolveCitations = true
+	agent.Wait.Interval = time.Millisecond
+
+	reply, err := agent.Ask(testCtx(t), "What's 1 + 2?")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(outputs) != 1 || outputs[0].CallID != "call_1" || outputs[0].Output != "3" {
+		t.Fatalf("unexpected tool outputs: %+v", outputs)
+	}
+
+	if want := "1 + 2 = 3[1].\n\n[1] has more."; reply.Text != want {
+		t.Fatalf("expected reply %q, got %q", want, reply.Text)
+	}
+
+	if len(reply.Citations) != 1 || reply.Citations[0].FileName != "notes.md" {
+		t.Fatalf("unexpected citations: %+v", reply.Citations)
+	}
+
+	if reply.Run.Status != openai.RunStatusCompleted || polls != 2 {
+		t.Fatalf("unexpected run %q after %d polls", reply.Run.Status, polls)
+	}
+
+	if agent.ThreadID() != "thread_abc" {
+		t.Fatalf("unexpected thread %q", agent.ThreadID())
+	}
+
+	if err := agent.Close(testCtx(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	if agent.ThreadID() != "" {
+		t.Fatal("expected the thread to be deleted")
+	}
+}
+
+func TestAgent_CancelRun(t *testing.T) {
+	var (
+		status     string
+		cancels    int
+		cancelFail bool
+	)
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/threads":
+			fmt.Fprint(w, `{"id": "thread_abc", "object": "thread"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/threads/thread_abc/messages":
+			fmt.Fprint(w, `{"id": "msg_user", "object": "thread.message", "role": "user"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/threads/thread_abc/runs":
+			fmt.Fprint(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": "queued"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/threads/thread_abc/runs/run_abc":
+			fmt.Fprintf(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": %q}`, status)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/threads/thread_abc/runs/run_abc/cancel":
+			cancels++
+			if cancelFail {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error": {"message": "Cannot cancel run", "type": "invalid_request_error"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": "cancelling"}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	agent := openai.NewAgent(c, "asst_abc", nil)
+	agent.Wait.Interval = time.Millisecond
+	agent.Wait.Timeout = 20 * time.Millisecond
+
+	// A run still in progress when the timeout is reached is cancelled.
+	status = "in_progress"
+
+	_, err := agent.Ask(testCtx(t), "Hello?")
+	if !errors.Is(err, context.DeadlineExceeded) || cancels != 1 {
+		t.Fatalf("expected a deadline exceeded error and 1 cancel, got %v and %d", err, cancels)
+	}
+
+	// Failing to cancel it is reported too.
+	cancelFail = true
+
+	_, err = agent.Ask(testCtx(t), "Hello?")
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "failed to cancel run") || cancels != 2 {
+		t.Fatalf("expected the cancel error, got %v and %d cancels", err, cancels)
+	}
+
+	// A run that failed has ended, so isn't cancelled.
+	status = "failed"
+
+	if _, err := agent.Ask(testCtx(t), "Hello?"); err == nil || cancels != 2 {
+		t.Fatalf("expected a failed run error without cancelling, got %v and %d cancels", err, cancels)
+	}
+}
@@ -0,0 +1,673 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// AudioChunk is a chunk of an audio file split by SplitAudio.
+type AudioChunk struct {
+	// Data is the chunk, encoded as a complete file in the format of the
+	// original audio.
+	Data []byte
+
+	// Start is the time the chunk starts at in the original audio, and
+	// Duration is its length, in seconds.
+	Start, Duration float64
+}
+
+// SplitAudio splits WAV or MP3 audio into chunks of at most maxSize bytes, such
+// as to transcribe audio larger than the API accepts. WAV audio is split at the
+// quietest moment near the end of each chunk, so words aren't cut in half, and
+// MP3 audio between frames. The format is detected from the data.
+//
+// Audio that fits is returned as a single chunk, whatever its format. Larger
+// audio in other formats, such as M4A or Ogg, must be converted first, such as
+// with ffmpeg.
+func SplitAudio(data []byte, maxSize int) ([]AudioChunk, error) {
+	if len(data) <= maxSize {
+		return []AudioChunk{{Data: data}}, nil
+	}
+
+	switch {
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		return splitWAV(data, maxSize)
+	case isMP3(data):
+		return splitMP3(data, maxSize)
+	default:
+		return nil, errors.New("cannot split audio: only WAV and MP3 audio is supported")
+	}
+}
+
+// wavFormat is the format of the samples of a WAV file.
+type wavFormat struct {
+	format        uint16
+	channels      uint16
+	sampleRate    uint32
+	blockAlign    uint16
+	bitsPerSample uint16
+}
+
+// WAV sample formats.
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+// wavHeaderSize is the size of the header of each WAV chunk.
+const wavHeaderSize = 44
+
+// splitWAV splits WAV audio into chunks of at most maxSize bytes, cutting at
+// the quietest moment in the last part of each chunk.
+func splitWAV(data []byte, maxSize int) ([]AudioChunk, error) {
+	var (
+		f       wavFormat
+		hasFmt  bool
+		samples []byte
+	)
+
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		body := data[pos+8:]
+
+		// Streamed files may not know the size of their data.
+		if size > len(body) {
+			size = len(body)
+		}
+		body = body[:size]
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, errors.New("invalid WAV audio: short format chunk")
+			}
+
+			f = wavFormat{
+				format:        binary.LittleEndian.Uint16(body),
+				channels:      binary.LittleEndian.Uint16(body[2:]),
+				sampleRate:    binary.LittleEndian.Uint32(body[4:]),
+				blockAlign:    binary.LittleEndian.Uint16(body[12:]),
+				bitsPerSample: binary.LittleEndian.Uint16(body[14:]),
+			}
+
+			// The format of extensible files is the start of their sub format.
+			if f.format == wavFormatExtensible && size >= 26 {
+				f.format = binary.LittleEndian.Uint16(body[24:])
+			}
+
+			hasFmt = true
+		case "data":
+			samples = body
+		}
+
+		pos += 8 + size + size%2
+	}
+
+	if !hasFmt || samples == nil || f.blockAlign == 0 || f.sampleRate == 0 {
+		return nil, errors.New("invalid WAV audio: missing format or data")
+	}
+
+	maxFrames := (maxSize - wavHeaderSize) / int(f.blockAlign)
+	if maxFrames <= 0 {
+		return nil, fmt.Errorf("chunk size %d is too small for the WAV audio", maxSize)
+	}
+
+	frames := len(samples) / int(f.blockAlign)
+	rate := float64(f.sampleRate)
+
+	var chunks []AudioChunk
+
+	for start := 0; start < frames; {
+		end := min(start+maxFrames, frames)
+		if end < frames {
+			end = f.quietest(samples, start, end)
+		}
+
+		chunks = append(chunks, AudioChunk{
+			Data:     f.encode(samples[start*int(f.blockAlign) : end*int(f.blockAlign)]),
+			Start:    float64(start) / rate,
+			Duration: float64(end-start) / rate,
+		})
+
+		start = end
+	}
+
+	return chunks, nil
+}
+
+// quietest returns the frame in the middle of the quietest 20ms of the last
+// tenth of samples[start:end], at most 10s, to end a chunk at. It returns end
+// if the samples can't be read.
+func (f wavFormat) quietest(samples []byte, start, end int) int {
+	window := max(int(f.sampleRate)/50, 1)
+	search := min((end-start)/10, 10*int(f.sampleRate))
+
+	if search < window || f.sample(samples, 0) == nil {
+		return end
+	}
+
+	best, bestEnergy := end, math.Inf(1)
+
+	for w := end - window; w >= end-search; w -= window {
+		var energy float64
+		for i := w; i < w+window; i++ {
+			for _, v := range f.sample(samples, i) {
+				energy += v * v
+			}
+		}
+
+		if energy < bestEnergy {
+			best, bestEnergy = w+window/2, energy
+		}
+	}
+
+	return best
+}
+
+// sample returns the values of the channels of the i'th frame, between -1 and
+// 1, or nil if the format isn't supported.
+func (f wavFormat) sample(samples []byte, i int) []float64 {
+	width := int(f.bitsPerSample) / 8
+	if width == 0 || int(f.channels)*width > int(f.blockAlign) {
+		return nil
+	}
+
+	values := make([]float64, f.channels)
+	for c := range values {
+		b := samples[i*int(f.blockAlign)+c*width:]
+
+		switch {
+		case f.format == wavFormatPCM && width == 1:
+			values[c] = (float64(b[0]) - 128) / 128
+		case f.format == wavFormatPCM && width == 2:
+			values[c] = float64(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+		case f.format == wavFormatPCM && width == 3:
+			values[c] = float64(int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24)>>8) / (1 << 23)
+		case f.format == wavFormatPCM && width == 4:
+			values[c] = float64(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+		case f.format == wavFormatFloat && width == 4:
+			values[c] = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case f.format == wavFormatFloat && width == 8:
+			values[c] = math.Float64frombits(binary.LittleEndian.Uint64(b))
+		default:
+			return nil
+		}
+	}
+	return values
+}
+
+// encode returns a WAV file of the samples.
+func (f wavFormat) encode(samples []byte) []byte {
+	b := make([]byte, wavHeaderSize, wavHeaderSize+len(samples))
+
+	copy(b, "RIFF")
+	binary.LittleEndian.PutUint32(b[4:], uint32(wavHeaderSize-8+len(samples)))
+	copy(b[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(b[16:], 16)
+	binary.LittleEndian.PutUint16(b[20:], f.format)
+	binary.LittleEndian.PutUint16(b[22:], f.channels)
+	binary.LittleEndian.PutUint32(b[24:], f.sampleRate)
+	binary.LittleEndian.PutUint32(b[28:], f.sampleRate*uint32(f.blockAlign))
+	binary.LittleEndian.PutUint16(b[32:], f.blockAlign)
+	binary.LittleEndian.PutUint16(b[34:], f.bitsPerSample)
+	copy(b[36:], "data")
+	binary.LittleEndian.PutUint32(b[40:], uint32(len(samples)))
+
+	return append(b, samples...)
+}
+
+// mp3Frame is a frame of MP3 audio.
+type mp3Frame struct {
+	offset, size int
+	duration     float64
+}
+
+// MPEG audio bitrates in kbit/s, by version (MPEG-1, or MPEG-2 and 2.5), layer
+// (I, II, III) and index.
+var mp3Bitrates = [2][3][15]int{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	},
+}
+
+// MPEG audio sample rates, by version (MPEG-1, 2 and 2.5) and index.
+var mp3SampleRates = [3][3]int{
+	{44100, 48000, 32000},
+	{22050, 24000, 16000},
+	{11025, 12000, 8000},
+}
+
+// parseMP3Frame parses the header of the MP3 frame at the start of b,
+// reporting false if there isn't a valid one.
+func parseMP3Frame(b []byte) (mp3Frame, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return mp3Frame{}, false
+	}
+
+	var version int // 0 for MPEG-1, 1 for MPEG-2, 2 for MPEG-2.5.
+	switch (b[1] >> 3) & 3 {
+	case 3:
+		version = 0
+	case 2:
+		version = 1
+	case 0:
+		version = 2
+	default:
+		return mp3Frame{}, false
+	}
+
+	layer := 3 - int((b[1]>>1)&3) // 0 for layer I, 1 for II, 2 for III.
+	bitrateIndex := int(b[2] >> 4)
+	rateIndex := int((b[2] >> 2) & 3)
+
+	if layer == 3 || bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+		return mp3Frame{}, false
+	}
+
+	bitrate := mp3Bitrates[min(version, 1)][layer][bitrateIndex] * 1000
+	rate := mp3SampleRates[version][rateIndex]
+	padding := int((b[2] >> 1) & 1)
+
+	var size, samples int
+	switch {
+	case layer == 0:
+		size, samples = (12*bitrate/rate+padding)*4, 384
+	case layer == 2 && version > 0:
+		size, samples = 72*bitrate/rate+padding, 576
+	default:
+		size, samples = 144*bitrate/rate+padding, 1152
+	}
+
+	return mp3Frame{size: size, duration: float64(samples) / float64(rate)}, true
+}
+
+// isMP3 reports whether the data looks like MP3 audio.
+func isMP3(data []byte) bool {
+	if bytes.HasPrefix(data, []byte("ID3")) {
+		return true
+	}
+	_, ok := parseMP3Frame(data)
+	return ok
+}
+
+// splitMP3 splits MP3 audio into chunks of whole frames, of at most maxSize
+// bytes. Tags and the VBR header frame, whose frame count would be wrong for
+// the chunks, are left out.
+func splitMP3(data []byte, maxSize int) ([]AudioChunk, error) {
+	pos := 0
+
+	// Skip the ID3v2 tag, whose size is a 28-bit "syncsafe" integer.
+	if len(data) >= 10 && bytes.HasPrefix(data, []byte("ID3")) {
+		pos = 10 + (int(data[6]&0x7F)<<21 | int(data[7]&0x7F)<<14 | int(data[8]&0x7F)<<7 | int(data[9]&0x7F))
+		if data[5]&0x10 != 0 {
+			pos += 10
+		}
+	}
+
+	var frames []mp3Frame
+
+	for pos+4 <= len(data) {
+		f, ok := parseMP3Frame(data[pos:])
+		if !ok || pos+f.size > len(data) {
+			// Look for the next frame, past any garbage or trailing tag.
+			pos++
+			continue
+		}
+
+		f.offset = pos
+		pos += f.size
+
+		frame := data[f.offset:pos]
+		if len(frames) == 0 && (bytes.Contains(frame, []byte("Xing")) || bytes.Contains(frame, []byte("Info")) || bytes.Contains(frame, []byte("VBRI"))) {
+			continue
+		}
+
+		frames = append(frames, f)
+	}
+
+	if len(frames) == 0 {
+		return nil, errors.New("invalid MP3 audio: no frames found")
+	}
+
+	var (
+		chunks []AudioChunk
+		start  float64
+	)
+
+	for i := 0; i < len(frames); {
+		first := frames[i].offset
+
+		var duration float64
+		j := i
+		for ; j < len(frames) && frames[j].offset+frames[j].size-first <= maxSize; j++ {
+			duration += frames[j].duration
+		}
+
+		if j == i {
+			return nil, fmt.Errorf("chunk size %d is too small for the MP3 audio", maxSize)
+		}
+
+		last := frames[j-1]
+		chunks = append(chunks, AudioChunk{
+			Data:     data[first : last.offset+last.size],
+			Start:    start,
+			Duration: duration,
+		})
+
+		start += duration
+		i = j
+	}
+
+	return chunks, nil
+}
+
+// ChunkedTranscriptionOptions configures CreateChunkedAudioTranscription.
+type ChunkedTranscriptionOptions struct {
+	// ChunkSize is the maximum size of each chunk of audio sent, in bytes.
+	//
+	// Optional. Defaults to 24MB, under the API's limit of 25MB.
+	ChunkSize int
+
+	// Concurrency is the maximum number of chunks transcribed at once.
+	//
+	// Optional. Defaults to 4.
+	Concurrency int
+
+	// ContinuePrompt prompts the transcription of each chunk with the end of
+	// the transcript of the chunk before it, after the request's Prompt, so
+	// spelling and style stay consistent across chunks. As each chunk waits
+	// for the one before it, chunks are then transcribed one at a time.
+	//
+	// Optional. Defaults to false.
+	ContinuePrompt bool
+}
+
+// promptTailSize is the maximum number of bytes of the transcript of a chunk
+// used to prompt the next one, as the API only uses the last 224 tokens of a
+// prompt.
+const promptTailSize = 800
+
+// CreateChunkedAudioTranscription transcribes audio of any length, splitting
+// audio larger than the API accepts with SplitAudio and transcribing the
+// chunks concurrently. The transcripts of the chunks are joined in order, with
+// the timestamps of "verbose_json", "srt" and "vtt" transcripts offset by the
+// start of their chunk, so the response is like that of CreateAudioTranscription
+// for the whole audio.
+//
+// The file is read into memory to be split.
+//
+// # Example
+//
+//	fh, _ := os.Open("podcast.mp3")
+//	defer fh.Close()
+//
+//	resp, err := c.CreateChunkedAudioTranscription(ctx, &openai.CreateAudioTranscriptionRequest{
+//		File:           fh,
+//		Model:          openai.ModelWhisper1,
+//		ResponseFormat: openai.AudioTranscriptionFormatSRT,
+//	}, &openai.ChunkedTranscriptionOptions{ContinuePrompt: true})
+func (c *Client) CreateChunkedAudioTranscription(ctx context.Context, req *CreateAudioTranscriptionRequest, opts *ChunkedTranscriptionOptions) (CreateAudioTranscriptionResponse, error) {
+	if opts == nil {
+		opts = &ChunkedTranscriptionOptions{}
+	}
+
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = 24 << 20
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	data, err := io.ReadAll(req.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	chunks, err := SplitAudio(data, size)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CreateAudioTranscriptionResponse, len(chunks))
+
+	transcribe := func(ctx context.Context, i int, prompt string) error {
+		r := *req
+		r.File = NewAudioTranscriptableFileFromReadCloser(io.NopCloser(bytes.NewReader(chunks[i].Data)), req.File.Name())
+		r.Prompt = prompt
+
+		res, err := c.CreateAudioTranscription(ctx, &r)
+		if err != nil {
+			return fmt.Errorf("failed to transcribe chunk %d: %w", i, err)
+		}
+		results[i] = res
+		return nil
+	}
+
+	if opts.ContinuePrompt || len(chunks) == 1 {
+		for i := range chunks {
+			prompt := req.Prompt
+			if i > 0 {
+				prompt = strings.TrimSpace(prompt + " " + promptTail(transcriptText(results[i-1])))
+			}
+
+			if err := transcribe(ctx, i, prompt); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			firstErr error
+		)
+
+		sem := make(chan struct{}, concurrency)
+
+		for i := range chunks {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+
+			if ctx.Err() != nil {
+				break
+			}
+
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				if err := transcribe(ctx, i, req.Prompt); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if firstErr != nil {
+			return nil, firstErr
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(chunks) == 1 {
+		return results[0], nil
+	}
+
+	return joinTranscriptions(chunks, results), nil
+}
+
+// promptTail returns the end of the text, starting at a word, to prompt the
+// transcription of the next chunk with.
+func promptTail(text string) string {
+	if len(text) <= promptTailSize {
+		return text
+	}
+
+	text = text[len(text)-promptTailSize:]
+	if i := strings.IndexAny(text, " \n"); i >= 0 {
+		text = text[i+1:]
+	}
+	return text
+}
+
+// transcriptText returns the text of the transcription, without the cue
+// numbers and timings of subtitles.
+func transcriptText(res CreateAudioTranscriptionResponse) string {
+	switch res.(type) {
+	case *AudioTranscriptionResponseSRT, *AudioTranscriptionResponseVTT:
+	default:
+		return res.Text()
+	}
+
+	var lines []string
+	for _, cue := range subtitleCues(res.Text()) {
+		lines = append(lines, cue[1:]...)
+	}
+	return strings.Join(lines, " ")
+}
+
+// joinTranscriptions joins the transcriptions of the chunks into one.
+func joinTranscriptions(chunks []AudioChunk, results []CreateAudioTranscriptionResponse) CreateAudioTranscriptionResponse {
+	var texts []string
+	for _, res := range results {
+		if text := strings.TrimSpace(transcriptText(res)); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	text := strings.Join(texts, " ")
+
+	switch results[0].(type) {
+	case *AudioTranscriptionResponseVerboseJSON:
+		joined := &AudioTranscriptionResponseVerboseJSON{RawText: text}
+
+		for i, res := range results {
+			v := res.(*AudioTranscriptionResponseVerboseJSON)
+			offset := chunks[i].Start
+
+			if joined.Task == "" {
+				joined.Task, joined.Language = v.Task, v.Language
+			}
+			joined.Duration = offset + v.Duration
+
+			for _, w := range v.Words {
+				w.Start += offset
+				w.End += offset
+				joined.Words = append(joined.Words, w)
+			}
+
+			for _, s := range v.Segments {
+				s.ID = len(joined.Segments)
+				s.Seek += int(math.Round(offset * 100))
+				s.Start += offset
+				s.End += offset
+				joined.Segments = append(joined.Segments, s)
+			}
+		}
+
+		return joined
+	case *AudioTranscriptionResponseSRT:
+		var b strings.Builder
+
+		n := 0
+		for i, res := range results {
+			for _, cue := range subtitleCues(res.Text()) {
+				n++
+				fmt.Fprintf(&b, "%d\n%s\n%s\n\n", n, shiftTimestamps(cue[0], chunks[i].Start, ','), strings.Join(cue[1:], "\n"))
+			}
+		}
+
+		return &AudioTranscriptionResponseSRT{RawText: b.String()}
+	case *AudioTranscriptionResponseVTT:
+		var b strings.Builder
+		b.WriteString("WEBVTT\n\n")
+
+		for i, res := range results {
+			for _, cue := range subtitleCues(res.Text()) {
+				fmt.Fprintf(&b, "%s\n%s\n\n", shiftTimestamps(cue[0], chunks[i].Start, '.'), strings.Join(cue[1:], "\n"))
+			}
+		}
+
+		return &AudioTranscriptionResponseVTT{RawText: b.String()}
+	case *CreateAudioTranscriptionResponseText:
+		return &CreateAudioTranscriptionResponseText{RawText: text}
+	default:
+		return &CreateAudioTranscriptionResponseJSON{RawText: text}
+	}
+}
+
+// subtitleCues returns the cues of an SRT or WebVTT document, each as its
+// timing line followed by its lines of text. Cue numbers and identifiers,
+// headers and notes are left out.
+func subtitleCues(doc string) [][]string {
+	var cues [][]string
+
+	for _, block := range strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				cues = append(cues, lines[i:])
+				break
+			}
+		}
+	}
+
+	return cues
+}
+
+// subtitleTimestamp matches the timestamps of SRT and WebVTT cues, whose hours
+// are optional in WebVTT.
+var subtitleTimestamp = regexp.MustCompile(`(?:(\d+):)?(\d{2}):(\d{2})[,.](\d{3})`)
+
+// shiftTimestamps returns the timing line of a cue with its timestamps offset
+// by the given seconds, formatted with the given decimal separator.
+func shiftTimestamps(line string, offset float64, sep byte) string {
+	return subtitleTimestamp.ReplaceAllStringFunc(line, func(ts string) string {
+		m := subtitleTimestamp.FindStringSubmatch(ts)
+
+		var ms int
+		for i, unit := range []int{3600000, 60000, 1000, 1} {
+			n, _ := strconv.Atoi(m[i+1])
+			ms += n * unit
+		}
+		ms += int(math.Round(offset * 1000))
+
+		return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+	})
+}
@@ -0,0 +1,207 @@
+package openai_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+// testWAV returns 3s of a 16-bit mono tone, sampled at 1kHz, that is silent
+// from 1.85s to 1.9s.
+func testWAV() []byte {
+	samples := make([]byte, 3000*2)
+	for i := range 3000 {
+		v := int16(10000)
+		if i%2 == 1 {
+			v = -v
+		}
+		if i >= 1850 && i < 1900 {
+			v = 0
+		}
+		binary.LittleEndian.PutUint16(samples[i*2:], uint16(v))
+	}
+
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, uint32(36+len(samples)))
+	b.WriteString("WAVEfmt ")
+	for _, v := range []any{uint32(16), uint16(1), uint16(1), uint32(1000), uint32(2000), uint16(2), uint16(16)} {
+		binary.Write(&b, binary.LittleEndian, v)
+	}
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, uint32(len(samples)))
+	b.Write(samples)
+	return b.Bytes()
+}
+
+func TestSplitAudio_WAV(t *testing.T) {
+	wav := testWAV()
+
+	chunks, err := openai.SplitAudio(wav, 44+2000*2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+
+	// The first chunk ends in the silence, rather than at 2s.
+	if chunks[0].Duration != 1.89 || chunks[1].Start != 1.89 || chunks[1].Duration != 1.11 {
+		t.Fatalf("unexpected chunks: %v+%v, %v+%v", chunks[0].Start, chunks[0].Duration, chunks[1].Start, chunks[1].Duration)
+	}
+
+	for _, c := range chunks {
+		if !bytes.HasPrefix(c.Data, []byte("RIFF")) || !bytes.Equal(c.Data[8:36], wav[8:36]) {
+			t.Fatalf("unexpected chunk header: %q", c.Data[:44])
+		}
+
+		if size := binary.LittleEndian.Uint32(c.Data[40:]); int(size) != len(c.Data)-44 {
+			t.Fatalf("expected data size %d, got %d", len(c.Data)-44, size)
+		}
+	}
+
+	if !bytes.Equal(append(chunks[0].Data[44:], chunks[1].Data[44:]...), wav[44:]) {
+		t.Fatal("expected the chunks to hold all the samples")
+	}
+}
+
+func TestSplitAudio_MP3(t *testing.T) {
+	// An MPEG-1 Layer III frame at 128kbit/s and 44.1kHz is 417 bytes.
+	frame := func(body string) []byte {
+		b := make([]byte, 417)
+		copy(b, []byte{0xFF, 0xFB, 0x90, 0x00})
+		copy(b[36:], body)
+		return b
+	}
+
+	var mp3 bytes.Buffer
+	mp3.Write([]byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, 10})
+	mp3.Write(make([]byte, 10))
+	mp3.Write(frame("Xing"))
+	for range 10 {
+		mp3.Write(frame(""))
+	}
+	mp3.WriteString("TAG" + strings.Repeat(" ", 125))
+
+	chunks, err := openai.SplitAudio(mp3.Bytes(), 417*4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+
+	for i, want := range []int{4, 4, 2} {
+		c := chunks[i]
+
+		if len(c.Data) != want*417 || !bytes.Equal(c.Data[:417], frame("")) {
+			t.Fatalf("expected chunk %d to be %d frames, got %d bytes", i, want, len(c.Data))
+		}
+
+		if start := float64(4*i*1152) / 44100; math.Abs(c.Start-start) > 1e-9 {
+			t.Fatalf("expected chunk %d to start at %v, got %v", i, start, c.Start)
+		}
+	}
+
+	if _, err := openai.SplitAudio([]byte(strings.Repeat("not audio", 100)), 100); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
+
+func TestCreateChunkedAudioTranscription(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		prompts []string
+	)
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+
+		f, h, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(f)
+
+		if h.Filename != "speech.wav" || len(b) > 44+2000*2 {
+			t.Errorf("unexpected file %q of %d bytes", h.Filename, len(b))
+		}
+
+		mu.Lock()
+		prompts = append(prompts, r.FormValue("prompt"))
+		n := len(prompts)
+		mu.Unlock()
+
+		switch r.FormValue("response_format") {
+		case openai.AudioTranscriptionFormatSRT:
+			fmt.Fprintf(w, "1\n00:00:00,000 --> 00:00:01,000\nPart %d.\n\n", n)
+		default:
+			fmt.Fprint(w, `{"task": "transcribe", "language": "english", "duration": 1.5, "text": " Hello.", "words": [{"word": "Hello", "start": 0.5, "end": 1}], "segments": [{"id": 0, "start": 0, "end": 1, "text": " Hello."}]}`)
+		}
+	})
+
+	file := func() openai.AudioTranscriptableFile {
+		return openai.NewAudioTranscriptableFileFromReadCloser(io.NopCloser(bytes.NewReader(testWAV())), "speech.wav")
+	}
+
+	opts := &openai.ChunkedTranscriptionOptions{ChunkSize: 44 + 2000*2}
+
+	resp, err := c.CreateChunkedAudioTranscription(testCtx(t), &openai.CreateAudioTranscriptionRequest{
+		File:                   file(),
+		Model:                  openai.ModelWhisper1,
+		ResponseFormat:         openai.AudioTranscriptionFormatVerboseJSON,
+		TimestampGranularities: []string{openai.TimestampGranularityWord, openai.TimestampGranularitySegment},
+	}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := resp.(*openai.AudioTranscriptionResponseVerboseJSON)
+
+	if v.Text() != "Hello. Hello." || math.Abs(v.Duration-3.39) > 1e-9 || v.Language != "english" {
+		t.Fatalf("unexpected transcription: %q, %v, %q", v.Text(), v.Duration, v.Language)
+	}
+
+	if len(v.Segments) != 2 || v.Segments[1].ID != 1 || v.Segments[1].Start != 1.89 || math.Abs(v.Segments[1].End-2.89) > 1e-9 {
+		t.Fatalf("unexpected segments: %+v", v.Segments)
+	}
+
+	if len(v.Words) != 2 || math.Abs(v.Words[1].Start-2.39) > 1e-9 {
+		t.Fatalf("unexpected words: %+v", v.Words)
+	}
+
+	// Each chunk is prompted with the transcript of the one before it.
+	prompts = nil
+	opts.ContinuePrompt = true
+
+	resp, err = c.CreateChunkedAudioTranscription(testCtx(t), &openai.CreateAudioTranscriptionRequest{
+		File:           file(),
+		Model:          openai.ModelWhisper1,
+		Prompt:         "A greeting.",
+		ResponseFormat: openai.AudioTranscriptionFormatSRT,
+	}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(prompts) != 2 || prompts[0] != "A greeting." || prompts[1] != "A greeting. Part 1." {
+		t.Fatalf("unexpected prompts: %q", prompts)
+	}
+
+	want := "1\n00:00:00,000 --> 00:00:01,000\nPart 1.\n\n2\n00:00:01,890 --> 00:00:02,890\nPart 2.\n\n"
+	if resp.Text() != want {
+		t.Fatalf("unexpected transcript:\n%s", resp.Text())
+	}
+}
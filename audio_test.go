@@ -0,0 +1,224 @@
+package openai_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestCreateAudioTranscription_ResponseFormats(t *testing.T) {
+	tests := []struct {
+		format      string
+		contentType string
+		body        string
+		want        string
+		check       func(t *testing.T, resp openai.CreateAudioTranscriptionResponse)
+	}{
+		{
+			format:      "",
+			contentType: "application/json",
+			body:        `{"text":"Hello world."}`,
+			want:        "Hello world.",
+		},
+		{
+			format:      openai.AudioTranscriptionFormatText,
+			contentType: "text/plain",
+			body:        "Hello world.\n",
+			want:        "Hello world.",
+		},
+		{
+			format:      openai.AudioTranscriptionFormatSRT,
+			contentType: "text/plain",
+			body:        "1\n00:00:00,000 --> 00:00:01,500\nHello world.\n\n",
+			want:        "1\n00:00:00,000 --> 00:00:01,500\nHello world.\n\n",
+		},
+		{
+			format:      openai.AudioTranscriptionFormatVTT,
+			contentType: "text/vtt",
+			body:        "WEBVTT\n\n00:00:00.000 --> 00:00:01.500\nHello world.\n\n",
+			want:        "WEBVTT\n\n00:00:00.000 --> 00:00:01.500\nHello world.\n\n",
+		},
+		{
+			format:      openai.AudioTranscriptionFormatVerboseJSON,
+			contentType: "application/json",
+			body:        `{"task":"transcribe","language":"english","duration":1.5,"text":"Hello world.","segments":[{"id":0,"start":0,"end":1.5,"text":"Hello world."}]}`,
+			want:        "Hello world.",
+			check: func(t *testing.T, resp openai.CreateAudioTranscriptionResponse) {
+				v, ok := resp.(*openai.AudioTranscriptionResponseVerboseJSON)
+				if !ok {
+					t.Fatalf("expected *AudioTranscriptionResponseVerboseJSON, got %T", resp)
+				}
+				if v.Language != "english" || v.Duration != 1.5 {
+					t.Fatalf("unexpected language or duration: %q %v", v.Language, v.Duration)
+				}
+				if len(v.Segments) != 1 || v.Segments[0].End != 1.5 || v.Segments[0].Text != "Hello world." {
+					t.Fatalf("unexpected segments: %+v", v.Segments)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/audio/transcriptions" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				if err := r.ParseMultipartForm(1 << 20); err != nil {
+					t.Errorf("failed to parse form: %v", err)
+				}
+
+				if got := r.FormValue("response_format"); got != tt.format {
+					t.Errorf("expected response_format %q, got %q", tt.format, got)
+				}
+
+				w.Header().Set("Content-Type", tt.contentType)
+				io.WriteString(w, tt.body)
+			})
+
+			resp, err := c.CreateAudioTranscription(testCtx(t), &openai.CreateAudioTranscriptionRequest{
+				Model:          openai.ModelWhisper1,
+				File:           openai.NewAudioTranscriptableFileFromReadCloser(io.NopCloser(strings.NewReader("audio")), "hello.m4a"),
+				ResponseFormat: tt.format,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Text() != tt.want {
+				t.Fatalf("expected text %q, got %q", tt.want, resp.Text())
+			}
+
+			if tt.check != nil {
+				tt.check(t, resp)
+			}
+		})
+	}
+}
+
+func TestCreateAudioTranscription_TimestampGranularities(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+
+		got := r.MultipartForm.Value["timestamp_granularities[]"]
+		if len(got) != 2 || got[0] != "word" || got[1] != "segment" {
+			t.Errorf("unexpected timestamp_granularities[]: %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{
+			"task": "transcribe",
+			"language": "english",
+			"duration": 1.2,
+			"text": "Hello world.",
+			"words": [
+				{"word": "Hello", "start": 0.0, "end": 0.5},
+				{"word": "world", "start": 0.6, "end": 1.2}
+			],
+			"segments": [
+				{"id": 0, "seek": 0, "start": 0.0, "end": 1.2, "text": "Hello world.", "tokens": [1, 2], "avg_logprob": -0.2, "no_speech_prob": 0.01}
+			]
+		}`)
+	})
+
+	resp, err := c.CreateAudioTranscription(testCtx(t), &openai.CreateAudioTranscriptionRequest{
+		Model:                  openai.ModelWhisper1,
+		File:                   openai.NewAudioTranscriptableFileFromReadCloser(io.NopCloser(strings.NewReader("audio")), "hello.m4a"),
+		ResponseFormat:         openai.AudioTranscriptionFormatVerboseJSON,
+		TimestampGranularities: []string{openai.TimestampGranularityWord, openai.TimestampGranularitySegment},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := resp.(*openai.AudioTranscriptionResponseVerboseJSON)
+
+	if len(v.Words) != 2 || v.Words[1].Word != "world" || v.Words[1].Start != 0.6 || v.Words[1].End != 1.2 {
+		t.Fatalf("unexpected words: %+v", v.Words)
+	}
+
+	if len(v.Segments) != 1 || v.Segments[0].AvgLogprob != -0.2 || len(v.Segments[0].Tokens) != 2 {
+		t.Fatalf("unexpected segments: %+v", v.Segments)
+	}
+}
+
+func TestCreateSpeechStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		format openai.SpeechStreamFormat
+		body   string
+	}{
+		{
+			name: "audio",
+			body: "chunk-1chunk-2",
+		},
+		{
+			name:   "sse",
+			format: openai.SpeechStreamFormatSSE,
+			body: "data: {\"type\":\"speech.audio.delta\",\"audio\":\"Y2h1bmstMQ==\"}\n\n" +
+				"data: {\"type\":\"speech.audio.delta\",\"audio\":\"Y2h1bmstMg==\"}\n\n" +
+				"data: {\"type\":\"speech.audio.done\",\"usage\":{\"input_tokens\":3,\"output_tokens\":10,\"total_tokens\":13}}\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, tt.body)
+			})
+
+			var (
+				audio    strings.Builder
+				progress []int64
+			)
+
+			n, err := c.CreateSpeechStream(testCtx(t), &openai.CreateSpeechRequest{
+				Model:        "gpt-4o-mini-tts",
+				Input:        "Hello!",
+				Voice:        openai.SpeechVoiceAlloy,
+				StreamFormat: tt.format,
+			}, &audio, func(written int64) {
+				progress = append(progress, written)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if audio.String() != "chunk-1chunk-2" || n != 14 {
+				t.Fatalf("unexpected audio: %q (%d bytes)", audio.String(), n)
+			}
+
+			if len(progress) == 0 || progress[len(progress)-1] != 14 {
+				t.Fatalf("unexpected progress: %v", progress)
+			}
+		})
+	}
+}
+
+func TestCreateSpeech_Instructions(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(b), `"instructions":"Speak like a pirate."`) {
+			t.Errorf("expected instructions in request: %s", b)
+		}
+		io.WriteString(w, "audio")
+	})
+
+	audio, err := c.CreateSpeech(testCtx(t), &openai.CreateSpeechRequest{
+		Model:        openai.ModelGPT4oMiniTTS,
+		Input:        "Ahoy!",
+		Voice:        openai.SpeechVoiceCoral,
+		Instructions: "Speak like a pirate.",
+		Speed:        1.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	audio.Close()
+}
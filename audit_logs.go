@@ -0,0 +1,312 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AuditLogEventType is the type of event recorded in an audit log.
+//
+// https://platform.openai.com/docs/api-reference/audit-logs/object#audit-logs/object-type
+type AuditLogEventType string
+
+const (
+	AuditLogEventAPIKeyCreated         AuditLogEventType = "api_key.created"
+	AuditLogEventAPIKeyUpdated         AuditLogEventType = "api_key.updated"
+	AuditLogEventAPIKeyDeleted         AuditLogEventType = "api_key.deleted"
+	AuditLogEventInviteSent            AuditLogEventType = "invite.sent"
+	AuditLogEventInviteAccepted        AuditLogEventType = "invite.accepted"
+	AuditLogEventInviteDeleted         AuditLogEventType = "invite.deleted"
+	AuditLogEventLoginSucceeded        AuditLogEventType = "login.succeeded"
+	AuditLogEventLoginFailed           AuditLogEventType = "login.failed"
+	AuditLogEventLogoutSucceeded       AuditLogEventType = "logout.succeeded"
+	AuditLogEventLogoutFailed          AuditLogEventType = "logout.failed"
+	AuditLogEventOrganizationUpdated   AuditLogEventType = "organization.updated"
+	AuditLogEventProjectCreated        AuditLogEventType = "project.created"
+	AuditLogEventProjectUpdated        AuditLogEventType = "project.updated"
+	AuditLogEventProjectArchived       AuditLogEventType = "project.archived"
+	AuditLogEventRateLimitUpdated      AuditLogEventType = "rate_limit.updated"
+	AuditLogEventRateLimitDeleted      AuditLogEventType = "rate_limit.deleted"
+	AuditLogEventServiceAccountCreated AuditLogEventType = "service_account.created"
+	AuditLogEventServiceAccountUpdated AuditLogEventType = "service_account.updated"
+	AuditLogEventServiceAccountDeleted AuditLogEventType = "service_account.deleted"
+	AuditLogEventUserAdded             AuditLogEventType = "user.added"
+	AuditLogEventUserUpdated           AuditLogEventType = "user.updated"
+	AuditLogEventUserDeleted           AuditLogEventType = "user.deleted"
+)
+
+// AuditLogActorUser is the user behind an audit log actor.
+type AuditLogActorUser struct {
+	ID    string `json:"id"`
+	Email string `json:"email,omitempty"`
+}
+
+// AuditLogActorSession is the browser session that performed an audited action.
+//
+// https://platform.openai.com/docs/api-reference/audit-logs/object#audit-logs/object-actor-session
+type AuditLogActorSession struct {
+	User      AuditLogActorUser `json:"user"`
+	IPAddress string            `json:"ip_address,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+}
+
+// AuditLogActorAPIKey is the API key that performed an audited action.
+//
+// https://platform.openai.com/docs/api-reference/audit-logs/object#audit-logs/object-actor-api_key
+type AuditLogActorAPIKey struct {
+	ID string `json:"id"`
+
+	// Type is either "user" or "service_account".
+	Type string `json:"type"`
+
+	User           *AuditLogActorUser `json:"user,omitempty"`
+	ServiceAccount *struct {
+		ID string `json:"id"`
+	} `json:"service_account,omitempty"`
+}
+
+// AuditLogActor is the user or API key that performed an audited action.
+//
+// https://platform.openai.com/docs/api-reference/audit-logs/object#audit-logs/object-actor
+type AuditLogActor struct {
+	// Type is either "session" or "api_key".
+	Type string `json:"type"`
+
+	Session *AuditLogActorSession `json:"session,omitempty"`
+	APIKey  *AuditLogActorAPIKey  `json:"api_key,omitempty"`
+}
+
+// AuditLogProject is the project an audited action was scoped to.
+type AuditLogProject struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// AuditLogResource is the payload for events that only identify the affected
+// resource, such as deletions.
+type AuditLogResource struct {
+	ID string `json:"id"`
+}
+
+// AuditLogAPIKeyCreated is the payload of an "api_key.created" event.
+type AuditLogAPIKeyCreated struct {
+	ID   string `json:"id"`
+	Data struct {
+		Scopes []string `json:"scopes,omitempty"`
+	} `json:"data"`
+}
+
+// AuditLogAPIKeyUpdated is the payload of an "api_key.updated" event.
+type AuditLogAPIKeyUpdated struct {
+	ID               string `json:"id"`
+	ChangesRequested struct {
+		Scopes []string `json:"scopes,omitempty"`
+	} `json:"changes_requested"`
+}
+
+// AuditLogInviteSent is the payload of an "invite.sent" event.
+type AuditLogInviteSent struct {
+	ID   string `json:"id"`
+	Data struct {
+		Email string `json:"email"`
+		Role  string `json:"role,omitempty"`
+	} `json:"data"`
+}
+
+// AuditLogFailure is the payload of "login.failed" and "logout.failed" events.
+type AuditLogFailure struct {
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// AuditLogOrganizationUpdated is the payload of an "organization.updated" event.
+type AuditLogOrganizationUpdated struct {
+	ID               string `json:"id"`
+	ChangesRequested struct {
+		Title       string `json:"title,omitempty"`
+		Description string `json:"description,omitempty"`
+		Name        string `json:"name,omitempty"`
+		Settings    *struct {
+			ThreadsUIVisibility      string `json:"threads_ui_visibility,omitempty"`
+			UsageDashboardVisibility string `json:"usage_dashboard_visibility,omitempty"`
+		} `json:"settings,omitempty"`
+	} `json:"changes_requested"`
+}
+
+// AuditLogProjectCreated is the payload of a "project.created" event.
+type AuditLogProjectCreated struct {
+	ID   string `json:"id"`
+	Data struct {
+		Name  string `json:"name"`
+		Title string `json:"title,omitempty"`
+	} `json:"data"`
+}
+
+// AuditLogProjectUpdated is the payload of a "project.updated" event.
+type AuditLogProjectUpdated struct {
+	ID               string `json:"id"`
+	ChangesRequested struct {
+		Title string `json:"title,omitempty"`
+	} `json:"changes_requested"`
+}
+
+// AuditLogRateLimitUpdated is the payload of a "rate_limit.updated" event.
+type AuditLogRateLimitUpdated struct {
+	ID               string         `json:"id"`
+	ChangesRequested map[string]int `json:"changes_requested"`
+}
+
+// AuditLogRoleAssigned is the payload of "user.added" and
+// "service_account.created" events.
+type AuditLogRoleAssigned struct {
+	ID   string `json:"id"`
+	Data struct {
+		Role string `json:"role"`
+	} `json:"data"`
+}
+
+// AuditLogRoleChanged is the payload of "user.updated" and
+// "service_account.updated" events.
+type AuditLogRoleChanged struct {
+	ID               string `json:"id"`
+	ChangesRequested struct {
+		Role string `json:"role"`
+	} `json:"changes_requested"`
+}
+
+// AuditLog is a single entry in the organization audit log. Exactly one of the
+// event payload fields is set, matching Type.
+//
+// https://platform.openai.com/docs/api-reference/audit-logs/object
+type AuditLog struct {
+	ID          string            `json:"id"`
+	Type        AuditLogEventType `json:"type"`
+	EffectiveAt int               `json:"effective_at"`
+	Project     *AuditLogProject  `json:"project,omitempty"`
+	Actor       AuditLogActor     `json:"actor"`
+
+	APIKeyCreated         *AuditLogAPIKeyCreated       `json:"api_key.created,omitempty"`
+	APIKeyUpdated         *AuditLogAPIKeyUpdated       `json:"api_key.updated,omitempty"`
+	APIKeyDeleted         *AuditLogResource            `json:"api_key.deleted,omitempty"`
+	InviteSent            *AuditLogInviteSent          `json:"invite.sent,omitempty"`
+	InviteAccepted        *AuditLogResource            `json:"invite.accepted,omitempty"`
+	InviteDeleted         *AuditLogResource            `json:"invite.deleted,omitempty"`
+	LoginFailed           *AuditLogFailure             `json:"login.failed,omitempty"`
+	LogoutFailed          *AuditLogFailure             `json:"logout.failed,omitempty"`
+	OrganizationUpdated   *AuditLogOrganizationUpdated `json:"organization.updated,omitempty"`
+	ProjectCreated        *AuditLogProjectCreated      `json:"project.created,omitempty"`
+	ProjectUpdated        *AuditLogProjectUpdated      `json:"project.updated,omitempty"`
+	ProjectArchived       *AuditLogResource            `json:"project.archived,omitempty"`
+	RateLimitUpdated      *AuditLogRateLimitUpdated    `json:"rate_limit.updated,omitempty"`
+	RateLimitDeleted      *AuditLogResource            `json:"rate_limit.deleted,omitempty"`
+	ServiceAccountCreated *AuditLogRoleAssigned        `json:"service_account.created,omitempty"`
+	ServiceAccountUpdated *AuditLogRoleChanged         `json:"service_account.updated,omitempty"`
+	ServiceAccountDeleted *AuditLogResource            `json:"service_account.deleted,omitempty"`
+	UserAdded             *AuditLogRoleAssigned        `json:"user.added,omitempty"`
+	UserUpdated           *AuditLogRoleChanged         `json:"user.updated,omitempty"`
+	UserDeleted           *AuditLogResource            `json:"user.deleted,omitempty"`
+}
+
+// AuditLogTimeRange filters audit logs by their effective_at Unix timestamp.
+// Zero fields are ignored.
+//
+// https://platform.openai.com/docs/api-reference/audit-logs/list#audit-logs-list-effective_at
+type AuditLogTimeRange struct {
+	GT  int
+	GTE int
+	LT  int
+	LTE int
+}
+
+// https://platform.openai.com/docs/api-reference/audit-logs/list
+type ListAuditLogsRequest struct {
+	// Optional.
+	EffectiveAt *AuditLogTimeRange `json:"-"`
+
+	// Optional.
+	ProjectIDs []string `json:"-"`
+
+	// Optional.
+	EventTypes []AuditLogEventType `json:"-"`
+
+	// Optional.
+	ActorIDs []string `json:"-"`
+
+	// Optional.
+	ActorEmails []string `json:"-"`
+
+	// Optional.
+	ResourceIDs []string `json:"-"`
+
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// Optional.
+	After string `json:"-"`
+
+	// Optional.
+	Before string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/audit-logs/list
+type ListAuditLogsResponse = Page[AuditLog]
+
+// ListAuditLogs lists the organization audit logs matching the given filters.
+// Use NextPage or All on the response to page through results.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/audit-logs/list
+func (c *Client) ListAuditLogs(ctx context.Context, req *ListAuditLogsRequest) (*ListAuditLogsResponse, error) {
+	q := url.Values{}
+
+	if r := req.EffectiveAt; r != nil {
+		for op, v := range map[string]int{"gt": r.GT, "gte": r.GTE, "lt": r.LT, "lte": r.LTE} {
+			if v != 0 {
+				q.Set("effective_at["+op+"]", strconv.Itoa(v))
+			}
+		}
+	}
+
+	for key, values := range map[string][]string{
+		"project_ids[]":  req.ProjectIDs,
+		"actor_ids[]":    req.ActorIDs,
+		"actor_emails[]": req.ActorEmails,
+		"resource_ids[]": req.ResourceIDs,
+	} {
+		for _, v := range values {
+			q.Add(key, v)
+		}
+	}
+
+	for _, t := range req.EventTypes {
+		q.Add("event_types[]", string(t))
+	}
+
+	if req.Limit != 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if req.After != "" {
+		q.Set("after", req.After)
+	}
+
+	if req.Before != "" {
+		q.Set("before", req.Before)
+	}
+
+	path := "/organization/audit_logs"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var res ListAuditLogsResponse
+	err := c.do(ctx, http.MethodGet, path, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListAuditLogsRequest, after string) { r.After = after }, c.ListAuditLogs)
+	return &res, nil
+}
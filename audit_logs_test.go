@@ -0,0 +1,73 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestListAuditLogs(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/organization/audit_logs" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		q := r.URL.Query()
+		if q.Get("effective_at[gte]") != "1720000000" || q.Get("effective_at[gt]") != "" {
+			t.Errorf("unexpected effective_at filter: %s", r.URL.RawQuery)
+		}
+		if len(q["event_types[]"]) != 2 || q.Get("project_ids[]") != "proj_abc" || q.Get("after") != "audit_log-prev" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{
+			"object": "list",
+			"data": [
+				{
+					"id": "audit_log-1",
+					"type": "api_key.created",
+					"effective_at": 1720804090,
+					"project": {"id": "proj_abc", "name": "Default"},
+					"actor": {"type": "session", "session": {"user": {"id": "user-abc", "email": "user@example.com"}, "ip_address": "127.0.0.1"}},
+					"api_key.created": {"id": "key_abc", "data": {"scopes": ["resource.operation"]}}
+				},
+				{
+					"id": "audit_log-2",
+					"type": "login.failed",
+					"effective_at": 1720804100,
+					"actor": {"type": "api_key", "api_key": {"id": "key_def", "type": "service_account", "service_account": {"id": "svc_acct_abc"}}},
+					"login.failed": {"error_code": "invalid_credentials", "error_message": "Invalid credentials"}
+				}
+			],
+			"first_id": "audit_log-1",
+			"last_id": "audit_log-2",
+			"has_more": true
+		}`)
+	})
+
+	resp, err := c.ListAuditLogs(testCtx(t), &openai.ListAuditLogsRequest{
+		EffectiveAt: &openai.AuditLogTimeRange{GTE: 1720000000},
+		ProjectIDs:  []string{"proj_abc"},
+		EventTypes:  []openai.AuditLogEventType{openai.AuditLogEventAPIKeyCreated, openai.AuditLogEventLoginFailed},
+		After:       "audit_log-prev",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data) != 2 || !resp.HasMore || resp.LastID != "audit_log-2" {
+		t.Fatalf("unexpected response: %#+v", resp)
+	}
+
+	created := resp.Data[0]
+	if created.APIKeyCreated == nil || created.APIKeyCreated.ID != "key_abc" || created.Actor.Session.User.Email != "user@example.com" {
+		t.Fatalf("unexpected api_key.created event: %#+v", created)
+	}
+
+	failed := resp.Data[1]
+	if failed.LoginFailed == nil || failed.LoginFailed.ErrorCode != "invalid_credentials" || failed.Actor.APIKey.ServiceAccount.ID != "svc_acct_abc" {
+		t.Fatalf("unexpected login.failed event: %#+v", failed)
+	}
+}
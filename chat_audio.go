@@ -0,0 +1,93 @@
+package openai
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// ChatModality is a type of output a chat model can generate.
+//
+// https://platform.openai.com/docs/api-reference/chat/create#chat-create-modalities
+type ChatModality string
+
+const (
+	ChatModalityText  ChatModality = "text"
+	ChatModalityAudio ChatModality = "audio"
+)
+
+// ChatAudioFormat is the format of audio given to or generated by a chat model.
+type ChatAudioFormat string
+
+const (
+	ChatAudioFormatWAV   ChatAudioFormat = "wav"
+	ChatAudioFormatMP3   ChatAudioFormat = "mp3"
+	ChatAudioFormatFLAC  ChatAudioFormat = "flac"
+	ChatAudioFormatOpus  ChatAudioFormat = "opus"
+	ChatAudioFormatPCM16 ChatAudioFormat = "pcm16"
+)
+
+// ChatAudioParams configures the audio output of a chat model, requested with
+// the "audio" modality.
+//
+// https://platform.openai.com/docs/api-reference/chat/create#chat-create-audio
+type ChatAudioParams struct {
+	// Voice is the voice the model speaks with.
+	//
+	// Required.
+	Voice SpeechVoice `json:"voice"`
+
+	// Format is the format of the generated audio.
+	//
+	// Required.
+	Format ChatAudioFormat `json:"format"`
+}
+
+// ChatInputAudio is the audio of an "input_audio" content part.
+type ChatInputAudio struct {
+	// Data is the base64 encoded audio data.
+	//
+	// Required.
+	Data string `json:"data"`
+
+	// Format is the format of the audio, either "wav" or "mp3".
+	//
+	// Required.
+	Format ChatAudioFormat `json:"format"`
+}
+
+// AudioPart returns an audio content part with the given audio data, for models
+// with audio input, such as gpt-4o-audio-preview.
+func AudioPart(data []byte, format ChatAudioFormat) ChatContentPart {
+	return ChatContentPart{Type: ChatContentPartInputAudio, InputAudio: &ChatInputAudio{
+		Data:   base64.StdEncoding.EncodeToString(data),
+		Format: format,
+	}}
+}
+
+// ChatMessageAudio is the audio of an assistant message generated with the
+// "audio" modality.
+//
+// https://platform.openai.com/docs/api-reference/chat/object#chat/object-choices
+type ChatMessageAudio struct {
+	// ID identifies the audio, to refer to it in later turns of the conversation.
+	ID string `json:"id"`
+
+	// Data is the base64 encoded audio data, in the requested format.
+	Data string `json:"data,omitempty"`
+
+	// ExpiresAt is the Unix timestamp after which the audio can no longer be
+	// referred to by its ID.
+	ExpiresAt int `json:"expires_at,omitempty"`
+
+	// Transcript is the transcript of the audio.
+	Transcript string `json:"transcript,omitempty"`
+}
+
+// Bytes returns the decoded audio data.
+func (a *ChatMessageAudio) Bytes() ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(a.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode audio %q: %w", a.ID, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,75 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestCreateChat_Audio(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Modalities []string                `json:"modalities"`
+			Audio      *openai.ChatAudioParams `json:"audio"`
+			Messages   []json.RawMessage       `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Join(body.Modalities, ",") != "text,audio" || body.Audio == nil || body.Audio.Voice != openai.SpeechVoiceAlloy {
+			t.Errorf("unexpected audio request: %+v", body)
+		}
+
+		if got := string(body.Messages[0]); !strings.Contains(got, `{"type":"input_audio","input_audio":{"data":"UklGRg==","format":"wav"}}`) {
+			t.Errorf("unexpected audio message: %s", got)
+		}
+
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":null,"audio":{"id":"audio_1","data":"SGVsbG8=","expires_at":1729018505,"transcript":"Hello!"}}}]}`)
+	}
+
+	c := testClient(t, h)
+
+	resp, err := c.CreateChat(testCtx(t), &openai.CreateChatRequest{
+		Model:      "gpt-4o-audio-preview",
+		Modalities: []openai.ChatModality{openai.ChatModalityText, openai.ChatModalityAudio},
+		Audio:      &openai.ChatAudioParams{Voice: openai.SpeechVoiceAlloy, Format: openai.ChatAudioFormatWAV},
+		Messages: []openai.ChatMessage{
+			openai.UserParts(openai.AudioPart([]byte("RIFF"), openai.ChatAudioFormatWAV)),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := resp.FirstChoice()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Audio == nil || msg.Audio.Transcript != "Hello!" {
+		t.Fatalf("unexpected audio: %+v", msg.Audio)
+	}
+
+	audio, err := msg.Audio.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(audio) != "Hello" {
+		t.Fatalf("unexpected audio data: %q", audio)
+	}
+
+	// The audio is referred to by its ID in later turns.
+	b, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `{"role":"assistant","content":null,"audio":{"id":"audio_1"}}` {
+		t.Fatalf("unexpected message JSON: %s", b)
+	}
+}
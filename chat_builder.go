@@ -0,0 +1,281 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ChatRequestBuilder builds a CreateChatRequest with a fluent API, validating
+// the request when Build is called. Use Chat to create one.
+type ChatRequestBuilder struct {
+	req CreateChatRequest
+	err error
+}
+
+// Chat returns a new ChatRequestBuilder for the given model.
+//
+// # Example
+//
+//	req, err := openai.Chat(openai.ModelGPT35Turbo).
+//		System("You are a helpful assistant.").
+//		User("Hello!").
+//		Temperature(0.2).
+//		Build()
+func Chat(model string) *ChatRequestBuilder {
+	return &ChatRequestBuilder{
+		req: CreateChatRequest{
+			Model: model,
+		},
+	}
+}
+
+// fail records the first error encountered while building, to be returned by Build.
+func (b *ChatRequestBuilder) fail(format string, args ...any) *ChatRequestBuilder {
+	if b.err == nil {
+		b.err = fmt.Errorf(format, args...)
+	}
+	return b
+}
+
+// Message appends the given messages to the conversation.
+func (b *ChatRequestBuilder) Message(msgs ...ChatMessage) *ChatRequestBuilder {
+	b.req.Messages = append(b.req.Messages, msgs...)
+	return b
+}
+
+// System appends a system message to the conversation.
+func (b *ChatRequestBuilder) System(content string) *ChatRequestBuilder {
+	return b.Message(System(content))
+}
+
+// User appends a user message to the conversation.
+func (b *ChatRequestBuilder) User(content string) *ChatRequestBuilder {
+	return b.Message(User(content))
+}
+
+// Assistant appends an assistant message to the conversation.
+func (b *ChatRequestBuilder) Assistant(content string) *ChatRequestBuilder {
+	return b.Message(AssistantMsg(content))
+}
+
+// Tool adds a function tool the model can call.
+func (b *ChatRequestBuilder) Tool(fn *Function) *ChatRequestBuilder {
+	if fn == nil || fn.Name == "" {
+		return b.fail("tool function must have a name")
+	}
+
+	for _, existing := range b.req.Tools {
+		if existing.Function != nil && existing.Function.Name == fn.Name {
+			return b.fail("duplicate tool function %q", fn.Name)
+		}
+	}
+
+	b.req.Tools = append(b.req.Tools, FunctionTool(fn))
+	return b
+}
+
+// ToolChoice controls which, if any, of the tools added with Tool the model calls.
+func (b *ChatRequestBuilder) ToolChoice(choice ToolChoice) *ChatRequestBuilder {
+	b.req.ToolChoice = choice
+	return b
+}
+
+// Function adds a function the model can call, using the legacy functions
+// parameter. Prefer Tool for models that support tool calling.
+func (b *ChatRequestBuilder) Function(fn *Function) *ChatRequestBuilder {
+	if fn == nil || fn.Name == "" {
+		return b.fail("function must have a name")
+	}
+
+	for _, existing := range b.req.Functions {
+		if existing.Name == fn.Name {
+			return b.fail("duplicate function %q", fn.Name)
+		}
+	}
+
+	b.req.Functions = append(b.req.Functions, fn)
+	return b
+}
+
+// FunctionCall controls how the model calls the functions added with Function.
+func (b *ChatRequestBuilder) FunctionCall(control FunctionCallControl) *ChatRequestBuilder {
+	b.req.FunctionCall = control
+	return b
+}
+
+// Temperature sets the sampling temperature, between 0 and 2.
+func (b *ChatRequestBuilder) Temperature(t float64) *ChatRequestBuilder {
+	if t < 0 || t > 2 {
+		return b.fail("temperature must be between 0 and 2, got %v", t)
+	}
+	b.req.Temperature = t
+	return b
+}
+
+// TopP sets the nucleus sampling probability mass, between 0 and 1.
+func (b *ChatRequestBuilder) TopP(p float64) *ChatRequestBuilder {
+	if p < 0 || p > 1 {
+		return b.fail("top_p must be between 0 and 1, got %v", p)
+	}
+	b.req.TopP = p
+	return b
+}
+
+// N sets the number of choices to generate.
+func (b *ChatRequestBuilder) N(n int) *ChatRequestBuilder {
+	if n < 1 {
+		return b.fail("n must be at least 1, got %d", n)
+	}
+	b.req.N = n
+	return b
+}
+
+// MaxTokens sets the maximum number of tokens to generate.
+func (b *ChatRequestBuilder) MaxTokens(n int) *ChatRequestBuilder {
+	if n < 1 {
+		return b.fail("max_tokens must be at least 1, got %d", n)
+	}
+	b.req.MaxTokens = n
+	return b
+}
+
+// MaxCompletionTokens sets the maximum number of tokens to generate, including
+// reasoning tokens.
+func (b *ChatRequestBuilder) MaxCompletionTokens(n int) *ChatRequestBuilder {
+	if n < 1 {
+		return b.fail("max_completion_tokens must be at least 1, got %d", n)
+	}
+	b.req.MaxCompletionTokens = n
+	return b
+}
+
+// ReasoningEffort sets how much a reasoning model thinks before it answers.
+func (b *ChatRequestBuilder) ReasoningEffort(effort ReasoningEffort) *ChatRequestBuilder {
+	b.req.ReasoningEffort = effort
+	return b
+}
+
+// Stop sets up to 4 sequences where the model will stop generating tokens.
+func (b *ChatRequestBuilder) Stop(sequences ...string) *ChatRequestBuilder {
+	if len(sequences) > 4 {
+		return b.fail("at most 4 stop sequences are allowed, got %d", len(sequences))
+	}
+	b.req.Stop = sequences
+	return b
+}
+
+// PresencePenalty sets the presence penalty, between -2 and 2.
+func (b *ChatRequestBuilder) PresencePenalty(p float64) *ChatRequestBuilder {
+	if p < -2 || p > 2 {
+		return b.fail("presence_penalty must be between -2 and 2, got %v", p)
+	}
+	b.req.PresencePenalty = p
+	return b
+}
+
+// FrequencyPenalty sets the frequency penalty, between -2 and 2.
+func (b *ChatRequestBuilder) FrequencyPenalty(p float64) *ChatRequestBuilder {
+	if p < -2 || p > 2 {
+		return b.fail("frequency_penalty must be between -2 and 2, got %v", p)
+	}
+	b.req.FrequencyPenalty = p
+	return b
+}
+
+// LogitBias sets the bias for the given token ID.
+func (b *ChatRequestBuilder) LogitBias(token string, bias float64) *ChatRequestBuilder {
+	if bias < -100 || bias > 100 {
+		return b.fail("logit bias must be between -100 and 100, got %v", bias)
+	}
+	if b.req.LogitBias == nil {
+		b.req.LogitBias = map[string]float64{}
+	}
+	b.req.LogitBias[token] = bias
+	return b
+}
+
+// Seed sets the seed for best-effort deterministic sampling.
+func (b *ChatRequestBuilder) Seed(seed int) *ChatRequestBuilder {
+	b.req.Seed = &seed
+	return b
+}
+
+// Logprobs requests the log probabilities of the output tokens, along with the
+// given number of most likely alternatives, between 0 and 20, at each position.
+func (b *ChatRequestBuilder) Logprobs(top int) *ChatRequestBuilder {
+	if top < 0 || top > 20 {
+		return b.fail("top_logprobs must be between 0 and 20, got %d", top)
+	}
+	b.req.Logprobs = true
+	b.req.TopLogprobs = top
+	return b
+}
+
+// EndUser sets the unique identifier of the end-user the request is made on
+// behalf of.
+func (b *ChatRequestBuilder) EndUser(id string) *ChatRequestBuilder {
+	b.req.User = id
+	return b
+}
+
+// ResponseFormat sets the format the model must output, such as a JSON schema
+// returned by JSONSchemaFormat.
+func (b *ChatRequestBuilder) ResponseFormat(format *ChatResponseFormat) *ChatRequestBuilder {
+	if format != nil && format.Type == ChatResponseFormatJSONSchema && (format.JSONSchema == nil || format.JSONSchema.Name == "") {
+		return b.fail("json_schema response format must have a named schema")
+	}
+	b.req.ResponseFormat = format
+	return b
+}
+
+// Stream enables streaming the response.
+func (b *ChatRequestBuilder) Stream() *ChatRequestBuilder {
+	b.req.Stream = true
+	return b
+}
+
+// Build validates and returns the request.
+func (b *ChatRequestBuilder) Build() (*CreateChatRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	if b.req.Model == "" {
+		return nil, errors.New("model is required")
+	}
+
+	if len(b.req.Messages) == 0 {
+		return nil, errors.New("at least one message is required")
+	}
+
+	if b.req.FunctionCall != nil && len(b.req.Functions) == 0 {
+		return nil, errors.New("function call control requires at least one function")
+	}
+
+	if choice, ok := b.req.ToolChoice.(ToolChoiceFunction); ok && !b.hasTool(string(choice)) {
+		return nil, fmt.Errorf("tool choice %q is not a tool", string(choice))
+	}
+
+	// Copy the slices and maps, so requests built later, or changes to this
+	// one, don't affect each other.
+	req := b.req
+	req.Messages = slices.Clone(b.req.Messages)
+	req.Stop = slices.Clone(b.req.Stop)
+	req.Modalities = slices.Clone(b.req.Modalities)
+	req.Functions = slices.Clone(b.req.Functions)
+	req.Tools = slices.Clone(b.req.Tools)
+	req.LogitBias = maps.Clone(b.req.LogitBias)
+	return &req, nil
+}
+
+// hasTool reports whether a function tool with the given name has been added.
+func (b *ChatRequestBuilder) hasTool(name string) bool {
+	for _, tool := range b.req.Tools {
+		if tool.Function != nil && tool.Function.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package openai_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestChatRequestBuilder(t *testing.T) {
+	req, err := openai.Chat(openai.ModelGPT35Turbo).
+		System("You are a helpful assistant.").
+		User("What's the weather in Boston?").
+		Tool(&openai.Function{
+			Name: "get_weather",
+			Parameters: &openai.JSONSchema{
+				Type: "object",
+			},
+		}).
+		ToolChoice(openai.ToolChoiceFunction("get_weather")).
+		Temperature(0.2).
+		MaxTokens(100).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if req.Model != openai.ModelGPT35Turbo || len(req.Messages) != 2 || req.Messages[0].Role != openai.ChatRoleSystem {
+		t.Fatalf("unexpected request: %#+v", req)
+	}
+
+	if len(req.Tools) != 1 || req.Tools[0].Type != openai.ToolTypeFunction || req.Temperature != 0.2 || req.MaxTokens != 100 {
+		t.Fatalf("unexpected request: %#+v", req)
+	}
+
+	tests := map[string]*openai.ChatRequestBuilder{
+		"no model":              openai.Chat("").User("hi"),
+		"no messages":           openai.Chat(openai.ModelGPT35Turbo),
+		"temperature":           openai.Chat(openai.ModelGPT35Turbo).User("hi").Temperature(3),
+		"too many stops":        openai.Chat(openai.ModelGPT35Turbo).User("hi").Stop("a", "b", "c", "d", "e"),
+		"unnamed tool":          openai.Chat(openai.ModelGPT35Turbo).User("hi").Tool(&openai.Function{}),
+		"duplicate tool":        openai.Chat(openai.ModelGPT35Turbo).User("hi").Tool(&openai.Function{Name: "a"}).Tool(&openai.Function{Name: "a"}),
+		"call without function": openai.Chat(openai.ModelGPT35Turbo).User("hi").FunctionCall(openai.FunctionCallName("a")),
+		"unknown choice":        openai.Chat(openai.ModelGPT35Turbo).User("hi").Tool(&openai.Function{Name: "a"}).ToolChoice(openai.ToolChoiceFunction("b")),
+	}
+
+	for name, b := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := b.Build()
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			t.Log(err)
+		})
+	}
+}
+
+func TestChatRequestBuilder_Build_Copies(t *testing.T) {
+	b := openai.Chat(openai.ModelGPT4o).
+		User("hi").
+		Tool(&openai.Function{Name: "a"}).
+		Stop("END").
+		LogitBias("50256", -100)
+
+	first, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first.Messages[0].Content = "changed"
+	first.Tools[0] = openai.Tool{}
+	first.Stop[0] = "changed"
+	first.LogitBias["50256"] = 100
+
+	second, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second.Messages[0].Content != "hi" || second.Tools[0].Function == nil || second.Stop[0] != "END" || second.LogitBias["50256"] != -100 {
+		t.Fatalf("expected changes to a built request not to affect the builder, got %#+v", second)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	history := []openai.ChatMessage{
+		openai.User("What's the weather in Boston?"),
+		openai.AssistantMsg("Let me check.").WithName("weather_bot"),
+	}
+
+	messages := openai.Messages(
+		openai.System("You are a helpful assistant."),
+		openai.ChatMessages(history),
+		openai.ToolResult("call_abc", `{"temperature": 72}`),
+		openai.User("Is that warm?", "Answer briefly."),
+	)
+
+	if len(messages) != 5 {
+		t.Fatalf("expected 5 messages, got %d", len(messages))
+	}
+
+	if messages[2].Name != "weather_bot" || messages[2].Role != openai.ChatRoleAssistant {
+		t.Fatalf("unexpected assistant message: %#+v", messages[2])
+	}
+
+	if messages[3].Role != openai.ChatRoleTool || messages[3].ToolCallID != "call_abc" {
+		t.Fatalf("unexpected tool message: %#+v", messages[3])
+	}
+
+	if messages[4].Content != "Is that warm?\nAnswer briefly." {
+		t.Fatalf("unexpected user message: %q", messages[4].Content)
+	}
+}
+
+func TestCreateChat_Seed(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(b), `"seed":0`) {
+			t.Errorf("expected seed in request body: %s", b)
+		}
+		fmt.Fprint(w, `{"system_fingerprint":"fp_44709d6fcb","choices":[{"message":{"role":"assistant","content":"Hi!"}}]}`)
+	}
+
+	req, err := openai.Chat(openai.ModelGPT4).User("Hi").Seed(0).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := testClient(t, h).CreateChat(testCtx(t), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.SystemFingerprint != "fp_44709d6fcb" {
+		t.Fatalf("unexpected system fingerprint: %q", resp.SystemFingerprint)
+	}
+}
+
+func TestCreateChat_Logprobs(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(b), `"logprobs":true,"top_logprobs":2`) {
+			t.Errorf("expected logprobs in request body: %s", b)
+		}
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"Yes"},"logprobs":{"content":[
+			{"token":"Yes","logprob":-0.1,"bytes":[89,101,115],"top_logprobs":[{"token":"Yes","logprob":-0.1,"bytes":[89,101,115]},{"token":"No","logprob":-2.4,"bytes":[78,111]}]}
+		]}}]}`)
+	}
+
+	req, err := openai.Chat(openai.ModelGPT4).User("Is the sky blue?").Logprobs(2).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := testClient(t, h).CreateChat(testCtx(t), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logprobs := resp.Choices[0].Logprobs
+	if logprobs == nil || len(logprobs.Content) != 1 {
+		t.Fatalf("unexpected logprobs: %+v", logprobs)
+	}
+
+	token := logprobs.Content[0]
+	if token.Token != "Yes" || len(token.TopLogprobs) != 2 || token.TopLogprobs[1].Token != "No" {
+		t.Fatalf("unexpected token logprob: %+v", token)
+	}
+
+	if p := token.Prob(); p < 0.9 || p > 0.91 {
+		t.Fatalf("unexpected probability: %v", p)
+	}
+
+	if _, err := openai.Chat(openai.ModelGPT4).User("Hi").Logprobs(21).Build(); err == nil {
+		t.Fatal("expected error for too many top logprobs")
+	}
+}
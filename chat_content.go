@@ -0,0 +1,190 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ChatContentPartType is the type of a ChatContentPart.
+type ChatContentPartType string
+
+const (
+	// ChatContentPartText is a text content part.
+	ChatContentPartText ChatContentPartType = "text"
+
+	// ChatContentPartImageURL is an image content part, for models with vision.
+	ChatContentPartImageURL ChatContentPartType = "image_url"
+
+	// ChatContentPartInputAudio is an audio content part, for models with audio input.
+	ChatContentPartInputAudio ChatContentPartType = "input_audio"
+)
+
+// ImageDetail is the level of detail used by the model to process an image.
+//
+// https://platform.openai.com/docs/guides/vision#low-or-high-fidelity-image-understanding
+type ImageDetail string
+
+const (
+	ImageDetailAuto ImageDetail = "auto"
+	ImageDetailLow  ImageDetail = "low"
+	ImageDetailHigh ImageDetail = "high"
+)
+
+// ChatContentPart is a part of a message's content, either text, an image or audio.
+//
+// https://platform.openai.com/docs/api-reference/chat/create#chat-create-messages
+type ChatContentPart struct {
+	// Type is the type of the part, either "text", "image_url" or "input_audio".
+	//
+	// Required.
+	Type ChatContentPartType `json:"type"`
+
+	// Text is the text of a "text" part.
+	Text string `json:"text,omitempty"`
+
+	// ImageURL is the image of an "image_url" part.
+	ImageURL *ChatImageURL `json:"image_url,omitempty"`
+
+	// InputAudio is the audio of an "input_audio" part.
+	InputAudio *ChatInputAudio `json:"input_audio,omitempty"`
+}
+
+// ChatImageURL is an image included in a message.
+type ChatImageURL struct {
+	// URL is either the URL of the image, or the base64 encoded image data as
+	// a data URL, such as "data:image/jpeg;base64,...".
+	//
+	// Required.
+	URL string `json:"url"`
+
+	// Detail is the level of detail used to process the image.
+	//
+	// Optional. Defaults to "auto".
+	Detail ImageDetail `json:"detail,omitempty"`
+}
+
+// TextPart returns a text content part.
+func TextPart(text string) ChatContentPart {
+	return ChatContentPart{Type: ChatContentPartText, Text: text}
+}
+
+// ImagePart returns an image content part for the given URL or data URL, with
+// an optional level of detail.
+func ImagePart(url string, detail ImageDetail) ChatContentPart {
+	return ChatContentPart{Type: ChatContentPartImageURL, ImageURL: &ChatImageURL{URL: url, Detail: detail}}
+}
+
+// text returns the text content of the message, joining its text parts if it
+// has ContentParts.
+func (m ChatMessage) text() string {
+	if m.Content != "" || len(m.ContentParts) == 0 {
+		return m.Content
+	}
+
+	var text []string
+	for _, part := range m.ContentParts {
+		if part.Type == ChatContentPartText {
+			text = append(text, part.Text)
+		}
+	}
+	return strings.Join(text, "\n")
+}
+
+// UserParts returns a user message with the given content parts, such as text
+// and images for vision models.
+//
+// # Example
+//
+//	msg := openai.UserParts(
+//		openai.TextPart("What's in this image?"),
+//		openai.ImagePart("https://example.com/cat.jpg", openai.ImageDetailLow),
+//	)
+func UserParts(parts ...ChatContentPart) ChatMessage {
+	return ChatMessage{Role: ChatRoleUser, ContentParts: parts}
+}
+
+// MarshalJSON marshals the message, encoding its content as an array of parts
+// if ContentParts is set, or as a plain string otherwise. Generated audio is
+// referred to by its ID only.
+func (m ChatMessage) MarshalJSON() ([]byte, error) {
+	return m.marshalJSON(false)
+}
+
+// marshalJSON marshals the message like MarshalJSON, keeping the transcript
+// and expiry of generated audio if keepAudio is set, such as for storage.
+func (m ChatMessage) marshalJSON(keepAudio bool) ([]byte, error) {
+	type message ChatMessage
+
+	var content any = m.Content
+
+	switch {
+	case len(m.ContentParts) > 0:
+		content = m.ContentParts
+	case m.Content == "" && (len(m.ToolCalls) > 0 || m.FunctionCall != nil || m.Audio != nil):
+		// Messages that only call tools or speak have no content.
+		content = nil
+	}
+
+	var audio *ChatMessageAudio
+	if m.Audio != nil {
+		audio = &ChatMessageAudio{ID: m.Audio.ID}
+		if keepAudio {
+			audio.ExpiresAt = m.Audio.ExpiresAt
+			audio.Transcript = m.Audio.Transcript
+		}
+	}
+
+	return json.Marshal(struct {
+		message
+		Content any               `json:"content"`
+		Audio   *ChatMessageAudio `json:"audio,omitempty"`
+	}{
+		message: message(m),
+		Content: content,
+		Audio:   audio,
+	})
+}
+
+// UnmarshalJSON unmarshals the message, accepting content as either a plain
+// string or an array of parts. For an array, Content is set to the text of the
+// text parts joined with newlines.
+func (m *ChatMessage) UnmarshalJSON(b []byte) error {
+	type message ChatMessage
+
+	tmp := struct {
+		*message
+		Content json.RawMessage `json:"content"`
+	}{
+		message: (*message)(m),
+	}
+
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	m.Content, m.ContentParts = "", nil
+
+	content := bytes.TrimSpace(tmp.Content)
+
+	switch {
+	case len(content) == 0 || bytes.Equal(content, []byte("null")):
+		return nil
+	case content[0] == '[':
+		if err := json.Unmarshal(content, &m.ContentParts); err != nil {
+			return err
+		}
+
+		var text []string
+		for _, part := range m.ContentParts {
+			if part.Type == ChatContentPartText {
+				text = append(text, part.Text)
+			}
+		}
+		m.Content = strings.Join(text, "\n")
+
+		return nil
+	default:
+		return json.Unmarshal(content, &m.Content)
+	}
+}
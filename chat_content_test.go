@@ -0,0 +1,67 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestChatMessage_ContentParts(t *testing.T) {
+	msg := openai.UserParts(
+		openai.TextPart("What's in this image?"),
+		openai.ImagePart("https://example.com/cat.jpg", openai.ImageDetailLow),
+	)
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"role":"user","content":[{"type":"text","text":"What's in this image?"},{"type":"image_url","image_url":{"url":"https://example.com/cat.jpg","detail":"low"}}]}`
+	if string(b) != want {
+		t.Fatalf("got %s, want %s", b, want)
+	}
+
+	var got openai.ChatMessage
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Role != openai.ChatRoleUser || len(got.ContentParts) != 2 || got.ContentParts[1].ImageURL.Detail != openai.ImageDetailLow {
+		t.Fatalf("unexpected message: %#+v", got)
+	}
+
+	if got.Content != "What's in this image?" {
+		t.Fatalf("unexpected text content: %q", got.Content)
+	}
+}
+
+func TestChatMessage_PlainContent(t *testing.T) {
+	b, err := json.Marshal(openai.User("Hello!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `{"role":"user","content":"Hello!"}` {
+		t.Fatalf("unexpected JSON: %s", b)
+	}
+
+	var got openai.ChatMessage
+	if err := json.Unmarshal([]byte(`{"role":"assistant","content":null,"tool_calls":[{"id":"call_1","type":"function","function":{"name":"f","arguments":"{}"}}]}`), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Content != "" || len(got.ToolCalls) != 1 {
+		t.Fatalf("unexpected message: %#+v", got)
+	}
+
+	b, err = json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != `{"role":"assistant","tool_calls":[{"id":"call_1","type":"function","function":{"name":"f","arguments":"{}"}}],"content":null}` {
+		t.Fatalf("unexpected JSON: %s", b)
+	}
+}
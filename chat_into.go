@@ -0,0 +1,209 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"slices"
+)
+
+// ChatInto sends the chat request with a strict structured output format
+// derived from T, as by JSONSchemaFor, and decodes the model's reply into a T.
+// T must be a struct, and fields tagged "omitempty" may be returned as null.
+//
+// If the reply isn't valid JSON for T, such as when it was cut off by the
+// token limit, the request is sent once more with the error appended to the
+// conversation. The given request isn't modified.
+//
+// # Example
+//
+//	type Weather struct {
+//		Location    string  `json:"location"`
+//		Temperature float64 `json:"temperature" description:"In degrees Celsius"`
+//	}
+//
+//	weather, err := openai.ChatInto[Weather](ctx, client, &openai.CreateChatRequest{
+//		Model:    openai.ModelGPT4o,
+//		Messages: []openai.ChatMessage{openai.User("It's 20 degrees in Paris today.")},
+//	})
+func ChatInto[T any](ctx context.Context, c *Client, req *CreateChatRequest) (T, error) {
+	var zero T
+
+	format, err := structuredOutputFormat[T]()
+	if err != nil {
+		return zero, err
+	}
+
+	r := *req
+	r.Stream = false
+	r.Messages = slices.Clone(req.Messages)
+	r.ResponseFormat = format
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.CreateChat(ctx, &r)
+		if err != nil {
+			return zero, err
+		}
+
+		msg, err := resp.FirstChoice()
+		if err != nil {
+			return zero, err
+		}
+
+		var v T
+		err = json.Unmarshal([]byte(msg.Content), &v)
+		if err == nil {
+			return v, nil
+		}
+
+		if attempt > 1 {
+			return zero, fmt.Errorf("invalid structured output: %w", err)
+		}
+
+		r.Messages = append(r.Messages, *msg, User(fmt.Sprintf(
+			"Your reply isn't valid JSON for the schema (%v). Reply again with only the complete JSON.", err,
+		)))
+	}
+}
+
+// StreamInto streams the chat request with a strict structured output format
+// derived from T, like ChatInto, calling fn with the reply decoded so far into
+// a new T after every chunk, as by UnmarshalPartialJSON, such as to render it
+// live. It returns the complete reply once the stream is done.
+//
+// Unlike ChatInto, the request isn't retried if the reply is invalid.
+//
+// # Example
+//
+//	weather, err := openai.StreamInto(ctx, client, req, func(partial Weather) error {
+//		fmt.Printf("\r%s: %.1f", partial.Location, partial.Temperature)
+//		return nil
+//	})
+func StreamInto[T any](ctx context.Context, c *Client, req *CreateChatRequest, fn func(partial T) error) (T, error) {
+	var zero T
+
+	format, err := structuredOutputFormat[T]()
+	if err != nil {
+		return zero, err
+	}
+
+	r := *req
+	r.ResponseFormat = format
+
+	stream, err := c.CreateChatStream(ctx, &r)
+	if err != nil {
+		return zero, err
+	}
+	defer stream.Close()
+
+	for {
+		chunk, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return zero, err
+		}
+
+		if !chunk.ContentDelta() {
+			continue
+		}
+
+		// Skip chunks that don't complete anything decodable yet.
+		var partial T
+		if err := UnmarshalPartialJSON([]byte(stream.Content()), &partial); err != nil {
+			continue
+		}
+
+		if err := fn(partial); err != nil {
+			return zero, err
+		}
+	}
+
+	var v T
+	if err := json.Unmarshal([]byte(stream.Content()), &v); err != nil {
+		return zero, fmt.Errorf("invalid structured output: %w", err)
+	}
+
+	return v, nil
+}
+
+// structuredOutputFormat returns a strict structured output format for T,
+// which must be a struct.
+func structuredOutputFormat[T any]() (*ChatResponseFormat, error) {
+	schema, err := JSONSchemaFor[T]()
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.Type != "object" || schema.Properties == nil {
+		return nil, fmt.Errorf("structured output must be a struct, got %T", *new(T))
+	}
+
+	if err := makeStrict(schema); err != nil {
+		return nil, err
+	}
+
+	return JSONSchemaFormat(schemaName(reflect.TypeFor[T]()), schema), nil
+}
+
+// makeStrict changes the schema in place for strict structured outputs, which
+// require every property of an object, and disallow additional properties.
+// Optional properties are made nullable instead.
+func makeStrict(s *JSONSchema) error {
+	if s == nil {
+		return nil
+	}
+
+	if s.AdditionalProperties != nil {
+		return fmt.Errorf("strict structured outputs don't support maps")
+	}
+
+	for _, sub := range append([]*JSONSchema{s.Items}, s.AnyOf...) {
+		if err := makeStrict(sub); err != nil {
+			return err
+		}
+	}
+
+	if s.Type != "object" {
+		return nil
+	}
+
+	s.DisallowAdditionalProperties = true
+
+	var optional []string
+	for name, prop := range s.Properties {
+		if err := makeStrict(prop); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+
+		if !slices.Contains(s.Required, name) {
+			s.Properties[name] = &JSONSchema{
+				Description: prop.Description,
+				AnyOf:       []*JSONSchema{prop, {Type: "null"}},
+			}
+			prop.Description = ""
+			optional = append(optional, name)
+		}
+	}
+
+	slices.Sort(optional)
+	s.Required = append(s.Required, optional...)
+	return nil
+}
+
+// invalidSchemaNameChars matches the characters not allowed in the name of a
+// structured output format.
+var invalidSchemaNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// schemaName returns the name of a structured output format for the type t.
+func schemaName(t reflect.Type) string {
+	name := invalidSchemaNameChars.ReplaceAllString(t.Name(), "_")
+	if name == "" {
+		return "response"
+	}
+	return name[:min(len(name), 64)]
+}
@@ -0,0 +1,141 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+type forecast struct {
+	Location string   `json:"location" description:"The city"`
+	Highs    []int    `json:"highs"`
+	Note     string   `json:"note,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+func TestChatInto(t *testing.T) {
+	var requests int
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var req struct {
+			Messages       []openai.ChatMessage `json:"messages"`
+			ResponseFormat struct {
+				Type       string `json:"type"`
+				JSONSchema struct {
+					Name   string          `json:"name"`
+					Strict bool            `json:"strict"`
+					Schema json.RawMessage `json:"schema"`
+				} `json:"json_schema"`
+			} `json:"response_format"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		format := req.ResponseFormat
+		if format.Type != "json_schema" || format.JSONSchema.Name != "forecast" || !format.JSONSchema.Strict {
+			t.Errorf("unexpected response format: %+v", format)
+		}
+
+		want := `{"type":"object","properties":{"highs":{"type":"array","items":{"type":"integer"}},"location":{"type":"string","description":"The city"},"note":{"anyOf":[{"type":"string"},{"type":"null"}]},"tags":{"anyOf":[{"type":"array","items":{"type":"string"}},{"type":"null"}]}},"required":["location","highs","note","tags"],"additionalProperties":false}`
+		if got := string(format.JSONSchema.Schema); got != want {
+			t.Errorf("unexpected schema:\n got: %s\nwant: %s", got, want)
+		}
+
+		switch requests {
+		case 1:
+			fmt.Fprint(w, `{"choices":[{"index":0,"finish_reason":"length","message":{"role":"assistant","content":"{\"location\":\"Par"}}]}`)
+		case 2:
+			last := req.Messages[len(req.Messages)-1]
+			if last.Role != openai.ChatRoleUser || !strings.Contains(last.Content, "valid JSON") {
+				t.Errorf("expected retry message, got %#+v", last)
+			}
+			fmt.Fprint(w, `{"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"{\"location\":\"Paris\",\"highs\":[20,22],\"note\":null,\"tags\":null}"}}]}`)
+		default:
+			t.Errorf("unexpected request %d", requests)
+		}
+	})
+
+	req := &openai.CreateChatRequest{
+		Model:    openai.ModelGPT4o,
+		Messages: []openai.ChatMessage{openai.User("What's the forecast for Paris?")},
+	}
+
+	got, err := openai.ChatInto[forecast](testCtx(t), c, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Location != "Paris" || len(got.Highs) != 2 || got.Highs[1] != 22 {
+		t.Errorf("unexpected forecast: %+v", got)
+	}
+
+	if len(req.Messages) != 1 || req.ResponseFormat != nil {
+		t.Errorf("request was modified: %+v", req)
+	}
+}
+
+func TestChatInto_InvalidType(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	if _, err := openai.ChatInto[[]string](testCtx(t), c, &openai.CreateChatRequest{}); err == nil {
+		t.Error("expected error for non-struct type")
+	}
+
+	if _, err := openai.ChatInto[struct {
+		Counts map[string]int `json:"counts"`
+	}](testCtx(t), c, &openai.CreateChatRequest{}); err == nil {
+		t.Error("expected error for map field")
+	}
+}
+
+func TestStreamInto(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(b), `"stream":true`) || !strings.Contains(string(b), `"type":"json_schema"`) {
+			t.Errorf("unexpected request body: %s", b)
+		}
+
+		for _, delta := range []string{`{"location":"Pa`, `ris","highs":[20,`, `22]`, `,"note":null,"tags":null}`} {
+			chunk, _ := json.Marshal(map[string]any{
+				"choices": []any{map[string]any{"index": 0, "delta": map[string]any{"content": delta}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	var partials []forecast
+
+	got, err := openai.StreamInto(testCtx(t), c, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT4o,
+		Messages: []openai.ChatMessage{openai.User("What's the forecast for Paris?")},
+	}, func(partial forecast) error {
+		partials = append(partials, partial)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(partials) != 4 {
+		t.Fatalf("expected 4 partial results, got %d: %+v", len(partials), partials)
+	}
+
+	if partials[0].Location != "Pa" || partials[1].Location != "Paris" || len(partials[1].Highs) != 1 || len(partials[2].Highs) != 2 {
+		t.Errorf("unexpected partial results: %+v", partials)
+	}
+
+	if got.Location != "Paris" || len(got.Highs) != 2 || got.Highs[1] != 22 {
+		t.Errorf("unexpected forecast: %+v", got)
+	}
+}
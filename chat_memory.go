@@ -0,0 +1,287 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ChatMemory is a strategy for managing the history of a ChatSession as it
+// grows, such as by summarizing or dropping older messages.
+type ChatMemory interface {
+	// Compact returns the history to keep, given the current history ending
+	// with the message about to be sent to the model. It's called before each
+	// request, and mustn't modify the given slice.
+	Compact(ctx context.Context, model string, history []ChatMessage) ([]ChatMessage, error)
+}
+
+// summaryPrefix starts the text of the system message holding the summary
+// written by a SummaryMemory.
+const summaryPrefix = "Summary of the conversation so far:\n\n"
+
+// summaryPrompt is the system prompt used to summarize a conversation.
+const summaryPrompt = "Summarize the conversation below, including any earlier summary, for the assistant to continue it. " +
+	"Keep names, facts, decisions, open questions and the user's preferences. Reply with only the summary."
+
+// SummaryMemory is a ChatMemory that summarizes the older messages of the
+// history with a cheaper model when it approaches the context window, and
+// replaces them with a system message holding the summary so far. System
+// messages and the most recent messages are kept as-is.
+//
+// # Example
+//
+//	session := openai.NewChatSession(client, openai.ModelGPT4o,
+//		openai.System("You are a helpful assistant."),
+//	)
+//	session.Memory = openai.NewSummaryMemory(client, openai.ModelGPT4oMini)
+type SummaryMemory struct {
+	client *Client
+	model  string
+
+	// Threshold is the fraction of the context window the history may use
+	// before it is summarized.
+	//
+	// Optional. Defaults to 0.75.
+	Threshold float64
+
+	// KeepLast is the number of the most recent messages kept as-is. Tool
+	// results are kept along with the message that called them.
+	//
+	// Optional. Defaults to 4.
+	KeepLast int
+
+	// ContextWindow is the context window size of the session's model in
+	// tokens.
+	//
+	// Optional. Defaults to the model's known context window. If it isn't
+	// known, the history isn't summarized.
+	ContextWindow int
+}
+
+// NewSummaryMemory returns a SummaryMemory that summarizes the history with the
+// given client and model, such as ModelGPT4oMini.
+func NewSummaryMemory(c *Client, model string) *SummaryMemory {
+	return &SummaryMemory{client: c, model: model}
+}
+
+// Compact implements the ChatMemory interface.
+func (m *SummaryMemory) Compact(ctx context.Context, model string, history []ChatMessage) ([]ChatMessage, error) {
+	window := m.ContextWindow
+	if window == 0 {
+		var ok bool
+		window, ok = ContextWindow(model)
+		if !ok {
+			return history, nil
+		}
+	}
+
+	threshold := m.Threshold
+	if threshold <= 0 {
+		threshold = 0.75
+	}
+
+	keep := m.KeepLast
+	if keep <= 0 {
+		keep = 4
+	}
+
+	n, err := CountChatTokens(model, history)
+	if err != nil {
+		return nil, err
+	}
+
+	if float64(n) < threshold*float64(window) {
+		return history, nil
+	}
+
+	var (
+		system  []ChatMessage
+		summary string
+		rest    []ChatMessage
+	)
+
+	for _, msg := range history {
+		switch {
+		case msg.Role == ChatRoleSystem && strings.HasPrefix(msg.Content, summaryPrefix):
+			summary = strings.TrimPrefix(msg.Content, summaryPrefix)
+		case msg.Role == ChatRoleSystem:
+			system = append(system, msg)
+		default:
+			rest = append(rest, msg)
+		}
+	}
+
+	// Tool results can't be kept without the message that called them.
+	i := max(len(rest)-keep, 0)
+	for i > 0 && rest[i].Role == ChatRoleTool {
+		i--
+	}
+
+	if i == 0 {
+		return history, nil
+	}
+
+	summary, err = m.summarize(ctx, summary, rest[:i])
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(system, []ChatMessage{System(summaryPrefix + summary)}, rest[i:]), nil
+}
+
+// summarize returns a summary of the earlier summary, if any, and the messages.
+func (m *SummaryMemory) summarize(ctx context.Context, summary string, messages []ChatMessage) (string, error) {
+	var b strings.Builder
+
+	if summary != "" {
+		fmt.Fprintf(&b, "Earlier summary: %s\n\n", summary)
+	}
+
+	for _, msg := range messages {
+		if text := msg.text(); text != "" {
+			fmt.Fprintf(&b, "%s: %s\n", msg.Role, text)
+		}
+
+		for _, call := range msg.ToolCalls {
+			if call.Function == nil {
+				continue
+			}
+
+			args, err := json.Marshal(call.Function.Arguments)
+			if err != nil {
+				return "", err
+			}
+
+			fmt.Fprintf(&b, "%s: called %s(%s)\n", msg.Role, call.Function.Name, args)
+		}
+	}
+
+	resp, err := m.client.CreateChat(ctx, &CreateChatRequest{
+		Model:    m.model,
+		Messages: []ChatMessage{System(summaryPrompt), User(b.String())},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize conversation: %w", err)
+	}
+
+	reply, err := resp.FirstChoice()
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize conversation: %w", err)
+	}
+
+	if reply.Content == "" {
+		return "", errors.New("failed to summarize conversation: empty summary")
+	}
+
+	return reply.Content, nil
+}
+
+// WindowMemory is a ChatMemory that keeps a sliding window of the most recent
+// messages of the history, along with its system messages and any pinned
+// messages, dropping the rest.
+//
+// # Example
+//
+//	session.Memory = &openai.WindowMemory{
+//		MaxMessages: 20,
+//		MaxTokens:   4000,
+//		Pinned: func(m openai.ChatMessage) bool {
+//			return m.Name == "profile"
+//		},
+//	}
+type WindowMemory struct {
+	// MaxMessages is the maximum number of recent messages kept, not counting
+	// system and pinned messages.
+	//
+	// Optional. Defaults to no limit.
+	MaxMessages int
+
+	// MaxTokens is the maximum number of tokens of the recent messages kept,
+	// not counting system and pinned messages. The newest message is always
+	// kept. Token counts are estimated unless the model's encoding is loaded,
+	// as described by CountChatTokens.
+	//
+	// Optional. Defaults to no limit.
+	MaxTokens int
+
+	// Pinned reports whether a message is always kept, like system messages.
+	//
+	// Optional.
+	Pinned func(m ChatMessage) bool
+}
+
+// Compact implements the ChatMemory interface. Tool results are dropped along
+// with the message that called them.
+func (w *WindowMemory) Compact(ctx context.Context, model string, history []ChatMessage) ([]ChatMessage, error) {
+	var (
+		keep    = make([]bool, len(history))
+		count   int
+		tokens  int
+		dropped bool
+	)
+
+	for i := len(history) - 1; i >= 0; i-- {
+		m := history[i]
+
+		if m.Role == ChatRoleSystem || (w.Pinned != nil && w.Pinned(m)) {
+			keep[i] = true
+			continue
+		}
+
+		if dropped {
+			continue
+		}
+
+		if w.MaxMessages > 0 && count >= w.MaxMessages {
+			dropped = true
+			continue
+		}
+
+		if w.MaxTokens > 0 {
+			n, err := CountChatTokens(model, history[i:i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			if count > 0 && tokens+n > w.MaxTokens {
+				dropped = true
+				continue
+			}
+			tokens += n
+		}
+
+		keep[i] = true
+		count++
+	}
+
+	if !dropped {
+		return history, nil
+	}
+
+	var (
+		messages []ChatMessage
+		calls    = map[string]bool{}
+	)
+
+	for i, m := range history {
+		if !keep[i] {
+			continue
+		}
+
+		// Tool results can't be sent without the message that called them.
+		if m.Role == ChatRoleTool && !calls[m.ToolCallID] {
+			continue
+		}
+
+		for _, call := range m.ToolCalls {
+			calls[call.ID] = true
+		}
+
+		messages = append(messages, m)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,121 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestSummaryMemory(t *testing.T) {
+	var summarized []string
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req openai.CreateChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if req.Model == openai.ModelGPT4oMini {
+			summarized = append(summarized, req.Messages[1].Content)
+			fmt.Fprintf(w, `{"choices": [{"message": {"role": "assistant", "content": "summary %d"}, "index": 0}]}`, len(summarized))
+			return
+		}
+
+		fmt.Fprint(w, `{"choices": [{"message": {"role": "assistant", "content": "ok"}, "index": 0}]}`)
+	})
+
+	session := openai.NewChatSession(c, openai.ModelGPT4o, openai.System("Be brief."))
+
+	memory := openai.NewSummaryMemory(c, openai.ModelGPT4oMini)
+	memory.ContextWindow = 100
+	memory.KeepLast = 2
+	session.Memory = memory
+
+	for i := range 6 {
+		if _, err := session.Send(testCtx(t), strings.Repeat(fmt.Sprintf("message %d ", i), 5)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(summarized) == 0 {
+		t.Fatal("expected the history to be summarized")
+	}
+
+	if n := len(summarized); n > 1 && !strings.Contains(summarized[n-1], fmt.Sprintf("Earlier summary: summary %d", n-1)) {
+		t.Fatalf("expected the earlier summary to be summarized again, got %q", summarized[n-1])
+	}
+
+	messages := session.Messages()
+
+	if messages[0].Content != "Be brief." {
+		t.Fatalf("expected the system message to be kept, got %q", messages[0].Content)
+	}
+
+	if want := fmt.Sprintf("summary %d", len(summarized)); !strings.HasSuffix(messages[1].Content, want) || messages[1].Role != openai.ChatRoleSystem {
+		t.Fatalf("expected the summary message, got %+v", messages[1])
+	}
+
+	if !strings.HasPrefix(messages[len(messages)-2].Content, "message 5") {
+		t.Fatalf("expected the last messages to be kept, got %+v", messages[2:])
+	}
+}
+
+func TestWindowMemory(t *testing.T) {
+	history := []openai.ChatMessage{
+		openai.System("Be brief."),
+		openai.User("My name is Gopher.").WithName("profile"),
+		openai.User("one"),
+		openai.AssistantMsg("1"),
+		{
+			Role:      openai.ChatRoleAssistant,
+			ToolCalls: []openai.ToolCall{{ID: "call_1", Type: openai.ToolTypeFunction, Function: &openai.FunctionCall{Name: "count"}}},
+		},
+		openai.ToolResult("call_1", "2"),
+		openai.AssistantMsg("2"),
+		openai.User("three"),
+	}
+
+	memory := &openai.WindowMemory{
+		MaxMessages: 3,
+		Pinned: func(m openai.ChatMessage) bool {
+			return m.Name == "profile"
+		},
+	}
+
+	messages, err := memory.Compact(testCtx(t), openai.ModelGPT4o, history)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, m := range messages {
+		got = append(got, m.Role+": "+m.Content)
+	}
+
+	// The tool result is dropped along with the message that called it.
+	want := []string{
+		"system: Be brief.",
+		"user: My name is Gopher.",
+		"assistant: 2",
+		"user: three",
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected messages:\n%s", strings.Join(got, "\n"))
+	}
+
+	memory = &openai.WindowMemory{MaxTokens: 1}
+
+	messages, err = memory.Compact(testCtx(t), openai.ModelGPT4o, history)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 2 || messages[1].Content != "three" {
+		t.Fatalf("expected the system and newest messages to be kept, got %+v", messages)
+	}
+}
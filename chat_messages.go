@@ -0,0 +1,67 @@
+package openai
+
+import "strings"
+
+// System returns a system message with the given text.
+func System(text string) ChatMessage {
+	return ChatMessage{Role: ChatRoleSystem, Content: text}
+}
+
+// User returns a user message, joining the given parts with newlines.
+func User(parts ...string) ChatMessage {
+	return ChatMessage{Role: ChatRoleUser, Content: strings.Join(parts, "\n")}
+}
+
+// AssistantMsg returns an assistant message with the given text.
+func AssistantMsg(text string) ChatMessage {
+	return ChatMessage{Role: ChatRoleAssistant, Content: text}
+}
+
+// ToolResult returns a tool message containing the result of the tool call
+// with the given ID.
+func ToolResult(callID, content string) ChatMessage {
+	return ChatMessage{Role: ChatRoleTool, ToolCallID: callID, Content: content}
+}
+
+// WithName returns a copy of the message with the given author name, which
+// distinguishes between participants with the same role.
+func (m ChatMessage) WithName(name string) ChatMessage {
+	m.Name = name
+	return m
+}
+
+// ChatMessageSource is a single ChatMessage or a list of ChatMessages, which can
+// be combined into a conversation with Messages.
+type ChatMessageSource interface {
+	chatMessages() []ChatMessage
+}
+
+func (m ChatMessage) chatMessages() []ChatMessage {
+	return []ChatMessage{m}
+}
+
+// ChatMessages is a list of chat messages, such as an existing conversation
+// history, that can be spliced into a conversation with Messages.
+type ChatMessages []ChatMessage
+
+func (m ChatMessages) chatMessages() []ChatMessage {
+	return m
+}
+
+// Messages combines the given messages and message lists into a single
+// conversation, in order.
+//
+// # Example
+//
+//	messages := openai.Messages(
+//		openai.System("You are a helpful assistant."),
+//		openai.ChatMessages(history),
+//		openai.User("What did I just ask you?"),
+//	)
+func Messages(sources ...ChatMessageSource) []ChatMessage {
+	var messages []ChatMessage
+	for _, s := range sources {
+		messages = append(messages, s.chatMessages()...)
+	}
+	return messages
+}
@@ -0,0 +1,87 @@
+package openai
+
+// ChatResponseFormatType is the type of a ChatResponseFormat.
+type ChatResponseFormatType string
+
+const (
+	// ChatResponseFormatText is plain text output, the default.
+	ChatResponseFormatText ChatResponseFormatType = "text"
+
+	// ChatResponseFormatJSONObject is valid JSON output, also known as JSON mode.
+	// The messages must instruct the model to produce JSON.
+	ChatResponseFormatJSONObject ChatResponseFormatType = "json_object"
+
+	// ChatResponseFormatJSONSchema is JSON output conforming to a schema, also
+	// known as structured outputs.
+	ChatResponseFormatJSONSchema ChatResponseFormatType = "json_schema"
+)
+
+// ChatResponseFormat is the format the model must output.
+//
+// https://platform.openai.com/docs/api-reference/chat/create#chat-create-response_format
+type ChatResponseFormat struct {
+	// Type is the type of the format, "text", "json_object" or "json_schema".
+	//
+	// Required.
+	Type ChatResponseFormatType `json:"type"`
+
+	// JSONSchema is the schema of a "json_schema" format.
+	JSONSchema *ChatJSONSchemaFormat `json:"json_schema,omitempty"`
+}
+
+// ChatJSONSchemaFormat is the schema the model's output must conform to.
+//
+// https://platform.openai.com/docs/guides/structured-outputs
+type ChatJSONSchemaFormat struct {
+	// Name is the name of the format, which may contain a-z, A-Z, 0-9,
+	// underscores and dashes, with a maximum length of 64 characters.
+	//
+	// Required.
+	Name string `json:"name"`
+
+	// Description describes what the format is for, to help the model respond.
+	//
+	// Optional.
+	Description string `json:"description,omitempty"`
+
+	// Schema is the JSON Schema of the output.
+	//
+	// Required.
+	Schema *JSONSchema `json:"schema"`
+
+	// Strict enables strict schema adherence. Strict schemas must list every
+	// property as required, and set DisallowAdditionalProperties on every object.
+	//
+	// Optional.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// JSONObjectFormat returns a response format for JSON mode.
+func JSONObjectFormat() *ChatResponseFormat {
+	return &ChatResponseFormat{Type: ChatResponseFormatJSONObject}
+}
+
+// JSONSchemaFormat returns a response format for strict structured outputs
+// with the given name and schema.
+//
+// # Example
+//
+//	format := openai.JSONSchemaFormat("weather", &openai.JSONSchema{
+//		Type: "object",
+//		Properties: map[string]*openai.JSONSchema{
+//			"location":    {Type: "string"},
+//			"temperature": {Type: "number"},
+//		},
+//		Required:                     []string{"location", "temperature"},
+//		DisallowAdditionalProperties: true,
+//	})
+func JSONSchemaFormat(name string, schema *JSONSchema) *ChatResponseFormat {
+	return &ChatResponseFormat{
+		Type: ChatResponseFormatJSONSchema,
+		JSONSchema: &ChatJSONSchemaFormat{
+			Name:   name,
+			Schema: schema,
+			Strict: true,
+		},
+	}
+}
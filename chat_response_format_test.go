@@ -0,0 +1,66 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestCreateChat_JSONSchemaFormat(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		var body struct {
+			ResponseFormat json.RawMessage `json:"response_format"`
+		}
+
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Fatal(err)
+		}
+
+		want := `{"type":"json_schema","json_schema":{"name":"weather","schema":{"type":"object","properties":{"location":{"type":"string"}},"required":["location"],"additionalProperties":false},"strict":true}}`
+		if string(body.ResponseFormat) != want {
+			t.Errorf("unexpected response format:\n got %s\nwant %s", body.ResponseFormat, want)
+		}
+
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"{\"location\":\"Boston\"}"}}]}`)
+	}
+
+	req, err := openai.Chat(openai.ModelGPT4).
+		User("Where is it sunny?").
+		ResponseFormat(openai.JSONSchemaFormat("weather", &openai.JSONSchema{
+			Type: "object",
+			Properties: map[string]*openai.JSONSchema{
+				"location": {Type: "string"},
+			},
+			Required:                     []string{"location"},
+			DisallowAdditionalProperties: true,
+		})).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := testCtx(t)
+
+	resp, err := testClient(t, h).CreateChat(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out struct {
+		Location string `json:"location"`
+	}
+
+	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Location != "Boston" {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+}
@@ -1,6 +1,6 @@
 package openai
 
-// ChatRole is a role that can be used in a chat session, either “system”, “user”, or “assistant”.
+// ChatRole is a role that can be used in a chat session, either “system”, “user”, “assistant”, or “tool”.
 //
 // https://platform.openai.com/docs/guides/chat/introduction
 type ChatRole = string
@@ -14,4 +14,7 @@ const (
 
 	// ChatRoleAssistant is an assistant role.
 	ChatRoleAssistant ChatRole = "assistant"
+
+	// ChatRoleTool is a tool role, used for the results of tool calls.
+	ChatRoleTool ChatRole = "tool"
 )
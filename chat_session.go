@@ -0,0 +1,240 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+)
+
+// ChatSession is a conversation with a chat model, which keeps the message
+// history and appends each reply to it automatically.
+//
+// If the model's context window is known, the oldest messages are left out of
+// each request as needed to fit it, as done by ContextBudget. The history
+// itself is kept in full.
+//
+// A ChatSession is safe for concurrent use, though messages are sent one at a time.
+//
+// # Example
+//
+//	session := openai.NewChatSession(client, openai.ModelGPT4,
+//		openai.System("You are a helpful assistant."),
+//	)
+//
+//	reply, _ := session.Send(ctx, "Hello!")
+//	fmt.Println(reply)
+//
+//	reply, _ = session.Stream(ctx, "Tell me a joke.", func(delta string) error {
+//		fmt.Print(delta)
+//		return nil
+//	})
+type ChatSession struct {
+	client *Client
+
+	// Defaults is the template for each request, such as its Model,
+	// MaxCompletionTokens or Temperature. Its Messages and Stream fields are
+	// ignored.
+	Defaults CreateChatRequest
+
+	// Memory manages the history as it grows, such as a SummaryMemory or a
+	// WindowMemory. It's applied before each request, and the history it
+	// returns is kept once the reply is received.
+	//
+	// Optional. Defaults to keeping the full history.
+	Memory ChatMemory
+
+	// Recall stores the messages of the session, and adds those relevant to
+	// each new user message to its request, such as ones the Memory dropped.
+	//
+	// Optional.
+	Recall *LongTermMemory
+
+	// mu guards messages, and serializes requests.
+	mu       sync.Mutex
+	messages []ChatMessage
+}
+
+// NewChatSession returns a new ChatSession with the given model, starting with
+// the given history, such as a system message.
+func NewChatSession(client *Client, model string, history ...ChatMessage) *ChatSession {
+	return &ChatSession{
+		client:   client,
+		Defaults: CreateChatRequest{Model: model},
+		messages: append([]ChatMessage(nil), history...),
+	}
+}
+
+// Messages returns a copy of the conversation history.
+func (s *ChatSession) Messages() []ChatMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]ChatMessage(nil), s.messages...)
+}
+
+// Reset clears the conversation history, keeping its system messages.
+func (s *ChatSession) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var system []ChatMessage
+	for _, m := range s.messages {
+		if m.Role == ChatRoleSystem {
+			system = append(system, m)
+		}
+	}
+	s.messages = system
+}
+
+// request compacts the history with the session's Memory, if any, and returns
+// the request for it, with the messages recalled by its Recall, if any, left
+// trimmed to fit the model's context window if it is known.
+func (s *ChatSession) request(ctx context.Context) (*CreateChatRequest, error) {
+	if s.Memory != nil {
+		messages, err := s.Memory.Compact(ctx, s.Defaults.Model, s.messages)
+		if err != nil {
+			return nil, err
+		}
+		s.messages = messages
+	}
+
+	req := s.Defaults
+	req.Stream = false
+	req.Messages = s.messages
+
+	if s.Recall != nil {
+		messages, err := s.Recall.inject(ctx, req.Messages)
+		if err != nil {
+			return nil, err
+		}
+		req.Messages = messages
+	}
+
+	if _, ok := ContextWindow(req.Model); ok {
+		budget := ContextBudget{Model: req.Model, MaxResponseTokens: max(req.MaxTokens, req.MaxCompletionTokens)}
+
+		messages, err := budget.Fit(req.Messages)
+		if err != nil {
+			return nil, err
+		}
+		req.Messages = messages
+	}
+
+	return &req, nil
+}
+
+// Send sends a user message with the given text, and returns the text of the
+// reply. Both are appended to the history once the reply is received; if the
+// request fails, the history is left unchanged.
+//
+// If the session has a Recall, both are stored in it, and if that fails, the
+// reply is returned along with the error.
+func (s *ChatSession) Send(ctx context.Context, text string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.messages
+	s.messages = append(slices.Clip(prev), User(text))
+
+	reply, err := s.send(ctx)
+	if err != nil {
+		s.messages = prev
+		return "", err
+	}
+
+	s.messages = append(s.messages, *reply)
+	return reply.Content, s.remember(ctx, User(text), *reply)
+}
+
+// remember stores the messages in the session's Recall, if any.
+func (s *ChatSession) remember(ctx context.Context, messages ...ChatMessage) error {
+	if s.Recall == nil {
+		return nil
+	}
+	return s.Recall.remember(ctx, messages...)
+}
+
+func (s *ChatSession) send(ctx context.Context) (*ChatMessage, error) {
+	req, err := s.request(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.CreateChat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.FirstChoice()
+}
+
+// Stream sends a user message with the given text, calling fn with each delta
+// of the reply as it arrives, and returns the full text of the reply. Both are
+// appended to the history once the reply is complete.
+//
+// If the stream fails partway through, the history is left unchanged, and the
+// text received so far is returned along with the error. As with Send, both
+// are stored in the session's Recall, if any.
+func (s *ChatSession) Stream(ctx context.Context, text string, fn func(delta string) error) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev := s.messages
+	s.messages = append(slices.Clip(prev), User(text))
+
+	reply, err := s.stream(ctx, fn)
+	if err != nil {
+		s.messages = prev
+		if reply != nil {
+			return reply.Content, err
+		}
+		return "", err
+	}
+
+	s.messages = append(s.messages, *reply)
+	return reply.Content, s.remember(ctx, User(text), *reply)
+}
+
+func (s *ChatSession) stream(ctx context.Context, fn func(delta string) error) (*ChatMessage, error) {
+	req, err := s.request(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := s.client.CreateChatStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	reply := &ChatMessage{Role: ChatRoleAssistant}
+
+	for {
+		chunk, err := stream.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			reply.Content = stream.Content()
+			return reply, err
+		}
+
+		if fn != nil && chunk.ContentDelta() {
+			if err := fn(*chunk.Choices[0].Delta.Content); err != nil {
+				reply.Content = stream.Content()
+				return reply, err
+			}
+		}
+	}
+
+	reply.Content = stream.Content()
+
+	reply.ToolCalls, err = stream.ToolCalls()
+	if err != nil {
+		return reply, err
+	}
+
+	return reply, nil
+}
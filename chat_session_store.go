@@ -0,0 +1,283 @@
+package openai
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrSessionNotFound is returned by a SessionStore when loading a session that
+// hasn't been saved.
+var ErrSessionNotFound = errors.New("chat session not found")
+
+// SessionStore persists the history of chat sessions by ID, so conversations
+// can be resumed after a restart. Use ChatSession.Save and ChatSession.Load.
+type SessionStore interface {
+	// SaveSession stores the messages of the session with the given ID,
+	// replacing any stored before.
+	SaveSession(ctx context.Context, id string, messages []ChatMessage) error
+
+	// LoadSession returns the messages of the session with the given ID, or
+	// ErrSessionNotFound.
+	LoadSession(ctx context.Context, id string) ([]ChatMessage, error)
+}
+
+// storedMessage is a ChatMessage as it is persisted by the session stores,
+// keeping the transcript and expiry of generated audio, which aren't sent back
+// to the API, but leaving out the audio data itself.
+type storedMessage ChatMessage
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m storedMessage) MarshalJSON() ([]byte, error) {
+	return ChatMessage(m).marshalJSON(true)
+}
+
+// storedMessages returns the messages to marshal for storage.
+func storedMessages(messages []ChatMessage) []storedMessage {
+	stored := make([]storedMessage, len(messages))
+	for i, m := range messages {
+		stored[i] = storedMessage(m)
+	}
+	return stored
+}
+
+// Save stores the conversation history in the store under the given ID.
+func (s *ChatSession) Save(ctx context.Context, store SessionStore, id string) error {
+	return store.SaveSession(ctx, id, s.Messages())
+}
+
+// Load replaces the conversation history with the one stored under the given
+// ID, returning ErrSessionNotFound if there is none.
+func (s *ChatSession) Load(ctx context.Context, store SessionStore, id string) error {
+	messages, err := store.LoadSession(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = messages
+	return nil
+}
+
+// JSONSessionStore is a SessionStore that keeps each session in a JSON file
+// named after its ID, such as "support-42.json", within a directory.
+//
+// # Example
+//
+//	store := &openai.JSONSessionStore{Dir: "sessions"}
+//
+//	err := session.Load(ctx, store, userID)
+//	if err != nil && !errors.Is(err, openai.ErrSessionNotFound) {
+//		return err
+//	}
+//
+//	reply, _ := session.Send(ctx, text)
+//
+//	err = session.Save(ctx, store, userID)
+type JSONSessionStore struct {
+	// Dir is the directory the session files are kept in. It is created when
+	// a session is first saved.
+	//
+	// Required.
+	Dir string
+}
+
+// path returns the path of the file of the session with the given ID.
+func (s *JSONSessionStore) path(id string) (string, error) {
+	if id == "" || !filepath.IsLocal(id) || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("invalid session ID: %q", id)
+	}
+	return filepath.Join(s.Dir, id+".json"), nil
+}
+
+// SaveSession implements the SessionStore interface. The file is replaced
+// atomically, so a crash while saving leaves the previous version intact.
+func (s *JSONSessionStore) SaveSession(ctx context.Context, id string, messages []ChatMessage) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(storedMessages(messages), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.Dir, id+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// LoadSession implements the SessionStore interface.
+func (s *JSONSessionStore) LoadSession(ctx context.Context, id string) ([]ChatMessage, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []ChatMessage
+	if err := json.Unmarshal(b, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode session %q: %w", id, err)
+	}
+
+	return messages, nil
+}
+
+// SQLSessionStore is a SessionStore that keeps sessions in a SQLite database,
+// in a "chat_sessions" table, with the messages of each session encoded as
+// JSON. The database driver, such as modernc.org/sqlite or
+// github.com/mattn/go-sqlite3, must be imported by the program.
+//
+// # Example
+//
+//	db, _ := sql.Open("sqlite", "bot.db")
+//
+//	store, err := openai.NewSQLSessionStore(ctx, db)
+//	if err != nil {
+//		return err
+//	}
+//
+//	err = session.Save(ctx, store, userID)
+type SQLSessionStore struct {
+	db *sql.DB
+}
+
+// NewSQLSessionStore returns a SQLSessionStore using the given database,
+// creating its table if it doesn't exist yet.
+func NewSQLSessionStore(ctx context.Context, db *sql.DB) (*SQLSessionStore, error) {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS chat_sessions (
+		id TEXT PRIMARY KEY,
+		messages TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chat_sessions table: %w", err)
+	}
+
+	return &SQLSessionStore{db: db}, nil
+}
+
+// SaveSession implements the SessionStore interface.
+func (s *SQLSessionStore) SaveSession(ctx context.Context, id string, messages []ChatMessage) error {
+	b, err := json.Marshal(storedMessages(messages))
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO chat_sessions (id, messages, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET messages = excluded.messages, updated_at = excluded.updated_at`,
+		id, string(b), time.Now().UTC(),
+	)
+	return err
+}
+
+// LoadSession implements the SessionStore interface.
+func (s *SQLSessionStore) LoadSession(ctx context.Context, id string) ([]ChatMessage, error) {
+	var b string
+
+	err := s.db.QueryRowContext(ctx, `SELECT messages FROM chat_sessions WHERE id = ?`, id).Scan(&b)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []ChatMessage
+	if err := json.Unmarshal([]byte(b), &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode session %q: %w", id, err)
+	}
+
+	return messages, nil
+}
+
+// WriteMarkdown writes the conversation history to w as a Markdown document,
+// with a heading for each message, such as for auditing conversations.
+func (s *ChatSession) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	for i, m := range s.Messages() {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		role := m.Role
+		if role != "" {
+			role = strings.ToUpper(role[:1]) + role[1:]
+		}
+
+		switch {
+		case m.ToolCallID != "":
+			fmt.Fprintf(&b, "## %s (%s)\n", role, m.ToolCallID)
+		case m.Name != "":
+			fmt.Fprintf(&b, "## %s (%s)\n", role, m.Name)
+		default:
+			fmt.Fprintf(&b, "## %s\n", role)
+		}
+
+		if text := m.text(); text != "" {
+			b.WriteString("\n" + text + "\n")
+		}
+
+		for _, part := range m.ContentParts {
+			if part.Type == ChatContentPartImageURL && part.ImageURL != nil && !strings.HasPrefix(part.ImageURL.URL, "data:") {
+				fmt.Fprintf(&b, "\n![image](%s)\n", part.ImageURL.URL)
+			}
+		}
+
+		calls := m.ToolCalls
+		if m.FunctionCall != nil {
+			calls = append(calls, ToolCall{Type: ToolTypeFunction, Function: m.FunctionCall})
+		}
+
+		for _, call := range calls {
+			if call.Function == nil {
+				continue
+			}
+
+			args, err := json.MarshalIndent(call.Function.Arguments, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(&b, "\nCalled `%s`:\n\n```json\n%s\n```\n", call.Function.Name, args)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,227 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestChatSession(t *testing.T) {
+	var requests [][]openai.ChatMessage
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req openai.CreateChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		requests = append(requests, req.Messages)
+
+		last := req.Messages[len(req.Messages)-1].Content
+
+		if last == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"message": "bad request"}}`)
+			return
+		}
+
+		if req.Stream {
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\",\"content\":\"Why did \"},\"index\":0}]}\n\n")
+			fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"the gopher...\"},\"index\":0}]}\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			return
+		}
+
+		fmt.Fprintf(w, `{"choices": [{"message": {"role": "assistant", "content": "You said: %s"}, "index": 0}]}`, last)
+	})
+
+	session := openai.NewChatSession(c, openai.ModelGPT4, openai.System("Be brief."))
+
+	reply, err := session.Send(testCtx(t), "Hello!")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply != "You said: Hello!" {
+		t.Fatalf("unexpected reply: %q", reply)
+	}
+
+	if _, err := session.Send(testCtx(t), "fail"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	var deltas []string
+	reply, err = session.Stream(testCtx(t), "Tell me a joke.", func(delta string) error {
+		deltas = append(deltas, delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reply != "Why did the gopher..." || len(deltas) != 2 {
+		t.Fatalf("unexpected streamed reply: %q from %q", reply, deltas)
+	}
+
+	var history []string
+	for _, m := range session.Messages() {
+		history = append(history, m.Role+": "+m.Content)
+	}
+
+	want := []string{
+		"system: Be brief.",
+		"user: Hello!",
+		"assistant: You said: Hello!",
+		"user: Tell me a joke.",
+		"assistant: Why did the gopher...",
+	}
+
+	if strings.Join(history, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected history:\n%s", strings.Join(history, "\n"))
+	}
+
+	// The failed message isn't sent again.
+	if n := len(requests[2]); n != 4 {
+		t.Fatalf("expected 4 messages in the third request, got %d", n)
+	}
+
+	session.Reset()
+	if msgs := session.Messages(); len(msgs) != 1 || msgs[0].Role != openai.ChatRoleSystem {
+		t.Fatalf("expected only the system message after reset, got %#+v", msgs)
+	}
+}
+
+func TestChatSession_FitsContextWindow(t *testing.T) {
+	var sent []openai.ChatMessage
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req openai.CreateChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		sent = req.Messages
+
+		fmt.Fprint(w, `{"choices": [{"message": {"role": "assistant", "content": "ok"}, "index": 0}]}`)
+	})
+
+	history := []openai.ChatMessage{openai.System("Be brief.")}
+	for i := 0; i < 100; i++ {
+		history = append(history, openai.User(strings.Repeat("word ", 100)), openai.AssistantMsg("ok"))
+	}
+
+	session := openai.NewChatSession(c, openai.ModelGPT4, history...)
+	session.Defaults.MaxTokens = 1024
+
+	if _, err := session.Send(testCtx(t), "Hello!"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sent) >= len(history) || sent[0].Role != openai.ChatRoleSystem || sent[len(sent)-1].Content != "Hello!" {
+		t.Fatalf("expected the oldest messages to be left out, sent %d messages", len(sent))
+	}
+
+	if n := len(session.Messages()); n != len(history)+2 {
+		t.Fatalf("expected the full history to be kept, got %d messages", n)
+	}
+}
+
+func TestChatSession_SaveLoad(t *testing.T) {
+	ctx := testCtx(t)
+	store := &openai.JSONSessionStore{Dir: filepath.Join(t.TempDir(), "sessions")}
+
+	session := openai.NewChatSession(nil, openai.ModelGPT4o,
+		openai.System("Be brief."),
+		openai.User("What's the weather in Paris?"),
+		openai.ChatMessage{Role: openai.ChatRoleAssistant, ToolCalls: []openai.ToolCall{{
+			ID:       "call_1",
+			Type:     openai.ToolTypeFunction,
+			Function: &openai.FunctionCall{Name: "get_weather", Arguments: openai.FunctionCallArguments{"location": "Paris"}},
+		}}},
+		openai.ToolResult("call_1", "Sunny"),
+		openai.AssistantMsg("It's sunny."),
+	)
+
+	if err := session.Save(ctx, store, "user-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := openai.NewChatSession(nil, openai.ModelGPT4o)
+
+	if err := restored.Load(ctx, store, "user-2"); !errors.Is(err, openai.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+
+	if err := restored.Load(ctx, store, "user-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, want := restored.Messages(), session.Messages()
+	if len(got) != len(want) || got[2].ToolCalls[0].Function.Arguments["location"] != "Paris" || got[4].Content != "It's sunny." {
+		t.Fatalf("unexpected restored messages: %+v", got)
+	}
+
+	if err := session.Save(ctx, store, "../escape"); err == nil {
+		t.Error("expected error for session ID outside the directory")
+	}
+
+	var md strings.Builder
+	if err := restored.WriteMarkdown(&md); err != nil {
+		t.Fatal(err)
+	}
+
+	wantMarkdown := "## System\n\nBe brief.\n\n" +
+		"## User\n\nWhat's the weather in Paris?\n\n" +
+		"## Assistant\n\nCalled `get_weather`:\n\n```json\n{\n  \"location\": \"Paris\"\n}\n```\n\n" +
+		"## Tool (call_1)\n\nSunny\n\n" +
+		"## Assistant\n\nIt's sunny.\n"
+
+	if md.String() != wantMarkdown {
+		t.Errorf("unexpected markdown:\n%s", md.String())
+	}
+}
+
+func TestChatSession_SaveLoadAudio(t *testing.T) {
+	ctx := testCtx(t)
+	store := &openai.JSONSessionStore{Dir: t.TempDir()}
+
+	session := openai.NewChatSession(nil, openai.ModelGPT4oAudioPreview,
+		openai.User("Say hello."),
+		openai.ChatMessage{Role: openai.ChatRoleAssistant, Audio: &openai.ChatMessageAudio{
+			ID:         "audio_1",
+			Data:       "UklGRg==",
+			ExpiresAt:  1700000000,
+			Transcript: "Hello!",
+		}},
+	)
+
+	if err := session.Save(ctx, store, "user-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := openai.NewChatSession(nil, openai.ModelGPT4oAudioPreview)
+	if err := restored.Load(ctx, store, "user-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The audio data isn't stored, but its transcript and expiry are.
+	audio := restored.Messages()[1].Audio
+	if audio == nil || audio.ID != "audio_1" || audio.Data != "" || audio.ExpiresAt != 1700000000 || audio.Transcript != "Hello!" {
+		t.Fatalf("unexpected restored audio: %+v", audio)
+	}
+
+	// Requests only refer to the audio by its ID.
+	b, err := json.Marshal(restored.Messages()[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(b), `"audio":{"id":"audio_1"}`) {
+		t.Fatalf("unexpected request message: %s", b)
+	}
+}
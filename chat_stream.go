@@ -0,0 +1,260 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/picatz/openai/sse"
+)
+
+// ChatStream is a streamed chat response, read one chunk at a time with Next.
+// It must be closed when no longer needed.
+//
+// # Example
+//
+//	stream, _ := c.CreateChatStream(ctx, &openai.CreateChatRequest{
+//		Model:    openai.ModelGPT35Turbo,
+//		Messages: []openai.ChatMessage{openai.User("Hello!")},
+//	})
+//	defer stream.Close()
+//
+//	for {
+//		chunk, err := stream.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		if chunk.ContentDelta() {
+//			fmt.Print(*chunk.Choices[0].Delta.Content)
+//		}
+//	}
+//
+//	fmt.Println(stream.Usage().TotalTokens)
+type ChatStream struct {
+	ctx    context.Context
+	body   io.ReadCloser
+	events *sse.Reader
+
+	// content is the content received so far, for the first choice.
+	content strings.Builder
+
+	// calls accumulates the tool calls of the first choice.
+	calls ToolCallAccumulator
+
+	usage *ChatUsage
+	err   error
+}
+
+func newChatStream(ctx context.Context, body io.ReadCloser, maxEventSize int) *ChatStream {
+	events := sse.NewReader(body)
+	events.MaxEventSize = maxEventSize
+
+	return &ChatStream{
+		ctx:    ctx,
+		body:   body,
+		events: events,
+	}
+}
+
+// CreateChatStream sends a chat request to the API, and returns the streamed
+// response. Unless req sets StreamOptions, the final usage of the completion is
+// requested, and made available with Usage once the stream is read.
+func (c *Client) CreateChatStream(ctx context.Context, req *CreateChatRequest) (*ChatStream, error) {
+	r := *req
+	r.Stream = true
+
+	if r.StreamOptions == nil {
+		r.StreamOptions = &ChatStreamOptions{IncludeUsage: true}
+	}
+
+	res, err := c.CreateChat(ctx, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return newChatStream(ctx, res.Stream, res.maxEventSize), nil
+}
+
+// Next returns the next chunk of the stream, or io.EOF once the stream is done.
+//
+// If the stream fails partway through, due to an error event, a malformed
+// chunk, or the context being cancelled, a *StreamError is returned with the
+// content received so far. Once Next returns an error, it returns the same
+// error on every following call.
+func (s *ChatStream) Next() (*ChatMessageStreamChunk, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if err := s.ctx.Err(); err != nil {
+		return nil, s.fail(err)
+	}
+
+	event, err := s.events.Next()
+	if err == io.EOF {
+		s.err = io.EOF
+		return nil, s.err
+	}
+	if err != nil {
+		// Prefer the context error if the read failed due to cancellation.
+		if ctx := s.ctx.Err(); ctx != nil {
+			err = ctx
+		}
+		return nil, s.fail(err)
+	}
+
+	data := bytes.TrimSpace(event.Data)
+
+	// Check if data is [DONE].
+	if bytes.Equal(data, []byte("[DONE]")) {
+		s.err = io.EOF
+		return nil, s.err
+	}
+
+	// Return error payloads sent mid-stream.
+	if err := streamError(data); err != nil {
+		return nil, s.fail(err)
+	}
+
+	if event.Event == "error" {
+		apiErr := &APIError{}
+		if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = string(data)
+		}
+		return nil, s.fail(apiErr)
+	}
+
+	var chunk ChatMessageStreamChunk
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return nil, s.fail(fmt.Errorf("malformed chunk: %w", err))
+	}
+
+	if chunk.ContentDelta() {
+		s.content.WriteString(*chunk.Choices[0].Delta.Content)
+	}
+
+	s.calls.Add(&chunk)
+
+	if chunk.Usage != nil {
+		s.usage = chunk.Usage
+	}
+
+	return &chunk, nil
+}
+
+// fail records err as a *StreamError, to be returned by Next from now on.
+func (s *ChatStream) fail(err error) error {
+	s.err = &StreamError{Err: err, Partial: s.content.String()}
+	return s.err
+}
+
+// Content returns the content of the first choice received so far.
+func (s *ChatStream) Content() string {
+	return s.content.String()
+}
+
+// ToolCalls returns the tool calls of the first choice, once the stream has
+// been read to the end.
+func (s *ChatStream) ToolCalls() ([]ToolCall, error) {
+	return s.calls.ToolCalls()
+}
+
+// Usage returns the token usage of the completion, or nil if it hasn't been
+// received (yet).
+func (s *ChatStream) Usage() *ChatUsage {
+	return s.usage
+}
+
+// Close closes the stream.
+func (s *ChatStream) Close() error {
+	return s.body.Close()
+}
+
+// StreamText reads a streamed chat response, calling fn with each content delta
+// of the first choice as it arrives, and returns the full content.
+//
+// If the stream fails partway through, including when the context is cancelled,
+// the content received so far is returned along with the error, so callers can
+// keep what was already rendered.
+//
+// # Example
+//
+//	text, err := resp.StreamText(ctx, func(delta string) error {
+//		fmt.Print(delta)
+//		return nil
+//	})
+//	if errors.Is(err, context.Canceled) {
+//		// text holds the partial reply.
+//	}
+func (r *CreateChatResponse) StreamText(ctx context.Context, fn func(delta string) error) (string, error) {
+	var text strings.Builder
+
+	err := r.ReadStream(ctx, func(chunk *ChatMessageStreamChunk) error {
+		if !chunk.ContentDelta() {
+			return nil
+		}
+
+		delta := *chunk.Choices[0].Delta.Content
+		text.WriteString(delta)
+
+		if fn == nil {
+			return nil
+		}
+		return fn(delta)
+	})
+
+	var streamErr *StreamError
+	if errors.As(err, &streamErr) {
+		return streamErr.Partial, err
+	}
+
+	return text.String(), err
+}
+
+// CollectStream reads a streamed chat response to completion, and returns the
+// assistant message it contains, including any tool calls.
+//
+// If the stream fails partway through, including when the context is cancelled,
+// the partial message received so far is returned along with the error.
+func (r *CreateChatResponse) CollectStream(ctx context.Context) (*ChatMessage, error) {
+	var (
+		text  strings.Builder
+		calls ToolCallAccumulator
+	)
+
+	err := r.ReadStream(ctx, func(chunk *ChatMessageStreamChunk) error {
+		calls.Add(chunk)
+		if chunk.ContentDelta() {
+			text.WriteString(*chunk.Choices[0].Delta.Content)
+		}
+		return nil
+	})
+
+	msg := &ChatMessage{
+		Role:    ChatRoleAssistant,
+		Content: text.String(),
+	}
+
+	var streamErr *StreamError
+	if errors.As(err, &streamErr) {
+		msg.Content = streamErr.Partial
+	}
+	if err != nil {
+		return msg, err
+	}
+
+	msg.ToolCalls, err = calls.ToolCalls()
+	if err != nil {
+		return msg, err
+	}
+
+	msg.FunctionCall, err = calls.FunctionCall()
+	return msg, err
+}
@@ -0,0 +1,150 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestStreamText_Cancelled(t *testing.T) {
+	// Stream two deltas, then hang until the request is cancelled, like a
+	// real HTTP response body would.
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		pr, pw := io.Pipe()
+
+		go func() {
+			fmt.Fprint(pw, "data: {\"choices\":[{\"delta\":{\"content\":\"Once upon\"},\"index\":0}]}\n\n")
+			fmt.Fprint(pw, "data: {\"choices\":[{\"delta\":{\"content\":\" a time\"},\"index\":0}]}\n\n")
+			<-r.Context().Done()
+			pw.CloseWithError(r.Context().Err())
+		}()
+
+		return &http.Response{StatusCode: http.StatusOK, Body: pr, Header: http.Header{}}, nil
+	})
+
+	c := openai.NewClient("test", openai.WithHTTPClient(&http.Client{Transport: transport}))
+
+	ctx, cancel := context.WithCancel(testCtx(t))
+	defer cancel()
+
+	resp, err := c.CreateChat(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT35Turbo,
+		Messages: []openai.ChatMessage{openai.User("Tell me a story.")},
+		Stream:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var deltas int
+	text, err := resp.StreamText(ctx, func(delta string) error {
+		deltas++
+		if deltas == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if text != "Once upon a time" {
+		t.Fatalf("unexpected partial text: %q", text)
+	}
+}
+
+func TestCollectStream(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"},\"index\":0}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Hello\"},\"index\":0}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"!\"},\"index\":0}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	ctx := testCtx(t)
+
+	resp, err := c.CreateChat(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT35Turbo,
+		Messages: []openai.ChatMessage{openai.User("Hi")},
+		Stream:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := resp.CollectStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Role != openai.ChatRoleAssistant || msg.Content != "Hello!" {
+		t.Fatalf("unexpected message: %#+v", msg)
+	}
+}
+
+func TestCreateChatStream(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(b), `"stream":true`) || !strings.Contains(string(b), `"stream_options":{"include_usage":true}`) {
+			t.Errorf("unexpected request body: %s", b)
+		}
+
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"},\"index\":0}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Hello\"},\"index\":0}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\" there\"},\"index\":0}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":2,\"total_tokens\":7}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}
+
+	ctx := testCtx(t)
+
+	stream, err := testClient(t, h).CreateChatStream(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT35Turbo,
+		Messages: []openai.ChatMessage{openai.User("Hi")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	var chunks int
+	for {
+		_, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks++
+	}
+
+	if chunks != 4 {
+		t.Fatalf("expected 4 chunks, got %d", chunks)
+	}
+
+	if stream.Content() != "Hello there" {
+		t.Fatalf("unexpected content: %q", stream.Content())
+	}
+
+	if usage := stream.Usage(); usage == nil || usage.TotalTokens != 7 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+
+	if _, err := stream.Next(); err != io.EOF {
+		t.Fatalf("expected io.EOF after the end of the stream, got %v", err)
+	}
+}
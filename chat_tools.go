@@ -0,0 +1,351 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ToolTypeFunction is the type of function tools, currently the only type of
+// tool supported by the chat API.
+const ToolTypeFunction = "function"
+
+// Tool is a tool the model may call.
+//
+// https://platform.openai.com/docs/api-reference/chat/create#chat-create-tools
+type Tool struct {
+	// Type is the type of the tool, currently only "function".
+	//
+	// Required.
+	Type string `json:"type"`
+
+	// Function describes the function the model may call.
+	//
+	// Required.
+	Function *Function `json:"function"`
+}
+
+// FunctionTool returns a Tool for the given function.
+func FunctionTool(fn *Function) Tool {
+	return Tool{
+		Type:     ToolTypeFunction,
+		Function: fn,
+	}
+}
+
+// ToolCall is a call to a tool generated by the model. The result of the call
+// is sent back to the model with a ToolResult message.
+//
+// https://platform.openai.com/docs/api-reference/chat/object#chat/object-choices
+type ToolCall struct {
+	// ID is the ID of the tool call, referenced by the ToolCallID of the message
+	// containing its result.
+	ID string `json:"id"`
+
+	// Type is the type of the tool, currently only "function".
+	Type string `json:"type"`
+
+	// Function is the name and arguments of the function to call.
+	Function *FunctionCall `json:"function"`
+}
+
+// ToolChoice controls which, if any, tool is called by the model. It is either
+// a ToolChoiceMode, or a ToolChoiceFunction to force a particular function.
+//
+// https://platform.openai.com/docs/api-reference/chat/create#chat-create-tool_choice
+type ToolChoice interface {
+	isToolChoice()
+}
+
+// ToolChoiceMode is a ToolChoice that lets the model decide whether to call
+// tools, prevents it from calling any, or requires it to call at least one.
+type ToolChoiceMode string
+
+const (
+	// ToolChoiceAuto lets the model pick between responding and calling tools.
+	// It is the default when tools are present.
+	ToolChoiceAuto ToolChoiceMode = "auto"
+
+	// ToolChoiceNone prevents the model from calling tools.
+	ToolChoiceNone ToolChoiceMode = "none"
+
+	// ToolChoiceRequired requires the model to call one or more tools.
+	ToolChoiceRequired ToolChoiceMode = "required"
+)
+
+func (ToolChoiceMode) isToolChoice() {}
+
+// ToolChoiceFunction is a ToolChoice that forces the model to call the function
+// with the given name.
+type ToolChoiceFunction string
+
+func (ToolChoiceFunction) isToolChoice() {}
+
+// MarshalJSON marshals the tool choice into a JSON object.
+func (f ToolChoiceFunction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type": ToolTypeFunction,
+		"function": map[string]string{
+			"name": string(f),
+		},
+	})
+}
+
+// ToolCallDelta is a fragment of a tool call in a streamed chat response. The
+// fragments of each tool call share an Index, and are combined with a
+// ToolCallAccumulator.
+//
+// https://platform.openai.com/docs/api-reference/chat/streaming#chat/streaming-choices
+type ToolCallDelta struct {
+	// Index is the position of the tool call in the message.
+	Index int `json:"index"`
+
+	// ID is the ID of the tool call, sent in its first fragment.
+	ID string `json:"id,omitempty"`
+
+	// Type is the type of the tool, sent in its first fragment.
+	Type string `json:"type,omitempty"`
+
+	// Function is the fragment of the function call.
+	Function *FunctionCallDelta `json:"function,omitempty"`
+}
+
+// FunctionCallDelta is a fragment of a function call in a streamed chat
+// response. The name is sent in the first fragment, and the JSON arguments are
+// split across fragments.
+type FunctionCallDelta struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// ToolCallAccumulator reconstructs the tool calls, or legacy function call, of
+// the first choice of a streamed chat response from its deltas.
+//
+// # Example
+//
+//	var acc openai.ToolCallAccumulator
+//
+//	err := resp.ReadStream(ctx, func(chunk *openai.ChatMessageStreamChunk) error {
+//		acc.Add(chunk)
+//		return nil
+//	})
+//
+//	calls, err := acc.ToolCalls()
+//
+// To handle each tool call as soon as its arguments are complete, rather than
+// once the stream ends, use Push instead of Add.
+type ToolCallAccumulator struct {
+	// Tools are the tools sent with the request. If set, Push validates each
+	// call against them, returning an error for calls to unknown functions or
+	// calls missing required arguments.
+	Tools []Tool
+
+	calls []*toolCallBuilder
+
+	function *toolCallBuilder
+}
+
+// maxToolCalls is the maximum number of tool calls accumulated per message.
+const maxToolCalls = 128
+
+type toolCallBuilder struct {
+	id, typ, name string
+	args          strings.Builder
+
+	// pushed is set once the call has been returned by Push.
+	pushed bool
+}
+
+func (b *toolCallBuilder) add(id, typ string, fn *FunctionCallDelta) {
+	if id != "" {
+		b.id = id
+	}
+	if typ != "" {
+		b.typ = typ
+	}
+	if fn != nil {
+		b.name += fn.Name
+		b.args.WriteString(fn.Arguments)
+	}
+}
+
+func (b *toolCallBuilder) functionCall() (*FunctionCall, error) {
+	args := FunctionCallArguments{}
+
+	if s := strings.TrimSpace(b.args.String()); s != "" {
+		if err := json.Unmarshal([]byte(s), &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments for function %q: %w", b.name, err)
+		}
+	}
+
+	return &FunctionCall{Name: b.name, Arguments: args}, nil
+}
+
+// Add adds the deltas of the first choice of the given chunk.
+func (a *ToolCallAccumulator) Add(chunk *ChatMessageStreamChunk) {
+	if chunk == nil || len(chunk.Choices) == 0 {
+		return
+	}
+
+	delta := chunk.Choices[0].Delta
+
+	for _, d := range delta.ToolCalls {
+		// Ignore nonsensical indexes, rather than allocating for them.
+		if d.Index < 0 || d.Index >= maxToolCalls {
+			continue
+		}
+
+		for len(a.calls) <= d.Index {
+			a.calls = append(a.calls, &toolCallBuilder{})
+		}
+
+		a.calls[d.Index].add(d.ID, d.Type, d.Function)
+	}
+
+	if delta.FunctionCall != nil {
+		if a.function == nil {
+			a.function = &toolCallBuilder{}
+		}
+		a.function.add("", "", delta.FunctionCall)
+	}
+}
+
+// toolCall returns the tool call being built.
+func (b *toolCallBuilder) toolCall() (ToolCall, error) {
+	fn, err := b.functionCall()
+	if err != nil {
+		return ToolCall{}, err
+	}
+
+	typ := b.typ
+	if typ == "" {
+		typ = ToolTypeFunction
+	}
+
+	return ToolCall{ID: b.id, Type: typ, Function: fn}, nil
+}
+
+// complete reports whether the call's arguments are a complete JSON value.
+func (b *toolCallBuilder) complete() bool {
+	args := strings.TrimSpace(b.args.String())
+	return args != "" && json.Valid([]byte(args))
+}
+
+// Push adds the deltas of the first choice of the given chunk, like Add, and
+// returns the tool calls completed by it, in order, so each can be handled
+// while the rest of the response streams in.
+//
+// A call is complete once the model starts the next call and its arguments
+// are valid JSON, or once the choice finishes. A legacy function call is
+// returned as a ToolCall without an ID when the choice finishes.
+//
+// An error is returned if the arguments of a finished call aren't valid JSON,
+// or if a call fails validation against Tools.
+//
+// # Example
+//
+//	acc := openai.ToolCallAccumulator{Tools: req.Tools}
+//
+//	err := resp.ReadStream(ctx, func(chunk *openai.ChatMessageStreamChunk) error {
+//		calls, err := acc.Push(chunk)
+//		if err != nil {
+//			return err
+//		}
+//		for _, call := range calls {
+//			go handle(call)
+//		}
+//		return nil
+//	})
+func (a *ToolCallAccumulator) Push(chunk *ChatMessageStreamChunk) ([]ToolCall, error) {
+	a.Add(chunk)
+
+	finished := chunk != nil && len(chunk.Choices) > 0 && chunk.Choices[0].FinishReason != nil && chunk.Choices[0].FinishReason != ""
+
+	var calls []ToolCall
+
+	for i, b := range a.calls {
+		if b.pushed || !(finished || (i < len(a.calls)-1 && b.complete())) {
+			continue
+		}
+
+		call, err := b.toolCall()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.validate(call.Function); err != nil {
+			return nil, err
+		}
+
+		b.pushed = true
+		calls = append(calls, call)
+	}
+
+	if finished && a.function != nil && !a.function.pushed {
+		fn, err := a.function.functionCall()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.validate(fn); err != nil {
+			return nil, err
+		}
+
+		a.function.pushed = true
+		calls = append(calls, ToolCall{Type: ToolTypeFunction, Function: fn})
+	}
+
+	return calls, nil
+}
+
+// validate checks the function call against the accumulator's tools, if any.
+func (a *ToolCallAccumulator) validate(fn *FunctionCall) error {
+	if len(a.Tools) == 0 {
+		return nil
+	}
+
+	i := slices.IndexFunc(a.Tools, func(t Tool) bool {
+		return t.Function != nil && t.Function.Name == fn.Name
+	})
+	if i < 0 {
+		return fmt.Errorf("call to unknown function %q", fn.Name)
+	}
+
+	if params := a.Tools[i].Function.Parameters; params != nil {
+		for _, name := range params.Required {
+			if _, ok := fn.Arguments[name]; !ok {
+				return fmt.Errorf("call to function %q is missing required argument %q", fn.Name, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ToolCalls returns the complete tool calls, in order. It returns an error if
+// the arguments of a call aren't valid JSON, such as when the stream ended early.
+func (a *ToolCallAccumulator) ToolCalls() ([]ToolCall, error) {
+	calls := make([]ToolCall, 0, len(a.calls))
+
+	for _, b := range a.calls {
+		call, err := b.toolCall()
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, call)
+	}
+
+	return calls, nil
+}
+
+// FunctionCall returns the complete legacy function call, or nil if there
+// wasn't one.
+func (a *ToolCallAccumulator) FunctionCall() (*FunctionCall, error) {
+	if a.function == nil {
+		return nil, nil
+	}
+
+	return a.function.functionCall()
+}
@@ -0,0 +1,209 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestCreateChat_Tools(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Tools []struct {
+				Type     string `json:"type"`
+				Function struct {
+					Name string `json:"name"`
+				} `json:"function"`
+			} `json:"tools"`
+			ToolChoice json.RawMessage `json:"tool_choice"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(body.Tools) != 1 || body.Tools[0].Type != "function" || body.Tools[0].Function.Name != "get_weather" {
+			t.Errorf("unexpected tools: %+v", body.Tools)
+		}
+
+		if string(body.ToolChoice) != `"required"` {
+			t.Errorf("unexpected tool choice: %s", body.ToolChoice)
+		}
+
+		fmt.Fprint(w, `{"choices":[{"index":0,"finish_reason":"tool_calls","message":{"role":"assistant","content":null,"tool_calls":[
+			{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\":\"Boston\"}"}},
+			{"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"location\":\"Paris\"}"}}
+		]}}]}`)
+	}
+
+	ctx := testCtx(t)
+
+	resp, err := testClient(t, h).CreateChat(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT4,
+		Messages: []openai.ChatMessage{openai.User("What's the weather in Boston and Paris?")},
+		Tools: []openai.Tool{
+			openai.FunctionTool(&openai.Function{
+				Name:       "get_weather",
+				Parameters: &openai.JSONSchema{Type: "object"},
+			}),
+		},
+		ToolChoice: openai.ToolChoiceRequired,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := resp.Choices[0].Message.ToolCalls
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(calls))
+	}
+
+	if calls[1].ID != "call_2" || calls[1].Function.Name != "get_weather" || calls[1].Function.Arguments["location"] != "Paris" {
+		t.Fatalf("unexpected tool call: %+v", calls[1])
+	}
+}
+
+func TestToolChoice_MarshalJSON(t *testing.T) {
+	tests := map[openai.ToolChoice]string{
+		openai.ToolChoiceAuto:                    `"auto"`,
+		openai.ToolChoiceNone:                    `"none"`,
+		openai.ToolChoiceRequired:                `"required"`,
+		openai.ToolChoiceFunction("get_weather"): `{"function":{"name":"get_weather"},"type":"function"}`,
+	}
+
+	for choice, want := range tests {
+		b, err := json.Marshal(choice)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != want {
+			t.Errorf("got %s, want %s", b, want)
+		}
+	}
+}
+
+func TestToolCallAccumulator(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		for _, chunk := range []string{
+			`{"choices":[{"index":0,"delta":{"role":"assistant","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"loca"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"get_time","arguments":"{}"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"tion\":\"Boston\"}"}}]}}]}`,
+			`{"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+		} {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}
+
+	ctx := testCtx(t)
+
+	resp, err := testClient(t, h).CreateChat(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT4,
+		Messages: []openai.ChatMessage{openai.User("What's the weather and time in Boston?")},
+		Stream:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := resp.CollectStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(msg.ToolCalls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(msg.ToolCalls))
+	}
+
+	first, second := msg.ToolCalls[0], msg.ToolCalls[1]
+
+	if first.ID != "call_1" || first.Function.Name != "get_weather" || first.Function.Arguments["location"] != "Boston" {
+		t.Fatalf("unexpected first tool call: %+v", first.Function)
+	}
+
+	if second.ID != "call_2" || second.Function.Name != "get_time" || len(second.Function.Arguments) != 0 {
+		t.Fatalf("unexpected second tool call: %+v", second.Function)
+	}
+}
+
+func TestToolCallAccumulator_Incomplete(t *testing.T) {
+	var chunk openai.ChatMessageStreamChunk
+	if err := json.Unmarshal([]byte(`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","function":{"name":"f","arguments":"{\"a\":"}}]}}]}`), &chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	var acc openai.ToolCallAccumulator
+	acc.Add(&chunk)
+
+	if _, err := acc.ToolCalls(); err == nil {
+		t.Fatal("expected error for incomplete arguments")
+	}
+}
+
+func TestToolCallAccumulator_Push(t *testing.T) {
+	acc := openai.ToolCallAccumulator{
+		Tools: []openai.Tool{
+			openai.FunctionTool(&openai.Function{Name: "get_weather", Parameters: &openai.JSONSchema{Type: "object", Required: []string{"location"}}}),
+			openai.FunctionTool(&openai.Function{Name: "get_time"}),
+		},
+	}
+
+	push := func(data string) []openai.ToolCall {
+		t.Helper()
+
+		var chunk openai.ChatMessageStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			t.Fatal(err)
+		}
+
+		calls, err := acc.Push(&chunk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return calls
+	}
+
+	if calls := push(`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"loca"}}]}}]}`); len(calls) != 0 {
+		t.Fatalf("expected no complete calls, got %#+v", calls)
+	}
+
+	if calls := push(`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"tion\":\"Boston\"}"}}]}}]}`); len(calls) != 0 {
+		t.Fatalf("expected no complete calls before the next call starts, got %#+v", calls)
+	}
+
+	calls := push(`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"get_time","arguments":"{}"}}]}}]}`)
+	if len(calls) != 1 || calls[0].ID != "call_1" || calls[0].Function.Arguments["location"] != "Boston" {
+		t.Fatalf("expected the first call to be complete, got %#+v", calls)
+	}
+
+	calls = push(`{"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`)
+	if len(calls) != 1 || calls[0].ID != "call_2" || calls[0].Function.Name != "get_time" {
+		t.Fatalf("expected the second call to be complete, got %#+v", calls)
+	}
+
+	if calls := push(`{"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`); len(calls) != 0 {
+		t.Fatalf("expected calls to be returned once, got %#+v", calls)
+	}
+}
+
+func TestToolCallAccumulator_PushValidation(t *testing.T) {
+	var chunk openai.ChatMessageStreamChunk
+	if err := json.Unmarshal([]byte(`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","function":{"name":"get_weather","arguments":"{}"}}]},"finish_reason":"tool_calls"}]}`), &chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tools := range map[string][]openai.Tool{
+		"missing argument": {openai.FunctionTool(&openai.Function{Name: "get_weather", Parameters: &openai.JSONSchema{Type: "object", Required: []string{"location"}}})},
+		"unknown function": {openai.FunctionTool(&openai.Function{Name: "get_time"})},
+	} {
+		acc := openai.ToolCallAccumulator{Tools: tools}
+		if _, err := acc.Push(&chunk); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
@@ -1,32 +1,105 @@
 package openai
 
 import (
-	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"math/rand"
-	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
+	"path"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/picatz/openai/sse"
 )
 
+// DefaultBaseURL is the base URL of the OpenAI API, used by clients without a
+// BaseURL.
+const DefaultBaseURL = "https://api.openai.com/v1"
+
 // Client is a client for the OpenAI API.
 //
 // https://platform.openai.com/docs/api-reference
 type Client struct {
-	// APIKey is the API key to use for requests.
+	// APIKey is the API key to use for requests, unless TokenProvider is set.
 	APIKey string
 
+	// TokenProvider provides the token to use for requests instead of APIKey,
+	// such as to refresh short-lived credentials.
+	TokenProvider TokenProvider
+
+	// BaseURL is the base URL that request paths, such as "/chat/completions",
+	// are appended to. If empty, DefaultBaseURL is used.
+	BaseURL string
+
 	// HTTPClient is the HTTP client to use for requests.
 	HTTPClient *http.Client
 
 	// Organization is the organization to use for requests.
 	Organization string
+
+	// AdminKey is the admin API key to use for organization management
+	// requests (/v1/organization/*). It is never sent to any other endpoint.
+	AdminKey string
+
+	// MaxStreamEventSize is the maximum size in bytes of a single streamed
+	// event, or 0 for no limit.
+	MaxStreamEventSize int
+
+	// MaxResponseBytes is the maximum size in bytes of a non-streamed response
+	// body, or 0 for no limit.
+	MaxResponseBytes int64
+
+	// MaxAttempts is the maximum number of attempts for a request failing with
+	// a 429 or 5xx status code, or 0 or 1 to never retry.
+	MaxAttempts int
+
+	// RetryPolicy configures the backoff between retried requests.
+	RetryPolicy RetryPolicy
+
+	// APIVersion is the dated API version to pin requests to, such as
+	// "2024-05-01", or empty to use the account's default.
+	APIVersion string
+
+	// BetaVersions overrides the versions of beta features sent in the
+	// OpenAI-Beta header, keyed by feature name, such as "assistants".
+	BetaVersions map[string]string
+
+	// Middleware wraps the sending of every API request, the first being the
+	// outermost.
+	Middleware []Middleware
+
+	// Headers are additional headers sent with every request, such as for a
+	// gateway or tracing. They override the client's default headers, except
+	// for Authorization.
+	Headers http.Header
+
+	// DisableIdempotencyKeys disables the random Idempotency-Key header sent
+	// with each POST request, and kept across its retries.
+	DisableIdempotencyKeys bool
+
+	// ModelCacheTTL is how long the model catalog returned by ListModels is
+	// cached for, or 0 to not cache it.
+	ModelCacheTTL time.Duration
+
+	// AutoModeration runs the user messages of every chat request through
+	// CreateModeration before sending it, as enabled by WithAutoModeration.
+	AutoModeration bool
+
+	// models is the cached model catalog.
+	models modelCache
+
+	// keyPool authenticates requests instead of APIKey or TokenProvider, as
+	// set by WithKeyPool.
+	keyPool *KeyPool
 }
 
 // ClientOption is a function that configures a Client.
@@ -44,6 +117,15 @@ func WithHTTPClient(c *http.Client) ClientOption {
 	}
 }
 
+// WithBaseURL is a ClientOption that sets the base URL for requests, such as
+// "http://localhost:8000/v1", to target Azure OpenAI, a local gateway, or an
+// OpenAI-compatible server like vLLM.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(client *Client) {
+		client.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
 // WithOrganization is a ClientOption that sets the organization to use for requests.
 //
 // https://platform.openai.com/docs/api-reference/authentication
@@ -53,6 +135,90 @@ func WithOrganization(org string) ClientOption {
 	}
 }
 
+// WithAdminKey is a ClientOption that sets the admin API key used for
+// organization management requests, such as ListProjectUsers or GetCosts.
+// All other requests continue to use the client's API key.
+//
+// https://platform.openai.com/docs/api-reference/administration
+func WithAdminKey(key string) ClientOption {
+	return func(client *Client) {
+		client.AdminKey = key
+	}
+}
+
+// WithMaxStreamEventSize is a ClientOption that limits the size of a single
+// event read from a streamed response, such as a chat completion chunk. Larger
+// events cause ReadStream to return ErrStreamEventTooLarge.
+//
+// By default there is no limit.
+func WithMaxStreamEventSize(n int) ClientOption {
+	return func(client *Client) {
+		client.MaxStreamEventSize = n
+	}
+}
+
+// WithMaxResponseBytes is a ClientOption that limits the size of non-streamed
+// response bodies, protecting against unexpectedly large payloads. Requests
+// with larger responses fail with a *ResponseTooLargeError.
+//
+// Streamed responses, and raw bodies like file contents and speech audio, are
+// not limited.
+//
+// By default there is no limit.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(client *Client) {
+		client.MaxResponseBytes = n
+	}
+}
+
+// WithAPIVersion is a ClientOption that pins requests to the given dated API
+// version, sent as the OpenAI-Version header. Beta feature flags, such as the
+// OpenAI-Beta header required by the Assistants API, are chosen to match.
+//
+// Pinning a version keeps behavior stable until it is deliberately rolled forward.
+func WithAPIVersion(date string) ClientOption {
+	return func(client *Client) {
+		client.APIVersion = date
+	}
+}
+
+// WithBetaVersion is a ClientOption that sets the version of a beta feature
+// sent in the OpenAI-Beta header, overriding the version chosen by the API
+// version, such as to keep using "assistants=v1".
+func WithBetaVersion(feature, version string) ClientOption {
+	return func(client *Client) {
+		if client.BetaVersions == nil {
+			client.BetaVersions = map[string]string{}
+		}
+		client.BetaVersions[feature] = version
+	}
+}
+
+// WithHeaders is a ClientOption that adds headers sent with every request, such
+// as an OpenAI-Beta override, gateway credentials, or tracing headers. A header
+// given more than once keeps every value.
+//
+// The headers override the client's default headers, except for Authorization,
+// which is always set from the client's API key or TokenProvider.
+//
+// # Example
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithHeaders(http.Header{
+//		"X-Gateway-Key": {os.Getenv("GATEWAY_KEY")},
+//	}))
+func WithHeaders(headers http.Header) ClientOption {
+	return func(client *Client) {
+		if client.Headers == nil {
+			client.Headers = http.Header{}
+		}
+		for k, v := range headers {
+			for _, value := range v {
+				client.Headers.Add(k, value)
+			}
+		}
+	}
+}
+
 // NewClient returns a new Client with the given API key.
 //
 // # Example
@@ -71,6 +237,212 @@ func NewClient(apiKey string, opts ...ClientOption) *Client {
 	return c
 }
 
+// NewAdminClient returns a new Client that only holds an admin API key. It can
+// be used for organization management requests, and refuses to make requests to
+// any other endpoint.
+//
+// # Example
+//
+//	c := openai.NewAdminClient(os.Getenv("OPENAI_ADMIN_KEY"))
+func NewAdminClient(adminKey string, opts ...ClientOption) *Client {
+	return NewClient("", append([]ClientOption{WithAdminKey(adminKey)}, opts...)...)
+}
+
+// setHeaders sets the Authorization, organization, version, beta, idempotency
+// and custom headers for r, so they are sent consistently by every request.
+//
+// The token comes from the client's TokenProvider, or its API key. The admin
+// key is used for organization management endpoints when one is configured.
+// The path is cleaned before it is checked, so the admin key can't be smuggled
+// to another endpoint through "..", and a client with only an admin key
+// refuses other requests.
+func (c *Client) setHeaders(r *http.Request) error {
+	p := c.apiPath(r.URL)
+
+	switch {
+	case c.usesAdminKey(p):
+		r.Header.Set("Authorization", "Bearer "+c.AdminKey)
+	case c.keyPool != nil:
+		// The key is set by the pool's middleware.
+	case c.APIKey == "" && c.TokenProvider == nil && c.AdminKey != "":
+		return fmt.Errorf("refusing to send admin API key to non-organization endpoint: %s", r.URL.Path)
+	default:
+		token, err := c.token(r.Context())
+		if err != nil {
+			return fmt.Errorf("failed to get API token: %w", err)
+		}
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if c.Organization != "" {
+		r.Header.Set("OpenAI-Organization", c.Organization)
+	}
+
+	if c.APIVersion != "" {
+		r.Header.Set("OpenAI-Version", c.APIVersion)
+	}
+
+	if beta := betaHeader(c.APIVersion, p, c.BetaVersions); beta != "" {
+		r.Header.Set("OpenAI-Beta", beta)
+	}
+
+	c.setIdempotencyKey(r)
+
+	for k, v := range c.Headers {
+		if http.CanonicalHeaderKey(k) == "Authorization" {
+			continue
+		}
+		r.Header[http.CanonicalHeaderKey(k)] = v
+	}
+
+	return nil
+}
+
+// endpoint returns the URL for the given API path, such as "/models".
+func (c *Client) endpoint(path string) string {
+	if c.BaseURL == "" {
+		return DefaultBaseURL + path
+	}
+	return c.BaseURL + path
+}
+
+// usesAdminKey reports whether requests to the API path, as returned by
+// apiPath, are authenticated with the client's admin key.
+func (c *Client) usesAdminKey(p string) bool {
+	return c.AdminKey != "" && strings.HasPrefix(p, "/organization/")
+}
+
+// apiPath returns the cleaned path of u relative to the client's base URL, such
+// as "/models", or an empty string if u isn't under the base URL.
+func (c *Client) apiPath(u *url.URL) string {
+	base, err := url.Parse(c.endpoint(""))
+	if err != nil || base.Host != u.Host {
+		return ""
+	}
+
+	p := path.Clean(u.Path)
+
+	prefix := strings.TrimSuffix(base.Path, "/")
+	if prefix != "" && !strings.HasPrefix(p, prefix+"/") {
+		return ""
+	}
+
+	return strings.TrimPrefix(p, prefix)
+}
+
+// limitBody limits the given response body to the client's MaxResponseBytes.
+func (c *Client) limitBody(body io.Reader) io.Reader {
+	if c.MaxResponseBytes <= 0 {
+		return body
+	}
+
+	return &limitedReader{
+		r:     io.LimitReader(body, c.MaxResponseBytes+1),
+		limit: c.MaxResponseBytes,
+	}
+}
+
+// doRequest sends a request with the given body against the given API path
+// (e.g. "/files"), setting the content type if it is not empty, and asking for
+// a gzip-encoded response, which is decoded by roundTrip. Responses
+// other than 200 OK are closed and returned as an *APIError; otherwise the
+// caller must close the response body.
+func (c *Client) doRequest(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	r, err := http.NewRequestWithContext(ctx, method, c.endpoint(path), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if sr, ok := body.(*sizedReader); ok {
+		r.ContentLength = sr.size
+	}
+
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	r.Header.Set("Accept-Encoding", "gzip")
+
+	err = c.setHeaders(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(c.limitBody(resp.Body))
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+
+	return resp, nil
+}
+
+// do performs a JSON request against the given API path (e.g. "/models"), encoding
+// in as the request body if it is not nil, and decoding the response body into out
+// if it is not nil.
+func (c *Client) do(ctx context.Context, method, path string, in, out any) error {
+	var (
+		body        io.Reader
+		contentType string
+	)
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+		contentType = "application/json"
+	}
+
+	resp, err := c.doRequest(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(c.limitBody(resp.Body)).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// listQuery encodes the common cursor pagination parameters as a query string,
+// including the leading "?" if any parameters are set.
+func listQuery(limit int, order SortOrder, after, before string) string {
+	q := url.Values{}
+
+	if limit != 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	if order != "" {
+		q.Set("order", string(order))
+	}
+
+	if after != "" {
+		q.Set("after", after)
+	}
+
+	if before != "" {
+		q.Set("before", before)
+	}
+
+	if len(q) == 0 {
+		return ""
+	}
+
+	return "?" + q.Encode()
+}
+
 // Role is the role of the user for a chat message.
 type Role = string
 
@@ -229,70 +601,50 @@ type CreateCompletionResponse struct {
 // [deprecated]: https://platform.openai.com/docs/guides/gpt/completions-api
 // [chat completions]: https://platform.openai.com/docs/api-reference/chat/create
 func (c *Client) CreateCompletion(ctx context.Context, req *CreateCompletionRequest) (*CreateCompletionResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/completions", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("Content-Type", "application/json")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
-
-	cResp := &CreateCompletionResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+	var res CreateCompletionResponse
+	err := c.do(ctx, http.MethodPost, "/completions", req, &res)
 	if err != nil {
 		return nil, err
 	}
+	return &res, nil
+}
 
-	return cResp, nil
+// https://platform.openai.com/docs/api-reference/models/object
+type ModelInfo struct {
+	ID         string            `json:"id"`
+	Object     string            `json:"object"`
+	Created    int               `json:"created"`
+	OwnedBy    string            `json:"owned_by"`
+	Permission []ModelPermission `json:"permission"`
+	Root       string            `json:"root"`
+	Parent     interface{}       `json:"parent"`
+}
+
+// ModelPermission is a permission granted on a model. It is only returned for
+// legacy models.
+type ModelPermission struct {
+	ID                 string      `json:"id"`
+	Object             string      `json:"object"`
+	Created            int         `json:"created"`
+	AllowCreateEngine  bool        `json:"allow_create_engine"`
+	AllowSampling      bool        `json:"allow_sampling"`
+	AllowLogprobs      bool        `json:"allow_logprobs"`
+	AllowSearchIndices bool        `json:"allow_search_indices"`
+	AllowView          bool        `json:"allow_view"`
+	AllowFineTuning    bool        `json:"allow_fine_tuning"`
+	Organization       string      `json:"organization"`
+	Group              interface{} `json:"group"`
+	IsBlocking         bool        `json:"is_blocking"`
 }
 
 // https://platform.openai.com/docs/api-reference/models/list
-type Models struct {
-	Object string `json:"object"`
-	Data   []struct {
-		ID         string `json:"id"`
-		Object     string `json:"object"`
-		Created    int    `json:"created"`
-		OwnedBy    string `json:"owned_by"`
-		Permission []struct {
-			ID                 string      `json:"id"`
-			Object             string      `json:"object"`
-			Created            int         `json:"created"`
-			AllowCreateEngine  bool        `json:"allow_create_engine"`
-			AllowSampling      bool        `json:"allow_sampling"`
-			AllowLogprobs      bool        `json:"allow_logprobs"`
-			AllowSearchIndices bool        `json:"allow_search_indices"`
-			AllowView          bool        `json:"allow_view"`
-			AllowFineTuning    bool        `json:"allow_fine_tuning"`
-			Organization       string      `json:"organization"`
-			Group              interface{} `json:"group"`
-			IsBlocking         bool        `json:"is_blocking"`
-		} `json:"permission"`
-		Root   string      `json:"root"`
-		Parent interface{} `json:"parent"`
-	} `json:"data"`
-}
+type Models = Page[ModelInfo]
 
 // ListModels list model identifiers that can be used with the OpenAI API.
 //
+// If the client caches the model catalog, as configured with WithModelCache,
+// the cached list is returned while it is fresh.
+//
 // # Example
 //
 //	resp, _ := client.ListModels(ctx)
@@ -303,34 +655,64 @@ type Models struct {
 //
 // https://platform.openai.com/docs/api-reference/models/list
 func (c *Client) ListModels(ctx context.Context) (*Models, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/models", nil)
-	if err != nil {
-		return nil, err
+	if c.ModelCacheTTL > 0 {
+		return c.cachedModels(ctx, false)
 	}
 
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("Content-Type", "application/json")
+	return c.listModels(ctx)
+}
 
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
+func (c *Client) listModels(ctx context.Context) (*Models, error) {
+	var res Models
+	err := c.do(ctx, http.MethodGet, "/models", nil, &res)
+	if err != nil {
+		return nil, err
 	}
+	return &res, nil
+}
 
-	resp, err := c.HTTPClient.Do(r)
+// GetModel retrieves a model, with basic information about its owner.
+//
+// # Example
+//
+//	model, _ := client.GetModel(ctx, openai.ModelGPT4)
+//
+//	fmt.Println(model.OwnedBy)
+//
+// https://platform.openai.com/docs/api-reference/models/retrieve
+func (c *Client) GetModel(ctx context.Context, id string) (*ModelInfo, error) {
+	var res ModelInfo
+	err := c.do(ctx, http.MethodGet, "/models/"+id, nil, &res)
 	if err != nil {
 		return nil, err
 	}
+	return &res, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
-	}
+// https://platform.openai.com/docs/api-reference/models/delete
+type DeleteModelResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
 
-	cResp := &Models{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+// DeleteModel deletes a fine-tuned model. You must have the Owner role in
+// your organization to delete a model.
+//
+// # Example
+//
+//	resp, _ := client.DeleteModel(ctx, "ft:gpt-4o-mini:acemeco:suffix:abc123")
+//
+//	fmt.Println(resp.Deleted)
+//
+// https://platform.openai.com/docs/api-reference/models/delete
+func (c *Client) DeleteModel(ctx context.Context, modelID string) (*DeleteModelResponse, error) {
+	var res DeleteModelResponse
+	err := c.do(ctx, http.MethodDelete, "/models/"+modelID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	return cResp, nil
+	return &res, nil
 }
 
 // CreateEditRequest is the request for a "edit" request to the OpenAI API.
@@ -396,41 +778,12 @@ type CreateEditResponse struct {
 //
 // [deprecated]: https://openai.com/blog/gpt-4-api-general-availability
 func (c *Client) CreateEdit(ctx context.Context, req *CreateEditRequest) (*CreateEditResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/edits", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("Content-Type", "application/json")
-	r.Header.Set("Content-Length", fmt.Sprintf("%d", len(b)))
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	cResp := &CreateEditResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+	var res CreateEditResponse
+	err := c.do(ctx, http.MethodPost, "/edits", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	return cResp, nil
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/images/create
@@ -453,7 +806,7 @@ type CreateImageRequest struct {
 	// https://platform.openai.com/docs/api-reference/images/create#images/create-size
 	//
 	// Size of the image to generate. Must be one of 256x256, 512x512, or 1024x1024.
-	Size string `json:"size,omitempty"`
+	Size ImageSize `json:"size,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/images/create#images/create-response_format
 	//
@@ -466,26 +819,78 @@ type CreateImageRequest struct {
 	// https://platform.openai.com/docs/api-reference/images/create#images-create-quality
 	//
 	// Optional. Either "standard" or "hd", defaults to "standard".
-	Quality string `json:"quality,omitempty"`
+	Quality ImageQuality `json:"quality,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/images/create#images-create-style
 	//
 	// Optional. Either "vivid" or "natural", defaults to "vivid". Only valid for "dall-e-3" model.
 	Style string `json:"style,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/images/create#images-create-background
+	//
+	// Optional. Defaults to "auto". Only valid for the "gpt-image-1" model, and
+	// "transparent" requires the "png" or "webp" OutputFormat.
+	Background ImageBackground `json:"background,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/images/create#images-create-output_format
+	//
+	// Optional. Defaults to "png". Only valid for the "gpt-image-1" model.
+	OutputFormat ImageOutputFormat `json:"output_format,omitempty"`
+
+	// OutputCompression is the compression level, from 0 to 100, of "jpeg" and
+	// "webp" images.
+	//
+	// https://platform.openai.com/docs/api-reference/images/create#images-create-output_compression
+	//
+	// Optional. Defaults to 100. Only valid for the "gpt-image-1" model.
+	OutputCompression *int `json:"output_compression,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/images/create#images-create-moderation
+	//
+	// Optional. Either "low" or "auto", defaults to "auto". Only valid for the "gpt-image-1" model.
+	Moderation ImageModeration `json:"moderation,omitempty"`
 }
 
 // CreateImageResponse ...
 type CreateImageResponse struct {
-	Created int `json:"created"`
-	Data    []struct {
-		// One of the following: "url" or "b64_json"
-		URL     *string `json:"url"`
-		B64JSON *string `json:"b64_json"`
+	Created int         `json:"created"`
+	Data    []ImageData `json:"data"`
+
+	// The following are only returned for the "gpt-image-1" model.
+	Background   ImageBackground   `json:"background,omitempty"`
+	OutputFormat ImageOutputFormat `json:"output_format,omitempty"`
+	Quality      ImageQuality      `json:"quality,omitempty"`
+	Size         ImageSize         `json:"size,omitempty"`
+	Usage        *ImageUsage       `json:"usage,omitempty"`
+
+	// client is used to download images by URL.
+	client *Client
+}
+
+// ImageData is a generated image.
+//
+// https://platform.openai.com/docs/api-reference/images/object
+type ImageData struct {
+	// One of the following: "url" or "b64_json"
+	URL     *string `json:"url"`
+	B64JSON *string `json:"b64_json"`
+
+	// If there were any prompt revisions made by the API.
+	// Use this to refine further.
+	RevisedPrompt *string `json:"revised_prompt"`
+}
 
-		// If there were any prompt revisions made by the API.
-		// Use this to refine further.
-		RevisedPrompt *string `json:"revised_prompt"`
-	} `json:"data"`
+// ImageUsage is the token usage of an image generation request.
+//
+// https://platform.openai.com/docs/api-reference/images/object#images/object-usage
+type ImageUsage struct {
+	TotalTokens        int `json:"total_tokens"`
+	InputTokens        int `json:"input_tokens"`
+	OutputTokens       int `json:"output_tokens"`
+	InputTokensDetails struct {
+		TextTokens  int `json:"text_tokens"`
+		ImageTokens int `json:"image_tokens"`
+	} `json:"input_tokens_details"`
 }
 
 // CreateImage performs a "image" request using the OpenAI API.
@@ -501,42 +906,24 @@ type CreateImageResponse struct {
 //
 // https://platform.openai.com/docs/api-reference/images/create
 func (c *Client) CreateImage(ctx context.Context, req *CreateImageRequest) (*CreateImageResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
+	if req.Size != "" && !req.Size.IsValid() {
+		return nil, fmt.Errorf("invalid image size: %q", req.Size)
 	}
 
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/images/generations", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("Content-Type", "application/json")
-	r.Header.Set("Content-Length", fmt.Sprintf("%d", len(b)))
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
+	if req.Quality != "" && !req.Quality.IsValid() {
+		return nil, fmt.Errorf("invalid image quality: %q", req.Quality)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
+	if req.OutputCompression != nil && (*req.OutputCompression < 0 || *req.OutputCompression > 100) {
+		return nil, fmt.Errorf("invalid image output compression: %d", *req.OutputCompression)
 	}
 
-	cResp := &CreateImageResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+	res := CreateImageResponse{client: c}
+	err := c.do(ctx, http.MethodPost, "/images/generations", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	return cResp, nil
-
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/embeddings
@@ -553,113 +940,252 @@ type CreateEmbeddingRequest struct {
 
 	// https://platform.openai.com/docs/api-reference/embeddings/create#embeddings/create-user
 	User string `json:"user,omitempty"`
+
+	// Dimensions is the number of dimensions the embedding should have, which
+	// shortens the embedding. Only supported by text-embedding-3 and later models.
+	//
+	// https://platform.openai.com/docs/api-reference/embeddings/create#embeddings-create-dimensions
+	//
+	// Optional.
+	Dimensions int `json:"dimensions,omitempty"`
+
+	// EncodingFormat is the format the embedding is returned in. The "base64"
+	// format is much smaller on the wire than "float", and is decoded
+	// transparently into EmbeddingData.EmbeddingFloat32 rather than Embedding.
+	//
+	// https://platform.openai.com/docs/api-reference/embeddings/create#embeddings-create-encoding_format
+	//
+	// Optional. Defaults to "float".
+	EncodingFormat EmbeddingEncodingFormat `json:"encoding_format,omitempty"`
 }
 
+// EmbeddingEncodingFormat is the format an embedding is returned in.
+type EmbeddingEncodingFormat string
+
+const (
+	EmbeddingEncodingFloat  EmbeddingEncodingFormat = "float"
+	EmbeddingEncodingBase64 EmbeddingEncodingFormat = "base64"
+)
+
 // CreateEmbeddingResponse ...
 //
 // https://platform.openai.com/docs/guides/embeddings/what-are-embeddings
 type CreateEmbeddingResponse struct {
-	Object string `json:"object"`
-	Data   []struct {
-		Object    string    `json:"object"`
-		Embedding []float64 `json:"embedding"`
-		Index     int       `json:"index"`
-	} `json:"data"`
-	Model string `json:"model"`
-	Usage struct {
+	Object string          `json:"object"`
+	Data   []EmbeddingData `json:"data"`
+	Model  string          `json:"model"`
+	Usage  struct {
 		PromptTokens int `json:"prompt_tokens"`
 		TotalTokens  int `json:"total_tokens"`
 	} `json:"usage"`
 }
 
-// CreateEmbedding performs a "embedding" request using the OpenAI API.
-//
-// # Example
-//
-//	resp, _ := c.CreateEmbedding(ctx, &openai.CreateEmbeddingRequest{
-//		Model: openai.ModelTextEmbeddingAda002,
-//		Input: "The food was delicious and the waiter...",
-//	})
+// EmbeddingData is an embedding of one input.
 //
-// https://platform.openai.com/docs/api-reference/embeddings
-func (c *Client) CreateEmbedding(ctx context.Context, req *CreateEmbeddingRequest) (*CreateEmbeddingResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
+// https://platform.openai.com/docs/api-reference/embeddings/object
+type EmbeddingData struct {
+	Object    string    `json:"object"`
+	Embedding []float64 `json:"embedding"`
+	Index     int       `json:"index"`
+
+	// EmbeddingFloat32 is the embedding when it's returned in the "base64"
+	// format, decoded straight into float32s, which take half the memory of
+	// Embedding. Embedding is nil then.
+	EmbeddingFloat32 []float32 `json:"-"`
+}
+
+// UnmarshalJSON unmarshals the embedding, accepting it as either an array of
+// floats, into Embedding, or a base64 string of little-endian float32s, into
+// EmbeddingFloat32.
+func (e *EmbeddingData) UnmarshalJSON(b []byte) error {
+	type data EmbeddingData
+
+	tmp := struct {
+		*data
+		Embedding json.RawMessage `json:"embedding"`
+	}{
+		data: (*data)(e),
 	}
 
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/embeddings", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
 	}
 
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("Content-Type", "application/json")
-	r.Header.Set("Content-Length", fmt.Sprintf("%d", len(b)))
+	e.Embedding, e.EmbeddingFloat32 = nil, nil
 
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
+	switch {
+	case len(tmp.Embedding) == 0:
+		return nil
+	case tmp.Embedding[0] != '"':
+		return json.Unmarshal(tmp.Embedding, &e.Embedding)
+	}
+
+	var s string
+	if err := json.Unmarshal(tmp.Embedding, &s); err != nil {
+		return err
 	}
 
-	resp, err := c.HTTPClient.Do(r)
+	raw, err := base64.StdEncoding.DecodeString(s)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("invalid base64 embedding: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
+	if len(raw)%4 != 0 {
+		return fmt.Errorf("invalid base64 embedding: %d bytes is not a multiple of 4", len(raw))
+	}
+
+	e.EmbeddingFloat32 = make([]float32, len(raw)/4)
+	for i := range e.EmbeddingFloat32 {
+		e.EmbeddingFloat32[i] = math.Float32frombits(binary.LittleEndian.Uint32(raw[i*4:]))
+	}
+
+	return nil
+}
+
+// Float32 returns the embedding as float32s, which halves its size in memory
+// for storage in vector indexes. For an embedding returned in the "base64"
+// format, it returns EmbeddingFloat32 itself, rather than a copy.
+func (e *EmbeddingData) Float32() []float32 {
+	if e.EmbeddingFloat32 != nil {
+		return e.EmbeddingFloat32
+	}
+
+	f := make([]float32, len(e.Embedding))
+	for i, v := range e.Embedding {
+		f[i] = float32(v)
 	}
+	return f
+}
 
-	cResp := &CreateEmbeddingResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+// CreateEmbedding performs a "embedding" request using the OpenAI API.
+//
+// # Example
+//
+//	resp, _ := c.CreateEmbedding(ctx, &openai.CreateEmbeddingRequest{
+//		Model: openai.ModelTextEmbeddingAda002,
+//		Input: "The food was delicious and the waiter...",
+//	})
+//
+// https://platform.openai.com/docs/api-reference/embeddings
+func (c *Client) CreateEmbedding(ctx context.Context, req *CreateEmbeddingRequest) (*CreateEmbeddingResponse, error) {
+	var res CreateEmbeddingResponse
+	err := c.do(ctx, http.MethodPost, "/embeddings", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	return cResp, nil
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/moderations/create
 type CreateModerationRequest struct {
 	// https://platform.openai.com/docs/api-reference/moderations/create#moderations/create-model
 	//
-	// Optional. The model to use for moderation. Defaults to "text-moderation-latest".
-	Model string `json:"model"`
+	// Optional. The model to use for moderation. Defaults to "omni-moderation-latest".
+	Model string `json:"model,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/moderations/create#moderations/create-input
 	//
-	// Required. The text to moderate.
+	// Required, unless Inputs or InputParts is set. The text to moderate.
 	Input string `json:"input"`
+
+	// Inputs are several texts to moderate in one request, such as a batch
+	// from a content pipeline. Each input gets its own result, in the same
+	// order. It is sent as the input in place of Input when set.
+	//
+	// Optional.
+	Inputs []string `json:"-"`
+
+	// InputParts is text and images to moderate together, which is only
+	// supported by omni-moderation models. It is sent as the input in place
+	// of Input when set.
+	//
+	// Optional.
+	InputParts []ModerationInputPart `json:"-"`
 }
 
 // CreateModerationResponse ...
 //
 // https://platform.openai.com/docs/guides/moderations/what-are-moderations
 type CreateModerationResponse struct {
-	ID      string `json:"id"`
-	Model   string `json:"model"`
-	Results []struct {
-		Categories struct {
-			Hate            bool `json:"hate"`
-			HateThreatening bool `json:"hate/threatening"`
-			SelfHarm        bool `json:"self-harm"`
-			Sexual          bool `json:"sexual"`
-			SexualMinors    bool `json:"sexual/minors"`
-			Violence        bool `json:"violence"`
-			ViolenceGraphic bool `json:"violence/graphic"`
-		} `json:"categories"`
-		CategoryScores struct {
-			Hate            float64 `json:"hate"`
-			HateThreatening float64 `json:"hate/threatening"`
-			SelfHarm        float64 `json:"self-harm"`
-			Sexual          float64 `json:"sexual"`
-			SexualMinors    float64 `json:"sexual/minors"`
-			Violence        float64 `json:"violence"`
-			ViolenceGraphic float64 `json:"violence/graphic"`
-		} `json:"category_scores"`
-		Flagged bool `json:"flagged"`
-	} `json:"results"`
+	ID    string `json:"id"`
+	Model string `json:"model"`
+
+	// Results are the results of each input, in the order of the request's
+	// Inputs, or a single result for Input or InputParts.
+	Results []ModerationResult `json:"results"`
+}
+
+// Flagged reports whether any input was flagged.
+func (r *CreateModerationResponse) Flagged() bool {
+	for _, result := range r.Results {
+		if result.Flagged {
+			return true
+		}
+	}
+	return false
+}
+
+// FlaggedIndexes returns the indexes of the flagged inputs, in order.
+func (r *CreateModerationResponse) FlaggedIndexes() []int {
+	var indexes []int
+	for i, result := range r.Results {
+		if result.Flagged {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// ModerationResult is the moderation result of an input.
+//
+// https://platform.openai.com/docs/api-reference/moderations/object
+type ModerationResult struct {
+	Categories     ModerationCategories     `json:"categories"`
+	CategoryScores ModerationCategoryScores `json:"category_scores"`
+	Flagged        bool                     `json:"flagged"`
+
+	// CategoryAppliedInputTypes are the types of input, "text" or "image",
+	// that each category was applied to. Only returned by omni-moderation models.
+	CategoryAppliedInputTypes map[string][]string `json:"category_applied_input_types,omitempty"`
+}
+
+// ModerationCategories are the categories an input was flagged for.
+//
+// https://platform.openai.com/docs/api-reference/moderations/object#moderations/object-results-categories
+type ModerationCategories struct {
+	Harassment            bool `json:"harassment"`
+	HarassmentThreatening bool `json:"harassment/threatening"`
+	Hate                  bool `json:"hate"`
+	HateThreatening       bool `json:"hate/threatening"`
+	Illicit               bool `json:"illicit"`
+	IllicitViolent        bool `json:"illicit/violent"`
+	SelfHarm              bool `json:"self-harm"`
+	SelfHarmIntent        bool `json:"self-harm/intent"`
+	SelfHarmInstructions  bool `json:"self-harm/instructions"`
+	Sexual                bool `json:"sexual"`
+	SexualMinors          bool `json:"sexual/minors"`
+	Violence              bool `json:"violence"`
+	ViolenceGraphic       bool `json:"violence/graphic"`
+}
+
+// ModerationCategoryScores are the scores of an input for each category, from
+// 0 to 1.
+//
+// https://platform.openai.com/docs/api-reference/moderations/object#moderations/object-results-category_scores
+type ModerationCategoryScores struct {
+	Harassment            float64 `json:"harassment"`
+	HarassmentThreatening float64 `json:"harassment/threatening"`
+	Hate                  float64 `json:"hate"`
+	HateThreatening       float64 `json:"hate/threatening"`
+	Illicit               float64 `json:"illicit"`
+	IllicitViolent        float64 `json:"illicit/violent"`
+	SelfHarm              float64 `json:"self-harm"`
+	SelfHarmIntent        float64 `json:"self-harm/intent"`
+	SelfHarmInstructions  float64 `json:"self-harm/instructions"`
+	Sexual                float64 `json:"sexual"`
+	SexualMinors          float64 `json:"sexual/minors"`
+	Violence              float64 `json:"violence"`
+	ViolenceGraphic       float64 `json:"violence/graphic"`
 }
 
 // CreateModeration performs a "moderation" request using the OpenAI API.
@@ -672,41 +1198,12 @@ type CreateModerationResponse struct {
 //
 // https://platform.openai.com/docs/api-reference/moderations
 func (c *Client) CreateModeration(ctx context.Context, req *CreateModerationRequest) (*CreateModerationResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/moderations", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("Content-Type", "application/json")
-	r.Header.Set("Content-Length", fmt.Sprintf("%d", len(b)))
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	cResp := &CreateModerationResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+	var res CreateModerationResponse
+	err := c.do(ctx, http.MethodPost, "/moderations", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	return cResp, nil
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/files/list
@@ -715,22 +1212,38 @@ type ListFilesRequest struct {
 	//
 	// Optional. Filter to only list files with the specified purpose (assistants, fine-tune, etc).
 	Purpose string `json:"purpose,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/files/list#files-list-limit
+	//
+	// Optional. Between 1 and 10,000, defaults to 10,000.
+	Limit int `json:"limit,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/files/list#files-list-order
+	//
+	// Optional. Defaults to "desc".
+	Order SortOrder `json:"order,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/files/list#files-list-after
+	//
+	// Optional.
+	After string `json:"after,omitempty"`
 }
 
-// https://platform.openai.com/docs/api-reference/files/list
-type ListFilesResponse struct {
-	Data []struct {
-		ID        string `json:"id"`
-		Object    string `json:"object"`
-		Bytes     int    `json:"bytes"`
-		CreatedAt int    `json:"created_at"`
-		Filename  string `json:"filename"`
-		Purpose   string `json:"purpose"`
-	} `json:"data"`
-	Object string `json:"object"`
+// https://platform.openai.com/docs/api-reference/files/object
+type File struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	Bytes     int    `json:"bytes"`
+	CreatedAt int    `json:"created_at"`
+	Filename  string `json:"filename"`
+	Purpose   string `json:"purpose"`
 }
 
-// ListFiles performs a "list files" request using the OpenAI API.
+// https://platform.openai.com/docs/api-reference/files/list
+type ListFilesResponse = Page[File]
+
+// ListFiles performs a "list files" request using the OpenAI API. Use
+// HasMore and NextPage, or All, to enumerate files across pages.
 //
 // # Example
 //
@@ -738,34 +1251,31 @@ type ListFilesResponse struct {
 //
 // https://platform.openai.com/docs/api-reference/files
 func (c *Client) ListFiles(ctx context.Context, req *ListFilesRequest) (*ListFilesResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/files", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
+	if req.Order != "" && !req.Order.IsValid() {
+		return nil, fmt.Errorf("invalid order: %q", req.Order)
 	}
 
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
+	q := url.Values{}
+	if req.Purpose != "" {
+		q.Set("purpose", req.Purpose)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
+	query := listQuery(req.Limit, req.Order, req.After, "")
+	if len(q) > 0 {
+		if query == "" {
+			query = "?" + q.Encode()
+		} else {
+			query += "&" + q.Encode()
+		}
 	}
 
-	cResp := &ListFilesResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+	var res ListFilesResponse
+	err := c.do(ctx, http.MethodGet, "/files"+query, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	return cResp, nil
+	paginate(&res, req, func(r *ListFilesRequest, after string) { r.After = after }, c.ListFiles)
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/files/upload
@@ -784,7 +1294,8 @@ type UploadFileRequest struct {
 	// Use "fine-tune" for Fine-tuning. This allows us to validate t
 	// the format of the uploaded file.
 	//
-	// Required.
+	// Required. One of the FilePurpose values, such as
+	// string(FilePurposeFineTune). Unknown purposes are rejected by the API.
 	Purpose string `json:"purpose"`
 
 	// Body of the file to upload.
@@ -807,6 +1318,10 @@ type UploadFileResponse struct {
 
 // UploadFile performs a "upload file" request using the OpenAI API.
 //
+// The body is streamed to the API rather than buffered in memory, so the
+// request isn't retried. Its size is sent as the Content-Length when it is
+// known, such as for an *os.File or a *bytes.Reader.
+//
 // # Example
 //
 //	resp, _ := c.UploadFile(ctx, &openai.UploadFileRequest{
@@ -816,63 +1331,22 @@ type UploadFileResponse struct {
 //
 // https://platform.openai.com/docs/api-reference/files
 func (c *Client) UploadFile(ctx context.Context, req *UploadFileRequest) (*UploadFileResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/files", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	r.Header.Set("Content-Type", "multipart/form-data")
-
-	var b bytes.Buffer
-	w := multipart.NewWriter(&b)
-
-	fw, err := w.CreateFormFile("file", req.Name)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = io.Copy(fw, req.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	err = w.WriteField("purpose", req.Purpose)
-	if err != nil {
-		return nil, err
-	}
-
-	err = w.Close()
-	if err != nil {
-		return nil, err
-	}
+	var form multipartForm
+	form.addFile("file", req.Name, req.Body)
+	form.addField("purpose", req.Purpose)
 
-	r.Body = io.NopCloser(&b)
-	r.ContentLength = int64(b.Len())
-	r.Header.Set("Content-Type", w.FormDataContentType())
-
-	resp, err := c.HTTPClient.Do(r)
+	resp, err := c.doMultipart(ctx, "/files", &form)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	cResp := &UploadFileResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
-	if err != nil {
-		return nil, err
+	var res UploadFileResponse
+	if err := json.NewDecoder(c.limitBody(resp.Body)).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	return cResp, nil
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/files/delete
@@ -902,34 +1376,12 @@ type DeleteFileResponse struct {
 //
 // https://platform.openai.com/docs/api-reference/files/delete
 func (c *Client) DeleteFile(ctx context.Context, req *DeleteFileRequest) (*DeleteFileResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodDelete, "https://api.openai.com/v1/files/"+req.ID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Set("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	cResp := &DeleteFileResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+	var res DeleteFileResponse
+	err := c.do(ctx, http.MethodDelete, "/files/"+req.ID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	return cResp, nil
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/files/retrieve
@@ -962,34 +1414,12 @@ type GetFileInfoResponse struct {
 //
 // https://platform.openai.com/docs/api-reference/files/retrieve
 func (c *Client) GetFileInfo(ctx context.Context, req *GetFileInfoRequest) (*GetFileInfoResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/files/"+req.ID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	cResp := &GetFileInfoResponse{}
-	err = json.NewDecoder(resp.Body).Decode(cResp)
+	var res GetFileInfoResponse
+	err := c.do(ctx, http.MethodGet, "/files/"+req.ID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	return cResp, nil
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/files/retrieve-content
@@ -1020,27 +1450,11 @@ type GetFileContentResponse struct {
 //
 // https://platform.openai.com/docs/api-reference/files/retrieve-content
 func (c *Client) GetFileContent(ctx context.Context, req *GetFileContentRequest) (*GetFileContentResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/files/"+req.ID+"/contents", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	resp, err := c.doRequest(ctx, http.MethodGet, "/files/"+req.ID+"/contents", "", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
 	return &GetFileContentResponse{
 		Body: resp.Body,
 	}, nil
@@ -1152,92 +1566,53 @@ type CreateFineTuneResponse struct {
 
 // https://platform.openai.com/docs/api-reference/fine-tunes/create
 func (c *Client) CreateFineTune(ctx context.Context, req *CreateFineTuneRequest) (*CreateFineTuneResponse, error) {
-	b, err := json.Marshal(req)
+	var res CreateFineTuneResponse
+	err := c.do(ctx, http.MethodPost, "/fine-tunes", req, &res)
 	if err != nil {
 		return nil, err
 	}
+	return &res, nil
+}
 
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/fine-tunes", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res CreateFineTuneResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+// https://platform.openai.com/docs/api-reference/fine-tunes/list
+type ListFineTunesRequest struct {
+	// Optional. The number of fine-tunes to return.
+	Limit int `json:"limit,omitempty"`
 
-	return &res, nil
+	// Optional. The ID of the last fine-tune of the previous page.
+	After string `json:"after,omitempty"`
 }
 
-// https://platform.openai.com/docs/api-reference/fine-tunes/list
-type ListFineTunesRequest struct {
-	// No fields yet.
+// https://platform.openai.com/docs/api-reference/fine-tunes/object
+type FineTune struct {
+	ID              string         `json:"id"`
+	Object          string         `json:"object"`
+	Model           string         `json:"model"`
+	CreatedAt       int            `json:"created_at"`
+	FineTunedModel  any            `json:"fine_tuned_model"`
+	Hyperparams     map[string]any `json:"hyperparams"`
+	OrganizationID  string         `json:"organization_id"`
+	ResultFiles     []any          `json:"result_files"`
+	Status          string         `json:"status"`
+	ValidationFiles []any          `json:"validation_files"`
+	TrainingFiles   []any          `json:"training_files"`
+	UpdatedAt       int            `json:"updated_at"`
 }
 
 // https://platform.openai.com/docs/api-reference/fine-tunes/list
-type ListFineTunesResponse struct {
-	Object string `json:"object"`
-	Data   []struct {
-		ID              string         `json:"id"`
-		Object          string         `json:"object"`
-		Model           string         `json:"model"`
-		CreatedAt       int            `json:"created_at"`
-		FineTunedModel  any            `json:"fine_tuned_model"`
-		Hyperparams     map[string]any `json:"hyperparams"`
-		OrganizationID  string         `json:"organization_id"`
-		ResultFiles     []any          `json:"result_files"`
-		Status          string         `json:"status"`
-		ValidationFiles []any          `json:"validation_files"`
-		TrainingFiles   []any          `json:"training_files"`
-		UpdatedAt       int            `json:"updated_at"`
-	} `json:"data"`
-}
+type ListFineTunesResponse = Page[FineTune]
 
+// ListFineTunes performs a "list fine-tunes" request using the OpenAI API.
+// Use HasMore and NextPage, or All, to enumerate fine-tunes across pages.
+//
 // https://platform.openai.com/docs/api-reference/fine-tunes/list
 func (c *Client) ListFineTunes(ctx context.Context, req *ListFineTunesRequest) (*ListFineTunesResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/fine-tunes", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res ListFineTunesResponse
+	err := c.do(ctx, http.MethodGet, "/fine-tunes"+listQuery(req.Limit, "", req.After, ""), nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res ListFineTunesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
+	paginate(&res, req, func(r *ListFineTunesRequest, after string) { r.After = after }, c.ListFineTunes)
 	return &res, nil
 }
 
@@ -1289,32 +1664,11 @@ type GetFineTuneResponse struct {
 
 // https://platform.openai.com/docs/api-reference/fine-tunes/retrieve
 func (c *Client) GetFineTune(ctx context.Context, req *GetFineTuneRequest) (*GetFineTuneResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/fine-tunes/"+req.ID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res GetFineTuneResponse
+	err := c.do(ctx, http.MethodGet, "/fine-tunes/"+req.ID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res GetFineTuneResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -1349,32 +1703,11 @@ type CancelFineTuneResponse struct {
 
 // https://platform.openai.com/docs/api-reference/fine-tunes/cancel
 func (c *Client) CancelFineTune(ctx context.Context, req *CancelFineTuneRequest) (*CancelFineTuneResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/fine-tunes/"+req.ID+"/cancel", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res CancelFineTuneResponse
+	err := c.do(ctx, http.MethodPost, "/fine-tunes/"+req.ID+"/cancel", nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res CancelFineTuneResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -1391,58 +1724,105 @@ type ListFineTuneEventsRequest struct {
 	Stream bool `json:"stream"`
 }
 
+// https://platform.openai.com/docs/api-reference/fine-tunes/events
+type FineTuneEvent struct {
+	Object    string `json:"object"`
+	CreatedAt int    `json:"created_at"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+}
+
 // https://platform.openai.com/docs/api-reference/fine-tunes/events
 type ListFineTuneEventsResponse struct {
-	Object string `json:"object"`
-	Data   []struct {
-		Object    string `json:"object"`
-		CreatedAt int    `json:"created_at"`
-		Level     string `json:"level"`
-		Message   string `json:"message"`
-	} `json:"data"`
+	Object string          `json:"object"`
+	Data   []FineTuneEvent `json:"data"`
 
 	// https://platform.openai.com/docs/api-reference/fine-tunes/events#fine-tunes/events-stream
 	//
 	// Only present if stream=true. Up to the caller to close the stream, e.g.: defer res.Stream.Close()
 	Stream io.ReadCloser `json:"-"`
+
+	// maxEventSize is the client's MaxStreamEventSize.
+	maxEventSize int
 }
 
-// https://platform.openai.com/docs/api-reference/fine-tunes/events
-func (c *Client) ListFineTuneEvents(ctx context.Context, req *ListFineTuneEventsRequest) (*ListFineTuneEventsResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/fine-tunes/"+req.ID+"/events", nil)
-	if err != nil {
-		return nil, err
+// ReadStream reads the events of a streamed response, applying the callback
+// to each event.
+//
+// Events are sent via server-sent events (SSE).
+func (r *ListFineTuneEventsResponse) ReadStream(ctx context.Context, cb func(*FineTuneEvent) error) error {
+	if r.Stream == nil {
+		return fmt.Errorf("no stream")
 	}
 
-	if req.Stream {
-		q := r.URL.Query()
-		q.Set("stream", "true")
-		r.URL.RawQuery = q.Encode()
+	// Close the stream when we're done.
+	defer r.Stream.Close()
+
+	s := sse.NewReader(r.Stream)
+	s.MaxEventSize = r.maxEventSize
+
+	for ctx.Err() == nil {
+		e, err := s.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return &StreamError{Err: err}
+		}
+
+		data := bytes.TrimSpace(e.Data)
+
+		if bytes.Equal(data, []byte("[DONE]")) {
+			break
+		}
+
+		// Return error payloads sent mid-stream.
+		if err := streamError(data); err != nil {
+			return &StreamError{Err: err}
+		}
+
+		var event FineTuneEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return &StreamError{Err: fmt.Errorf("malformed event: %w", err)}
+		}
+
+		if err := cb(&event); err != nil {
+			return err
+		}
+	}
+
+	// Check for context errors.
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
+	return nil
+}
 
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
+// https://platform.openai.com/docs/api-reference/fine-tunes/events
+func (c *Client) ListFineTuneEvents(ctx context.Context, req *ListFineTuneEventsRequest) (*ListFineTuneEventsResponse, error) {
+	path := "/fine-tunes/" + req.ID + "/events"
+	if req.Stream {
+		path += "?stream=true"
 	}
 
-	resp, err := c.HTTPClient.Do(r)
+	resp, err := c.doRequest(ctx, http.MethodGet, path, "", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
 	var res ListFineTuneEventsResponse
 	if !req.Stream {
-		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		defer resp.Body.Close()
+		if err := json.NewDecoder(c.limitBody(resp.Body)).Decode(&res); err != nil {
 			return nil, fmt.Errorf("failed to decode response: %w", err)
 		}
 	} else {
 		res.Stream = resp.Body
+		res.maxEventSize = c.MaxStreamEventSize
 	}
 
 	return &res, nil
@@ -1464,33 +1844,14 @@ type DeleteFineTuneModelResponse struct {
 }
 
 // https://platform.openai.com/docs/api-reference/fine-tunes/delete-model
+//
+// Deprecated: DeleteFineTuneModel uses the retired fine-tunes API. Use [github.com/picatz/openai.Client.DeleteModel] instead.
 func (c *Client) DeleteFineTuneModel(ctx context.Context, req *DeleteFineTuneModelRequest) (*DeleteFineTuneModelResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodDelete, "https://api.openai.com/v1/fine-tunes/"+req.ID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res DeleteFineTuneModelResponse
+	err := c.do(ctx, http.MethodDelete, "/fine-tunes/"+req.ID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res DeleteFineTuneModelResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -1616,6 +1977,10 @@ type JSONSchema struct {
 	// AdditionalProperties is the additional properties of the schema.
 	AdditionalProperties *JSONSchema `json:"additionalProperties,omitempty"`
 
+	// DisallowAdditionalProperties sets additionalProperties to false, which is
+	// required for every object in a strict structured output schema.
+	DisallowAdditionalProperties bool `json:"-"`
+
 	// Ref is the ref of the schema.
 	Ref string `json:"$ref,omitempty"`
 
@@ -1659,6 +2024,24 @@ type JSONSchema struct {
 	ExclusiveMax bool `json:"exclusiveMaximum,omitempty"`
 }
 
+// MarshalJSON marshals the schema, setting additionalProperties to false if
+// DisallowAdditionalProperties is set.
+func (s *JSONSchema) MarshalJSON() ([]byte, error) {
+	type schema JSONSchema
+
+	if !s.DisallowAdditionalProperties {
+		return json.Marshal((*schema)(s))
+	}
+
+	return json.Marshal(struct {
+		*schema
+		AdditionalProperties bool `json:"additionalProperties"`
+	}{
+		schema:               (*schema)(s),
+		AdditionalProperties: false,
+	})
+}
+
 type ChatMessage struct {
 	// Role is the role of the message, e.g. "user" or "bot".
 	//
@@ -1674,6 +2057,14 @@ type ChatMessage struct {
 	// Optional.
 	Content string `json:"content"`
 
+	// ContentParts are the parts of a message with multiple parts, such as text
+	// and images for vision models. If set, it is sent instead of Content.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-messages
+	//
+	// Optional.
+	ContentParts []ChatContentPart `json:"-"`
+
 	// Name is the author of this message. It is required if role is function,
 	// and it should be the name of the function whose response is in the content.
 	//
@@ -1691,6 +2082,29 @@ type ChatMessage struct {
 	//
 	// Optional.
 	FunctionCall *FunctionCall `json:"function_call,omitempty"`
+
+	// ToolCallID is the ID of the tool call that this message is the result of.
+	// It is required if role is tool.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-messages
+	//
+	// Optional.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+
+	// ToolCalls are the tool calls generated by the model, if any.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/object#chat/object-choices
+	//
+	// Optional.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+
+	// Audio is the audio generated by the model, if the "audio" modality was
+	// requested. Its Transcript holds the text of the reply.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/object#chat/object-choices
+	//
+	// Optional.
+	Audio *ChatMessageAudio `json:"audio,omitempty"`
 }
 
 // FunctionCallControl is an option used to control the behavior of a function call
@@ -1826,9 +2240,52 @@ type CreateChatRequest struct {
 	//
 	// https://platform.openai.com/docs/api-reference/chat/create#chat/create-max_tokens
 	//
+	// Reasoning models don't support max_tokens, so it is sent as
+	// MaxCompletionTokens to them instead.
+	//
 	// Optional.
 	MaxTokens int `json:"max_tokens,omitempty"`
 
+	// The maximum number of tokens to generate in the chat completion, including
+	// the reasoning tokens of reasoning models.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-max_completion_tokens
+	//
+	// Optional.
+	MaxCompletionTokens int `json:"max_completion_tokens,omitempty"`
+
+	// Modalities are the types of output the model should generate, such as
+	// text and audio. Audio output also requires Audio to be set.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-modalities
+	//
+	// Optional. Defaults to text only.
+	Modalities []ChatModality `json:"modalities,omitempty"`
+
+	// Audio configures the audio output requested with the "audio" modality.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-audio
+	//
+	// Optional.
+	Audio *ChatAudioParams `json:"audio,omitempty"`
+
+	// ServiceTier is the processing tier to serve the request with, such as
+	// "flex" for cheaper, slower processing, or "priority" for lower latency.
+	// The tier actually used is returned in the response.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-service_tier
+	//
+	// Optional. Defaults to ServiceTierAuto.
+	ServiceTier ServiceTier `json:"service_tier,omitempty"`
+
+	// ReasoningEffort constrains how much reasoning models think before they
+	// answer. Lower effort is faster and uses fewer reasoning tokens.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-reasoning_effort
+	//
+	// Optional. Defaults to ReasoningEffortMedium.
+	ReasoningEffort ReasoningEffort `json:"reasoning_effort,omitempty"`
+
 	// Number between -2.0 and 2.0. Positive values penalize new tokens based on whether
 	// they appear in the text so far, increasing the model's likelihood to talk about new topics.
 	//
@@ -1886,43 +2343,225 @@ type CreateChatRequest struct {
 	//
 	// Optional.
 	FunctionCall FunctionCallControl `json:"function_call,omitempty"`
+
+	// Tools are the tools the model may call, replacing Functions for models
+	// that support parallel tool calling.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-tools
+	//
+	// Optional.
+	Tools []Tool `json:"tools,omitempty"`
+
+	// ToolChoice controls which, if any, tool is called by the model.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-tool_choice
+	//
+	// Optional.
+	ToolChoice ToolChoice `json:"tool_choice,omitempty"`
+
+	// ParallelToolCalls controls whether the model may call multiple tools in
+	// a single response. Defaults to true.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-parallel_tool_calls
+	//
+	// Optional.
+	ParallelToolCalls *bool `json:"parallel_tool_calls,omitempty"`
+
+	// ResponseFormat is the format the model must output, such as a JSON object,
+	// or JSON conforming to a schema with structured outputs.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-response_format
+	//
+	// Optional.
+	ResponseFormat *ChatResponseFormat `json:"response_format,omitempty"`
+
+	// StreamOptions configures a streamed response.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-stream_options
+	//
+	// Optional.
+	StreamOptions *ChatStreamOptions `json:"stream_options,omitempty"`
+
+	// Seed makes sampling deterministic on a best-effort basis, so repeated
+	// requests with the same seed and parameters should return the same result.
+	// Compare the SystemFingerprint of responses to detect backend changes that
+	// affect determinism.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-seed
+	//
+	// Optional.
+	Seed *int `json:"seed,omitempty"`
+
+	// Logprobs returns the log probabilities of each output token.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-logprobs
+	//
+	// Optional.
+	Logprobs bool `json:"logprobs,omitempty"`
+
+	// TopLogprobs is the number of most likely tokens, between 0 and 20, to
+	// return at each position along with their log probabilities. Logprobs
+	// must be true if it is set.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/create#chat-create-top_logprobs
+	//
+	// Optional.
+	TopLogprobs int `json:"top_logprobs,omitempty"`
 }
 
-// CreateChatResponse is recieved in response to a chat request.
+// Logprobs are the log probabilities of the tokens of a chat completion choice.
 //
-// https://platform.openai.com/docs/api-reference/chat/create
-type CreateChatResponse struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	Created int    `json:"created"`
-	Model   string `json:"model"`
-	Usage   struct {
-		PromptTokens     int `json:"prompt_tokens"`
-		CompletionTokens int `json:"completion_tokens"`
-		TotalTokens      int `json:"total_tokens"`
-	} `json:"usage"`
-	Choices []struct {
-		Message      ChatMessage `json:"message"`
-		FinishReason string      `json:"finish_reason"`
-		Index        int         `json:"index"`
-	} `json:"choices"`
+// https://platform.openai.com/docs/api-reference/chat/object#chat/object-choices
+type Logprobs struct {
+	// Content are the log probabilities of the message content tokens.
+	Content []TokenLogprob `json:"content"`
 
-	// https://platform.openai.com/docs/api-reference/chat/create#chat/create-stream
-	Stream io.ReadCloser `json:"-"`
+	// Refusal are the log probabilities of the message refusal tokens.
+	Refusal []TokenLogprob `json:"refusal,omitempty"`
 }
 
-// FirstChoice returns the first choice in the response, or an error if there are no choices.
-func (r *CreateChatResponse) FirstChoice() (*ChatMessage, error) {
-	if len(r.Choices) == 0 {
-		return nil, fmt.Errorf("no choices returned")
-	}
+// TokenLogprob is the log probability of an output token, and the most likely
+// alternatives at its position.
+type TokenLogprob struct {
+	// Token is the token.
+	Token string `json:"token"`
 
-	return &r.Choices[0].Message, nil
+	// Logprob is the log probability of the token, or -9999.0 if it is very
+	// unlikely.
+	Logprob float64 `json:"logprob"`
+
+	// Bytes is the UTF-8 byte representation of the token, which may be nil.
+	// Tokens may be part of a multi-byte character, so the bytes of adjacent
+	// tokens need to be combined to decode it.
+	Bytes []int `json:"bytes"`
+
+	// TopLogprobs are the most likely tokens at this position, as requested
+	// with TopLogprobs.
+	TopLogprobs []TopLogprob `json:"top_logprobs"`
 }
 
-// RandomChoice returns a random choice in the response, or an error if there are no choices.
-func (r *CreateChatResponse) RandomChoice() (*ChatMessage, error) {
-	if len(r.Choices) == 0 {
+// TopLogprob is a likely token at a position of the output.
+type TopLogprob struct {
+	Token   string  `json:"token"`
+	Logprob float64 `json:"logprob"`
+	Bytes   []int   `json:"bytes"`
+}
+
+// Prob returns the probability of the token, between 0 and 1.
+func (t TokenLogprob) Prob() float64 {
+	return math.Exp(t.Logprob)
+}
+
+// ChatStreamOptions configures a streamed chat response.
+//
+// https://platform.openai.com/docs/api-reference/chat/create#chat-create-stream_options
+type ChatStreamOptions struct {
+	// IncludeUsage sends a final chunk with the token usage of the whole
+	// completion.
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
+// ChatUsage is the token usage of a chat completion.
+//
+// https://platform.openai.com/docs/api-reference/chat/object#chat/object-usage
+type ChatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+
+	// PromptTokensDetails breaks down the prompt tokens, if sent.
+	PromptTokensDetails *PromptTokensDetails `json:"prompt_tokens_details,omitempty"`
+
+	// CompletionTokensDetails breaks down the completion tokens, if sent.
+	CompletionTokensDetails *CompletionTokensDetails `json:"completion_tokens_details,omitempty"`
+}
+
+// PromptTokensDetails breaks down the prompt tokens of a chat completion.
+//
+// https://platform.openai.com/docs/api-reference/chat/object#chat/object-usage
+type PromptTokensDetails struct {
+	// CachedTokens is the number of prompt tokens read from the prompt cache.
+	CachedTokens int `json:"cached_tokens"`
+
+	// AudioTokens is the number of audio input tokens.
+	AudioTokens int `json:"audio_tokens"`
+}
+
+// CompletionTokensDetails breaks down the completion tokens of a chat completion.
+//
+// https://platform.openai.com/docs/api-reference/chat/object#chat/object-usage
+type CompletionTokensDetails struct {
+	// ReasoningTokens is the number of tokens generated by a reasoning model to
+	// think, which are billed as completion tokens but not part of the reply.
+	ReasoningTokens int `json:"reasoning_tokens"`
+
+	// AudioTokens is the number of audio output tokens.
+	AudioTokens int `json:"audio_tokens"`
+
+	// AcceptedPredictionTokens is the number of tokens of a predicted output
+	// that appeared in the completion.
+	AcceptedPredictionTokens int `json:"accepted_prediction_tokens"`
+
+	// RejectedPredictionTokens is the number of tokens of a predicted output
+	// that didn't appear in the completion, which are still billed.
+	RejectedPredictionTokens int `json:"rejected_prediction_tokens"`
+}
+
+// ReasoningTokens returns the number of reasoning tokens of the completion, or
+// 0 if they weren't reported.
+func (u ChatUsage) ReasoningTokens() int {
+	if u.CompletionTokensDetails == nil {
+		return 0
+	}
+	return u.CompletionTokensDetails.ReasoningTokens
+}
+
+// CreateChatResponse is recieved in response to a chat request.
+//
+// https://platform.openai.com/docs/api-reference/chat/create
+type CreateChatResponse struct {
+	ID      string    `json:"id"`
+	Object  string    `json:"object"`
+	Created int       `json:"created"`
+	Model   string    `json:"model"`
+	Usage   ChatUsage `json:"usage"`
+
+	// SystemFingerprint identifies the backend configuration the model ran with.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/object#chat/object-system_fingerprint
+	SystemFingerprint string `json:"system_fingerprint,omitempty"`
+
+	// ServiceTier is the processing tier the request was served with.
+	//
+	// https://platform.openai.com/docs/api-reference/chat/object#chat/object-service_tier
+	ServiceTier ServiceTier `json:"service_tier,omitempty"`
+
+	Choices []struct {
+		Message      ChatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+		Index        int         `json:"index"`
+		Logprobs     *Logprobs   `json:"logprobs,omitempty"`
+	} `json:"choices"`
+
+	// https://platform.openai.com/docs/api-reference/chat/create#chat/create-stream
+	Stream io.ReadCloser `json:"-"`
+
+	// maxEventSize is the client's MaxStreamEventSize.
+	maxEventSize int
+}
+
+// FirstChoice returns the first choice in the response, or an error if there are no choices.
+func (r *CreateChatResponse) FirstChoice() (*ChatMessage, error) {
+	if len(r.Choices) == 0 {
+		return nil, fmt.Errorf("no choices returned")
+	}
+
+	return &r.Choices[0].Message, nil
+}
+
+// RandomChoice returns a random choice in the response, or an error if there are no choices.
+func (r *CreateChatResponse) RandomChoice() (*ChatMessage, error) {
+	if len(r.Choices) == 0 {
 		return nil, fmt.Errorf("no choices returned")
 	}
 
@@ -1930,19 +2569,28 @@ func (r *CreateChatResponse) RandomChoice() (*ChatMessage, error) {
 }
 
 type ChatMessageStreamChunk struct {
-	ID      string `json:"id"`
-	Object  string `json:"object"`
-	Created int    `json:"created"`
-	Model   string `json:"model"`
-	Choices []struct {
+	ID                string      `json:"id"`
+	Object            string      `json:"object"`
+	Created           int         `json:"created"`
+	Model             string      `json:"model"`
+	SystemFingerprint string      `json:"system_fingerprint,omitempty"`
+	ServiceTier       ServiceTier `json:"service_tier,omitempty"`
+	Choices           []struct {
 		// Delta is either for role or content.
 		Delta struct {
-			Role    *string `json:"role"`
-			Content *string `json:"content"`
+			Role         *string            `json:"role"`
+			Content      *string            `json:"content"`
+			FunctionCall *FunctionCallDelta `json:"function_call,omitempty"`
+			ToolCalls    []ToolCallDelta    `json:"tool_calls,omitempty"`
 		} `json:"delta"`
-		Index        int `json:"index"`
-		FinishReason any `json:"finish_reason"`
+		Index        int       `json:"index"`
+		FinishReason any       `json:"finish_reason"`
+		Logprobs     *Logprobs `json:"logprobs,omitempty"`
 	} `json:"choices"`
+
+	// Usage is the token usage of the whole completion, sent in a final chunk
+	// without choices when requested with StreamOptions.
+	Usage *ChatUsage `json:"usage,omitempty"`
 }
 
 // Content returns the content of the message, or an error if there are no choices.
@@ -1974,74 +2622,33 @@ func (c *ChatMessageStreamChunk) FirstChoice() (string, error) {
 
 // ReadStream reads the stream, applying the callback to each message.
 //
-// Messages are sent via sever-sent events (SSE).
+// Messages are sent via server-sent events (SSE). If the stream fails partway
+// through, due to an error event, a malformed chunk, or the context being
+// cancelled, a *StreamError is returned with the content received so far.
 func (r *CreateChatResponse) ReadStream(ctx context.Context, cb func(*ChatMessageStreamChunk) error) error {
 	if r.Stream == nil {
 		return fmt.Errorf("no stream")
 	}
 
-	// Close the stream when we're done.
-	defer r.Stream.Close()
-
-	s := bufio.NewScanner(r.Stream)
-
-	for s.Scan() && ctx.Err() == nil {
-		// Get the data from the line.
-		data := s.Bytes()
-
-		// Skip empty lines.
-		if len(data) == 0 {
-			continue
-		}
-
-		// Skip comments.
-		if data[0] == ':' {
-			continue
-		}
-
-		// Split the line into fields.
-		fields := bytes.SplitN(data, []byte{':'}, 2)
-
-		// Ensure there are two fields.
-		if len(fields) != 2 {
-			continue
-		}
+	stream := newChatStream(ctx, r.Stream, r.maxEventSize)
 
-		// Ensure the first field is "data".
-		if !bytes.Equal(fields[0], []byte("data")) {
-			continue
-		}
+	// Close the stream when we're done.
+	defer stream.Close()
 
-		// Check if data is [DONE].
-		if bytes.Equal(fields[1], []byte("[DONE]")) {
-			break
+	for {
+		chunk, err := stream.Next()
+		if err == io.EOF {
+			return nil
 		}
-
-		// Unmarshal the message.
-		var chunk ChatMessageStreamChunk
-
-		// Skip if we can't unmarshal.
-		if err := json.Unmarshal(fields[1], &chunk); err != nil {
-			continue
+		if err != nil {
+			return err
 		}
 
 		// Call the callback.
-		if err := cb(&chunk); err != nil {
+		if err := cb(chunk); err != nil {
 			return err
 		}
 	}
-
-	// Check for scanner errors.
-	if err := s.Err(); err != nil {
-		return err
-	}
-
-	// Check for context errors.
-	if ctx.Err() != nil {
-		return ctx.Err()
-	}
-
-	return nil
 }
 
 // CreateChat sends a chat request to the API to obtain a chat response,
@@ -2072,45 +2679,36 @@ func (r *CreateChatResponse) ReadStream(ctx context.Context, cb func(*ChatMessag
 //	// Update history, summarize, forget, etc. Then repeat.
 //	history = appened(history, resp.Choices[0].Message)
 //
+// Parameters that reasoning models, such as o1 and o3, don't support are
+// adapted or left out when sending requests to them; see IsReasoningModel.
+//
 // https://platform.openai.com/docs/api-reference/chat/create
 func (c *Client) CreateChat(ctx context.Context, req *CreateChatRequest) (*CreateChatResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
+	if c.AutoModeration {
+		if err := c.moderateChat(ctx, req); err != nil {
+			return nil, err
+		}
 	}
 
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/chat/completions", bytes.NewReader(b))
+	b, err := json.Marshal(req.forModel())
 	if err != nil {
 		return nil, err
 	}
 
-	r.Header.Add("Content-Type", "application/json")
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	resp, err := c.doRequest(ctx, http.MethodPost, "/chat/completions", "application/json", bytes.NewReader(b))
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
 	var res CreateChatResponse
 	if !req.Stream {
-		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		defer resp.Body.Close()
+		if err := json.NewDecoder(c.limitBody(resp.Body)).Decode(&res); err != nil {
 			return nil, fmt.Errorf("failed to decode response: %w", err)
 		}
-		defer resp.Body.Close()
 	} else {
 		res.Stream = resp.Body
+		res.maxEventSize = c.MaxStreamEventSize
 	}
 
 	return &res, nil
@@ -2182,12 +2780,40 @@ type CreateAudioTranscriptionRequest struct {
 	//
 	// Optional.
 	Language string
+
+	// TimestampGranularities are the levels of detail of the timestamps in the
+	// transcript, either "word", "segment", or both. Word timestamps add latency,
+	// whereas segment timestamps don't.
+	//
+	// Requires the "verbose_json" ResponseFormat.
+	//
+	// https://platform.openai.com/docs/api-reference/audio/createTranscription#audio-createtranscription-timestamp_granularities
+	//
+	// Optional. Defaults to "segment".
+	TimestampGranularities []string
 }
 
+// Timestamp granularities of a transcription.
+const (
+	TimestampGranularityWord    = "word"
+	TimestampGranularitySegment = "segment"
+)
+
+// Transcription response formats.
+//
+// https://platform.openai.com/docs/api-reference/audio/createTranscription#audio-createtranscription-response_format
+const (
+	AudioTranscriptionFormatJSON        = "json"
+	AudioTranscriptionFormatText        = "text"
+	AudioTranscriptionFormatSRT         = "srt"
+	AudioTranscriptionFormatVerboseJSON = "verbose_json"
+	AudioTranscriptionFormatVTT         = "vtt"
+)
+
 // responseFormat returns the intended response format of the transcription.
 func (req *CreateAudioTranscriptionRequest) responseFormat() string {
 	if req.ResponseFormat == "" {
-		return "json"
+		return AudioTranscriptionFormatJSON
 	}
 	return req.ResponseFormat
 }
@@ -2195,6 +2821,9 @@ func (req *CreateAudioTranscriptionRequest) responseFormat() string {
 // https://platform.openai.com/docs/api-reference/audio/create
 type CreateAudioTranscriptionResponse interface {
 	Text() string
+
+	// decode decodes the response body.
+	decode(r io.Reader) error
 }
 
 // https://platform.openai.com/docs/api-reference/audio/create
@@ -2207,108 +2836,158 @@ func (a *CreateAudioTranscriptionResponseJSON) Text() string {
 	return a.RawText
 }
 
-// CreateAudioTranscription transcribes audio into the input language.
-//
-// https://platform.openai.com/docs/api-reference/audio/create
-func (c *Client) CreateAudioTranscription(ctx context.Context, req *CreateAudioTranscriptionRequest) (CreateAudioTranscriptionResponse, error) {
-	b := new(bytes.Buffer)
-	w := multipart.NewWriter(b)
+func (a *CreateAudioTranscriptionResponseJSON) decode(r io.Reader) error {
+	return json.NewDecoder(r).Decode(a)
+}
 
-	// Write the file
-	fw, err := w.CreateFormFile("file", req.File.Name())
-	if err != nil {
-		return nil, err
-	}
+// CreateAudioTranscriptionResponseText is a transcription in the "text" format.
+type CreateAudioTranscriptionResponseText struct {
+	RawText string
+}
 
-	if _, err := io.Copy(fw, req.File); err != nil {
-		return nil, err
-	}
+// Text returns the transcript.
+func (a *CreateAudioTranscriptionResponseText) Text() string {
+	return a.RawText
+}
 
-	// Write the model
-	if err := w.WriteField("model", req.Model); err != nil {
-		return nil, err
-	}
+func (a *CreateAudioTranscriptionResponseText) decode(r io.Reader) error {
+	b, err := io.ReadAll(r)
+	a.RawText = strings.TrimSuffix(string(b), "\n")
+	return err
+}
 
-	// Write the prompt
-	if req.Prompt != "" {
-		if err := w.WriteField("prompt", req.Prompt); err != nil {
-			return nil, err
-		}
-	}
+// AudioTranscriptionResponseSRT is a transcription in the "srt" subtitle format.
+type AudioTranscriptionResponseSRT struct {
+	RawText string
+}
 
-	// Write the response_format
-	if req.ResponseFormat != "" {
-		if err := w.WriteField("response_format", req.ResponseFormat); err != nil {
-			return nil, err
-		}
-	}
+// Text returns the transcript as an SRT document.
+func (a *AudioTranscriptionResponseSRT) Text() string {
+	return a.RawText
+}
 
-	// Write the temperature
-	if req.Temperature != 0 {
-		if err := w.WriteField("temperature", strconv.FormatFloat(req.Temperature, 'f', -1, 64)); err != nil {
-			return nil, err
-		}
-	}
+func (a *AudioTranscriptionResponseSRT) decode(r io.Reader) error {
+	b, err := io.ReadAll(r)
+	a.RawText = string(b)
+	return err
+}
 
-	// Write the language
-	if req.Language != "" {
-		if err := w.WriteField("language", req.Language); err != nil {
-			return nil, err
-		}
-	}
+// AudioTranscriptionResponseVTT is a transcription in the "vtt" (WebVTT) subtitle format.
+type AudioTranscriptionResponseVTT struct {
+	RawText string
+}
 
-	// Close the writer
-	if err := w.Close(); err != nil {
-		return nil, err
-	}
+// Text returns the transcript as a WebVTT document.
+func (a *AudioTranscriptionResponseVTT) Text() string {
+	return a.RawText
+}
 
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/audio/transcriptions", b)
-	if err != nil {
-		return nil, err
-	}
+func (a *AudioTranscriptionResponseVTT) decode(r io.Reader) error {
+	b, err := io.ReadAll(r)
+	a.RawText = string(b)
+	return err
+}
 
-	r.Header.Set("Content-Type", w.FormDataContentType())
+// AudioTranscriptionResponseVerboseJSON is a transcription in the "verbose_json"
+// format, which includes the detected language, duration, and the timestamped
+// words or segments requested with TimestampGranularities.
+//
+// https://platform.openai.com/docs/api-reference/audio/verbose-json-object
+type AudioTranscriptionResponseVerboseJSON struct {
+	Task     string                      `json:"task"`
+	Language string                      `json:"language"`
+	Duration float64                     `json:"duration"`
+	RawText  string                      `json:"text"`
+	Words    []AudioTranscriptionWord    `json:"words,omitempty"`
+	Segments []AudioTranscriptionSegment `json:"segments,omitempty"`
+}
+
+// Text returns the transcript.
+func (a *AudioTranscriptionResponseVerboseJSON) Text() string {
+	return a.RawText
+}
 
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
+func (a *AudioTranscriptionResponseVerboseJSON) decode(r io.Reader) error {
+	return json.NewDecoder(r).Decode(a)
+}
 
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
+// AudioTranscriptionWord is a word of a verbose transcription, included with the
+// "word" timestamp granularity. Times are in seconds.
+//
+// https://platform.openai.com/docs/api-reference/audio/verbose-json-object#audio/verbose-json-object-words
+type AudioTranscriptionWord struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// AudioTranscriptionSegment is a segment of a verbose transcription. Times are
+// in seconds.
+//
+// https://platform.openai.com/docs/api-reference/audio/verbose-json-object#audio/verbose-json-object-segments
+type AudioTranscriptionSegment struct {
+	ID               int     `json:"id"`
+	Seek             int     `json:"seek"`
+	Start            float64 `json:"start"`
+	End              float64 `json:"end"`
+	Text             string  `json:"text"`
+	Tokens           []int   `json:"tokens"`
+	Temperature      float64 `json:"temperature"`
+	AvgLogprob       float64 `json:"avg_logprob"`
+	CompressionRatio float64 `json:"compression_ratio"`
+	NoSpeechProb     float64 `json:"no_speech_prob"`
+}
+
+// CreateAudioTranscription transcribes audio into the input language.
+//
+// The file is streamed to the API rather than buffered in memory, so the
+// request isn't retried.
+//
+// https://platform.openai.com/docs/api-reference/audio/create
+func (c *Client) CreateAudioTranscription(ctx context.Context, req *CreateAudioTranscriptionRequest) (CreateAudioTranscriptionResponse, error) {
+	var form multipartForm
+	form.addFile("file", req.File.Name(), req.File)
+	form.addField("model", req.Model)
+	form.addField("prompt", req.Prompt)
+	form.addField("response_format", req.ResponseFormat)
+
+	if req.Temperature != 0 {
+		form.addField("temperature", strconv.FormatFloat(req.Temperature, 'f', -1, 64))
 	}
 
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
+	form.addField("language", req.Language)
+
+	for _, g := range req.TimestampGranularities {
+		form.addField("timestamp_granularities[]", g)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
+	resp, err := c.doMultipart(ctx, "/audio/transcriptions", &form)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var res CreateAudioTranscriptionResponse
 
 	switch req.responseFormat() {
-	case "json":
+	case AudioTranscriptionFormatJSON:
 		res = &CreateAudioTranscriptionResponseJSON{}
-
-		err := json.NewDecoder(resp.Body).Decode(res)
-		if err != nil {
-			return nil, err
-		}
-	// TODO: support other response formats
-	// case "text":
-	// 	res = &CreateAudioTranscriptionResponseText{}
-	// case "srt":
-	// 	res = &AudioTranscriptionResponseSRT{}
-	// case "verbose_json":
-	// 	res = &AudioTranscriptionResponseVerboseJSON{}
-	// case "vtt":
-	// 	res = &AudioTranscriptionResponseVTT{}
+	case AudioTranscriptionFormatVerboseJSON:
+		res = &AudioTranscriptionResponseVerboseJSON{}
+	case AudioTranscriptionFormatText:
+		res = &CreateAudioTranscriptionResponseText{}
+	case AudioTranscriptionFormatSRT:
+		res = &AudioTranscriptionResponseSRT{}
+	case AudioTranscriptionFormatVTT:
+		res = &AudioTranscriptionResponseVTT{}
 	default:
 		return nil, fmt.Errorf("unknown response format: %s", req.ResponseFormat)
 	}
 
+	if err := res.decode(c.limitBody(resp.Body)); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
 	return res, nil
 }
 
@@ -2339,9 +3018,14 @@ type CreateAssistantRequest struct {
 	// Optional.
 	Tools []map[string]any `json:"tools,omitempty"`
 
-	// https://platform.openai.com/docs/api-reference/assistants/createAssistant#assistants-createassistant-file_ids
+	// https://platform.openai.com/docs/api-reference/assistants/createAssistant#assistants-createassistant-tool_resources
 	//
 	// Optional.
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
+
+	// Optional.
+	//
+	// Deprecated: FileIDs is only supported by assistants v1. Use ToolResources instead.
 	FileIDs []string `json:"file_ids,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/assistants/createAssistant#assistants-createassistant-metadata
@@ -2352,16 +3036,19 @@ type CreateAssistantRequest struct {
 
 // https://platform.openai.com/docs/api-reference/assistants/object
 type Assistant struct {
-	ID           string           `json:"id"`
-	Object       string           `json:"object"`
-	Created      int              `json:"created"`
-	Name         string           `json:"name"`
-	Description  string           `json:"description"`
-	Model        string           `json:"model"`
-	Instructions string           `json:"instructions"`
-	Tools        []map[string]any `json:"tools"`
-	FileIDs      []string         `json:"file_ids"`
-	Metadata     map[string]any   `json:"metadata"`
+	ID            string           `json:"id"`
+	Object        string           `json:"object"`
+	Created       int              `json:"created"`
+	Name          string           `json:"name"`
+	Description   string           `json:"description"`
+	Model         string           `json:"model"`
+	Instructions  string           `json:"instructions"`
+	Tools         []map[string]any `json:"tools"`
+	ToolResources *ToolResources   `json:"tool_resources,omitempty"`
+	Metadata      map[string]any   `json:"metadata"`
+
+	// Deprecated: FileIDs is only returned by assistants v1. Use ToolResources instead.
+	FileIDs []string `json:"file_ids,omitempty"`
 }
 
 // https://platform.openai.com/docs/api-reference/assistants/create
@@ -2369,42 +3056,11 @@ type CreateAssistantResponse = Assistant
 
 // https://platform.openai.com/docs/api-reference/assistants/create
 func (c *Client) CreateAssistant(ctx context.Context, req *CreateAssistantRequest) (*CreateAssistantResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/assistants", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	resp, err := c.HTTPClient.Do(r)
+	var res CreateAssistantResponse
+	err := c.do(ctx, http.MethodPost, "/assistants", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res CreateAssistantResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -2420,37 +3076,11 @@ type GetAssistantResponse = Assistant
 
 // https://platform.openai.com/docs/api-reference/assistants/get#assistants/get-id
 func (c *Client) GetAssistant(ctx context.Context, req *GetAssistantRequest) (*GetAssistantResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/assistants/"+req.ID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	resp, err := c.HTTPClient.Do(r)
+	var res GetAssistantResponse
+	err := c.do(ctx, http.MethodGet, "/assistants/"+req.ID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res GetAssistantResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -2486,54 +3116,28 @@ type UpdateAssistantRequest struct {
 	// Optional.
 	Tools []map[string]any `json:"tools,omitempty"`
 
-	// https://platform.openai.com/docs/api-reference/assistants/modifyAssistant#assistants-modifyassistant-file_ids
+	// https://platform.openai.com/docs/api-reference/assistants/modifyAssistant#assistants-modifyassistant-tool_resources
 	//
 	// Optional.
-	FileIDs []string `json:"file_ids,omitempty"`
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
 
-	// https://platform.openai.com/docs/api-reference/assistants/modifyAssistant#assistants-modifyassistant-metadata
+	// Optional.
+	//
+	// Deprecated: FileIDs is only supported by assistants v1. Use ToolResources instead.
+	FileIDs []string `json:"file_ids,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/assistants/modifyAssistant#assistants-modifyassistant-metadata
 	//
 	// Optional.
 	Metadata map[string]any `json:"metadata,omitempty"`
 }
 
 func (c *Client) UpdateAssistant(ctx context.Context, req *UpdateAssistantRequest) (*Assistant, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/assistants/"+req.ID, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	resp, err := c.HTTPClient.Do(r)
+	var res Assistant
+	err := c.do(ctx, http.MethodPost, "/assistants/"+req.ID, req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res Assistant
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -2546,31 +3150,7 @@ type DeleteAssistantRequest struct {
 }
 
 func (c *Client) DeleteAssistant(ctx context.Context, req *DeleteAssistantRequest) error {
-	r, err := http.NewRequestWithContext(ctx, http.MethodDelete, "https://api.openai.com/v1/assistants/"+req.ID, nil)
-	if err != nil {
-		return err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	return nil
+	return c.do(ctx, http.MethodDelete, "/assistants/"+req.ID, nil, nil)
 }
 
 // https://platform.openai.com/docs/api-reference/assistants/listAssistants#assistants-listassistants-request
@@ -2583,7 +3163,7 @@ type ListAssistantsRequest struct {
 	// https://platform.openai.com/docs/api-reference/assistants/listAssistants#assistants-listassistants-order
 	//
 	// Optional. Defaults to "desc".
-	Order string `json:"order,omitempty"`
+	Order SortOrder `json:"order,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/assistants/listAssistants#assistants-listassistants-after
 	//
@@ -2597,61 +3177,20 @@ type ListAssistantsRequest struct {
 }
 
 // https://platform.openai.com/docs/api-reference/assistants/listAssistants#assistants-listassistants-response
-type ListAssistantsResponse struct {
-	Data []Assistant `json:"data"`
-}
+type ListAssistantsResponse = Page[Assistant]
 
 // https://platform.openai.com/docs/api-reference/assistants/listAssistants
 func (c *Client) ListAssistants(ctx context.Context, req *ListAssistantsRequest) (*ListAssistantsResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/assistants", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	q := r.URL.Query()
-
-	if req.Limit != 0 {
-		q.Set("limit", strconv.Itoa(req.Limit))
-	}
-
-	if req.Order != "" {
-		q.Set("order", req.Order)
+	if req.Order != "" && !req.Order.IsValid() {
+		return nil, fmt.Errorf("invalid order: %q", req.Order)
 	}
 
-	if req.After != "" {
-		q.Set("after", req.After)
-	}
-
-	if req.Before != "" {
-		q.Set("before", req.Before)
-	}
-
-	r.URL.RawQuery = q.Encode()
-
-	resp, err := c.HTTPClient.Do(r)
+	var res ListAssistantsResponse
+	err := c.do(ctx, http.MethodGet, "/assistants"+listQuery(req.Limit, req.Order, req.After, req.Before), nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res ListAssistantsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
+	paginate(&res, req, func(r *ListAssistantsRequest, after string) { r.After = after }, c.ListAssistants)
 	return &res, nil
 }
 
@@ -2680,41 +3219,14 @@ type CreateAssistantFileRequest struct {
 type CreateAssistantFileResponse = AssistantFile
 
 // https://platform.openai.com/docs/api-reference/assistants/createAssistantFile
+//
+// Deprecated: CreateAssistantFile is only supported by assistants v1. Use ToolResources instead.
 func (c *Client) CreateAssistantFile(ctx context.Context, req *CreateAssistantFileRequest) (*CreateAssistantFileResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/assistants/"+req.AssistantID+"/files", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res CreateAssistantFileResponse
+	err := c.do(ctx, http.MethodPost, "/assistants/"+req.AssistantID+"/files", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res CreateAssistantFileResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -2734,36 +3246,13 @@ type GetAssistantFileRequest struct {
 // https://platform.openai.com/docs/api-reference/assistants/getAssistantFile#assistants-getassistantfile-response
 type GetAssistantFileResponse = AssistantFile
 
+// Deprecated: GetAssistantFile is only supported by assistants v1. Use ToolResources instead.
 func (c *Client) GetAssistantFile(ctx context.Context, req *GetAssistantFileRequest) (*GetAssistantFileResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/assistants/"+req.AssistantID+"/files/"+req.FileID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res GetAssistantFileResponse
+	err := c.do(ctx, http.MethodGet, "/assistants/"+req.AssistantID+"/files/"+req.FileID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res GetAssistantFileResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -2781,34 +3270,10 @@ type DeleteAssistantFileRequest struct {
 }
 
 // https://platform.openai.com/docs/api-reference/assistants/deleteAssistantFile
+//
+// Deprecated: DeleteAssistantFile is only supported by assistants v1. Use ToolResources instead.
 func (c *Client) DeleteAssistantFile(ctx context.Context, req *DeleteAssistantFileRequest) error {
-	r, err := http.NewRequestWithContext(ctx, http.MethodDelete, "https://api.openai.com/v1/assistants/"+req.AssistantID+"/files/"+req.FileID, nil)
-	if err != nil {
-		return err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	return nil
+	return c.do(ctx, http.MethodDelete, "/assistants/"+req.AssistantID+"/files/"+req.FileID, nil, nil)
 }
 
 // https://platform.openai.com/docs/api-reference/assistants/listAssistantFiles
@@ -2826,7 +3291,7 @@ type ListAssistantFilesRequest struct {
 	// https://platform.openai.com/docs/api-reference/assistants/listAssistantFiles#assistants-listassistantfiles-order
 	//
 	// Optional. Defaults to "desc".
-	Order string `json:"order,omitempty"`
+	Order SortOrder `json:"order,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/assistants/listAssistantFiles#assistants-listassistantfiles-after
 	//
@@ -2840,70 +3305,32 @@ type ListAssistantFilesRequest struct {
 }
 
 // https://platform.openai.com/docs/api-reference/assistants/listAssistantFiles#assistants-listassistantfiles-response
-type ListAssistantFilesResponse struct {
-	Data []AssistantFile `json:"data"`
-}
+type ListAssistantFilesResponse = Page[AssistantFile]
 
 // https://platform.openai.com/docs/api-reference/assistants/listAssistantFiles
+//
+// Deprecated: ListAssistantFiles is only supported by assistants v1. Use ToolResources instead.
 func (c *Client) ListAssistantFiles(ctx context.Context, req *ListAssistantFilesRequest) (*ListAssistantFilesResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/assistants/"+req.AssistantID+"/files", nil)
-	if err != nil {
-		return nil, err
+	if req.Order != "" && !req.Order.IsValid() {
+		return nil, fmt.Errorf("invalid order: %q", req.Order)
 	}
 
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	q := r.URL.Query()
-
-	if req.Limit != 0 {
-		q.Set("limit", strconv.Itoa(req.Limit))
-	}
-
-	if req.Order != "" {
-		q.Set("order", req.Order)
-	}
-
-	if req.After != "" {
-		q.Set("after", req.After)
-	}
-
-	if req.Before != "" {
-		q.Set("before", req.Before)
-	}
-
-	r.URL.RawQuery = q.Encode()
-
-	resp, err := c.HTTPClient.Do(r)
+	var res ListAssistantFilesResponse
+	err := c.do(ctx, http.MethodGet, "/assistants/"+req.AssistantID+"/files"+listQuery(req.Limit, req.Order, req.After, req.Before), nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res ListAssistantFilesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
+	paginate(&res, req, func(r *ListAssistantFilesRequest, after string) { r.After = after }, c.ListAssistantFiles)
 	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/threads/object
 type Thread struct {
-	ID       string         `json:"id"`
-	Object   string         `json:"object"`
-	Created  int            `json:"created"`
-	Metadata map[string]any `json:"metadata"`
+	ID            string         `json:"id"`
+	Object        string         `json:"object"`
+	Created       int            `json:"created"`
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
+	Metadata      map[string]any `json:"metadata"`
 }
 
 // https://platform.openai.com/docs/api-reference/threads/createThread
@@ -2913,6 +3340,11 @@ type CreateThreadRequest struct {
 	// Optional.
 	Messages []*ChatMessage `json:"messages,omitempty"`
 
+	// https://platform.openai.com/docs/api-reference/threads/createThread#threads-createthread-tool_resources
+	//
+	// Optional.
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
+
 	// https://platform.openai.com/docs/api-reference/threads/createThread#threads-createthread-metadata
 	//
 	// Optional.
@@ -2924,41 +3356,11 @@ type CreateThreadResponse = Thread
 
 // https://platform.openai.com/docs/api-reference/threads/createThread
 func (c *Client) CreateThread(ctx context.Context, req *CreateThreadRequest) (*CreateThreadResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/threads", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res CreateThreadResponse
+	err := c.do(ctx, http.MethodPost, "/threads", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res CreateThreadResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -2974,36 +3376,11 @@ type GetThreadRequest struct {
 type GetThreadResponse = Thread
 
 func (c *Client) GetThread(ctx context.Context, req *GetThreadRequest) (*GetThreadResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/threads/"+req.ID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res GetThreadResponse
+	err := c.do(ctx, http.MethodGet, "/threads/"+req.ID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res GetThreadResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -3014,6 +3391,11 @@ type UpdateThreadRequest struct {
 	// Required.
 	ID string `json:"thread_id"`
 
+	// https://platform.openai.com/docs/api-reference/threads/modifyThread#threads-modifythread-tool_resources
+	//
+	// Optional.
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
+
 	// https://platform.openai.com/docs/api-reference/threads/modifyThread#threads-modifythread-metadata
 	//
 	// Optional.
@@ -3023,41 +3405,11 @@ type UpdateThreadRequest struct {
 type UpdateThreadResponse = Thread
 
 func (c *Client) UpdateThread(ctx context.Context, req *UpdateThreadRequest) (*UpdateThreadResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPatch, "https://api.openai.com/v1/threads/"+req.ID, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res UpdateThreadResponse
+	err := c.do(ctx, http.MethodPatch, "/threads/"+req.ID, req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res UpdateThreadResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -3071,31 +3423,7 @@ type DeleteThreadRequest struct {
 
 // https://platform.openai.com/docs/api-reference/threads/deleteThread
 func (c *Client) DeleteThread(ctx context.Context, req *DeleteThreadRequest) error {
-	r, err := http.NewRequestWithContext(ctx, http.MethodDelete, "https://api.openai.com/v1/threads/"+req.ID, nil)
-	if err != nil {
-		return err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	return nil
+	return c.do(ctx, http.MethodDelete, "/threads/"+req.ID, nil, nil)
 }
 
 // https://platform.openai.com/docs/api-reference/messages/object
@@ -3122,8 +3450,11 @@ type ThreadMessage struct {
 	Content     []ThreadMessageContent `json:"content"`
 	AssistantID string                 `json:"assistant_id,omitempty"`
 	RunID       string                 `json:"run_id,omitempty"`
-	FileIDs     []string               `json:"file_ids,omitempty"`
+	Attachments []MessageAttachment    `json:"attachments,omitempty"`
 	Metadata    map[string]any         `json:"metadata,omitempty"`
+
+	// Deprecated: FileIDs is only returned by assistants v1. Use Attachments instead.
+	FileIDs []string `json:"file_ids,omitempty"`
 }
 
 // https://platform.openai.com/docs/api-reference/messages/createMessage
@@ -3143,9 +3474,14 @@ type CreateMessageRequest struct {
 	// Required.
 	Content string `json:"content"`
 
-	// https://platform.openai.com/docs/api-reference/messages/createMessage#messages-createmessage-file_ids
+	// https://platform.openai.com/docs/api-reference/messages/createMessage#messages-createmessage-attachments
 	//
 	// Optional.
+	Attachments []MessageAttachment `json:"attachments,omitempty"`
+
+	// Optional.
+	//
+	// Deprecated: FileIDs is only supported by assistants v1. Use Attachments instead.
 	FileIDs []string `json:"file_ids,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/messages/createMessage#messages-createmessage-metadata
@@ -3159,41 +3495,11 @@ type CreateMessageResponse = ThreadMessage
 
 // https://platform.openai.com/docs/api-reference/messages/createMessage
 func (c *Client) CreateMessage(ctx context.Context, req *CreateMessageRequest) (*CreateMessageResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/threads/"+req.ThreadID+"/messages", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res CreateMessageResponse
+	err := c.do(ctx, http.MethodPost, "/threads/"+req.ThreadID+"/messages", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res CreateMessageResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -3214,36 +3520,11 @@ type GetMessageRequest struct {
 type GetMessageResponse = ThreadMessage
 
 func (c *Client) GetMessage(ctx context.Context, req *GetMessageRequest) (*GetMessageResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/messages/"+req.MessageID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res GetMessageResponse
+	err := c.do(ctx, http.MethodGet, "/messages/"+req.MessageID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res GetMessageResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -3269,42 +3550,32 @@ type UpdateMessageRequest struct {
 type UpdateMessageResponse = ThreadMessage
 
 func (c *Client) UpdateMessage(ctx context.Context, req *UpdateMessageRequest) (*UpdateMessageResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPatch, "https://api.openai.com/v1/messages/"+req.MessageID, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res UpdateMessageResponse
+	err := c.do(ctx, http.MethodPatch, "/messages/"+req.MessageID, req, &res)
 	if err != nil {
 		return nil, err
 	}
+	return &res, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
+// https://platform.openai.com/docs/api-reference/messages/deleteMessage
+type DeleteMessageRequest struct {
+	// https://platform.openai.com/docs/api-reference/messages/deleteMessage#messages-deletemessage-thread_id
+	//
+	// Required.
+	ThreadID string `json:"-"`
 
-	var res UpdateMessageResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	// https://platform.openai.com/docs/api-reference/messages/deleteMessage#messages-deletemessage-message_id
+	//
+	// Required.
+	MessageID string `json:"-"`
+}
 
-	return &res, nil
+// DeleteMessage deletes a message from a thread.
+//
+// https://platform.openai.com/docs/api-reference/messages/deleteMessage
+func (c *Client) DeleteMessage(ctx context.Context, req *DeleteMessageRequest) error {
+	return c.do(ctx, http.MethodDelete, "/threads/"+req.ThreadID+"/messages/"+req.MessageID, nil, nil)
 }
 
 // https://platform.openai.com/docs/api-reference/messages/listMessages
@@ -3322,7 +3593,7 @@ type ListMessagesRequest struct {
 	// https://platform.openai.com/docs/api-reference/messages/listMessages#messages-listmessages-order
 	//
 	// Optional. Defaults to "desc".
-	Order string `json:"order,omitempty"`
+	Order SortOrder `json:"order,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/messages/listMessages#messages-listmessages-after
 	//
@@ -3336,61 +3607,19 @@ type ListMessagesRequest struct {
 }
 
 // https://platform.openai.com/docs/api-reference/messages/listMessages#messages-listmessages-response
-type ListMessagesResponse struct {
-	Data []ThreadMessage `json:"data"`
-}
+type ListMessagesResponse = Page[ThreadMessage]
 
 func (c *Client) ListMessages(ctx context.Context, req *ListMessagesRequest) (*ListMessagesResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/threads/"+req.ThreadID+"/messages", nil)
-	if err != nil {
-		return nil, err
+	if req.Order != "" && !req.Order.IsValid() {
+		return nil, fmt.Errorf("invalid order: %q", req.Order)
 	}
 
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	q := r.URL.Query()
-
-	if req.Limit != 0 {
-		q.Set("limit", strconv.Itoa(req.Limit))
-	}
-
-	if req.Order != "" {
-		q.Set("order", req.Order)
-	}
-
-	if req.After != "" {
-		q.Set("after", req.After)
-	}
-
-	if req.Before != "" {
-		q.Set("before", req.Before)
-	}
-
-	r.URL.RawQuery = q.Encode()
-
-	resp, err := c.HTTPClient.Do(r)
+	var res ListMessagesResponse
+	err := c.do(ctx, http.MethodGet, "/threads/"+req.ThreadID+"/messages"+listQuery(req.Limit, req.Order, req.After, req.Before), nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res ListMessagesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
+	paginate(&res, req, func(r *ListMessagesRequest, after string) { r.After = after }, c.ListMessages)
 	return &res, nil
 }
 
@@ -3423,37 +3652,13 @@ type GetMessageFileRequest struct {
 // https://platform.openai.com/docs/api-reference/messages/getMessageFile#messages-getmessagefile-response
 type GetMessageFileResponse = MessageFile
 
+// Deprecated: GetMessageFile is only supported by assistants v1. Use MessageAttachment instead.
 func (c *Client) GetMessageFile(ctx context.Context, req *GetMessageFileRequest) (*GetMessageFileResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/messages/"+req.MessageID+"/files/"+req.FileID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res GetMessageFileResponse
+	err := c.do(ctx, http.MethodGet, "/messages/"+req.MessageID+"/files/"+req.FileID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res GetMessageFileResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -3477,7 +3682,7 @@ type ListMessageFilesRequest struct {
 	// https://platform.openai.com/docs/api-reference/messages/listMessageFiles#messages-listmessagefiles-order
 	//
 	// Optional. Defaults to "desc".
-	Order string `json:"order,omitempty"`
+	Order SortOrder `json:"order,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/messages/listMessageFiles#messages-listmessagefiles-after
 	//
@@ -3491,84 +3696,97 @@ type ListMessageFilesRequest struct {
 }
 
 // https://platform.openai.com/docs/api-reference/messages/listMessageFiles#messages-listmessagefiles-response
-type ListMessageFilesResponse struct {
-	Data []MessageFile `json:"data"`
-}
+type ListMessageFilesResponse = Page[MessageFile]
 
+// Deprecated: ListMessageFiles is only supported by assistants v1. Use MessageAttachment instead.
 func (c *Client) ListMessageFiles(ctx context.Context, req *ListMessageFilesRequest) (*ListMessageFilesResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/messages/"+req.MessageID+"/files", nil)
-	if err != nil {
-		return nil, err
+	if req.Order != "" && !req.Order.IsValid() {
+		return nil, fmt.Errorf("invalid order: %q", req.Order)
 	}
 
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	q := r.URL.Query()
-
-	if req.Limit != 0 {
-		q.Set("limit", strconv.Itoa(req.Limit))
-	}
-
-	if req.Order != "" {
-		q.Set("order", req.Order)
+	var res ListMessageFilesResponse
+	err := c.do(ctx, http.MethodGet, "/messages/"+req.MessageID+"/files"+listQuery(req.Limit, req.Order, req.After, req.Before), nil, &res)
+	if err != nil {
+		return nil, err
 	}
+	paginate(&res, req, func(r *ListMessageFilesRequest, after string) { r.After = after }, c.ListMessageFiles)
+	return &res, nil
+}
 
-	if req.After != "" {
-		q.Set("after", req.After)
-	}
+// https://platform.openai.com/docs/api-reference/runs/object
+type Run struct {
+	ID             string             `json:"id"`
+	Object         string             `json:"object"`
+	CreatedAt      int                `json:"created_at"`
+	ThreadID       string             `json:"thread_id"`
+	AssistantID    string             `json:"assistant_id"`
+	Status         RunStatus          `json:"status"`
+	RequiredAction *RunRequiredAction `json:"required_action,omitempty"`
+	LastError      map[string]any     `json:"last_error,omitempty"`
+	ExpiresAt      int                `json:"expires_at"`
+	StartedAt      int                `json:"started_at,omitempty"`
+	CancelledAt    int                `json:"cancelled_at,omitempty"`
+	FailedAt       int                `json:"failed_at,omitempty"`
+	CompletedAt    int                `json:"completed_at,omitempty"`
+	Model          string             `json:"model"`
+	Instructions   string             `json:"instructions"`
+	Tools          []map[string]any   `json:"tools"`
+	Metadata       map[string]any     `json:"metadata"`
+
+	// IncompleteDetails is why the run is incomplete, such as the token
+	// limit it reached.
+	//
+	// https://platform.openai.com/docs/api-reference/runs/object#runs/object-incomplete_details
+	IncompleteDetails map[string]any `json:"incomplete_details,omitempty"`
+
+	// Usage is the token usage of the whole run, once it's completed.
+	//
+	// https://platform.openai.com/docs/api-reference/runs/object#runs/object-usage
+	Usage *ChatUsage `json:"usage,omitempty"`
+
+	// Deprecated: FileIDs is only returned by assistants v1.
+	FileIDs []string `json:"file_ids,omitempty"`
+}
 
-	if req.Before != "" {
-		q.Set("before", req.Before)
-	}
+// RunRequiredActionSubmitToolOutputs is the type of the action required by a
+// run waiting for the outputs of its tool calls.
+const RunRequiredActionSubmitToolOutputs = "submit_tool_outputs"
 
-	r.URL.RawQuery = q.Encode()
+// RunRequiredAction is the action required to continue a run with the
+// "requires_action" status.
+//
+// https://platform.openai.com/docs/api-reference/runs/object#runs/object-required_action
+type RunRequiredAction struct {
+	// Type is the type of the action, currently only "submit_tool_outputs".
+	Type string `json:"type"`
 
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
-	}
+	// SubmitToolOutputs are the tool calls whose outputs must be submitted
+	// with SubmitToolOutputs.
+	SubmitToolOutputs *RunSubmitToolOutputs `json:"submit_tool_outputs,omitempty"`
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
+// RunSubmitToolOutputs are the tool calls a run is waiting on.
+type RunSubmitToolOutputs struct {
+	ToolCalls []ToolCall `json:"tool_calls"`
+}
 
-	var res ListMessageFilesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// ToolCalls returns the tool calls whose outputs the run is waiting on, or nil
+// if it isn't waiting on any.
+//
+// # Example
+//
+//	var outputs []*openai.AssistantToolOutput
+//
+//	for _, call := range run.ToolCalls() {
+//		out := dispatch(call.Function.Name, call.Function.Arguments)
+//		outputs = append(outputs, &openai.AssistantToolOutput{CallID: call.ID, Output: out})
+//	}
+func (r *Run) ToolCalls() []ToolCall {
+	if r.RequiredAction == nil || r.RequiredAction.SubmitToolOutputs == nil {
+		return nil
 	}
 
-	return &res, nil
-}
-
-// https://platform.openai.com/docs/api-reference/runs/object
-type Run struct {
-	ID             string           `json:"id"`
-	Object         string           `json:"object"`
-	CreatedAt      int              `json:"created_at"`
-	ThreadID       string           `json:"thread_id"`
-	AssistantID    string           `json:"assistant_id"`
-	Status         string           `json:"status"`
-	RequiredAction string           `json:"required_action,omitempty"`
-	LastError      map[string]any   `json:"last_error,omitempty"`
-	ExpiresAt      int              `json:"expires_at"`
-	StartedAt      int              `json:"started_at,omitempty"`
-	CancelledAt    int              `json:"cancelled_at,omitempty"`
-	FailedAt       int              `json:"failed_at,omitempty"`
-	CompletedAt    int              `json:"completed_at,omitempty"`
-	Model          string           `json:"model"`
-	Instructions   string           `json:"instructions"`
-	Tools          []map[string]any `json:"tools"`
-	FileIDs        []string         `json:"file_ids"`
-	Metadata       map[string]any   `json:"metadata"`
+	return r.RequiredAction.SubmitToolOutputs.ToolCalls
 }
 
 // https://platform.openai.com/docs/api-reference/runs/createRun
@@ -3609,57 +3827,14 @@ type CreateRunResponse = Run
 
 // https://platform.openai.com/docs/api-reference/runs/createRun
 func (c *Client) CreateRun(ctx context.Context, req *CreateRunRequest) (*CreateRunResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/threads/"+req.ThreadID+"/runs", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res CreateRunResponse
+	err := c.do(ctx, http.MethodPost, "/threads/"+req.ThreadID+"/runs", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res CreateRunResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
 	return &res, nil
 }
 
-// https://platform.openai.com/docs/api-reference/runs/object#runs/object-status
-type RunStatus = string
-
-const (
-	RunStatusQueued         RunStatus = "queued"
-	RunStatusInProgress     RunStatus = "in_progress"
-	RunStatusRequiresAction RunStatus = "requires_action"
-	RunStatusCancelling     RunStatus = "cancelling"
-	RunStatusCancelled      RunStatus = "cancelled"
-	RunStatusFailed         RunStatus = "failed"
-	RunStatusCompleted      RunStatus = "completed"
-	RunStatusExpired        RunStatus = "expired"
-)
-
 // https://platform.openai.com/docs/api-reference/runs/getRun
 type GetRunRequest struct {
 	// https://platform.openai.com/docs/api-reference/runs/getRun#runs-getrun-thread_id
@@ -3678,35 +3853,11 @@ type GetRunResponse = Run
 
 // https://platform.openai.com/docs/api-reference/runs/getRun
 func (c *Client) GetRun(ctx context.Context, req *GetRunRequest) (*GetRunResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/threads/"+req.ThreadID+"/runs/"+req.RunID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res GetRunResponse
+	err := c.do(ctx, http.MethodGet, "/threads/"+req.ThreadID+"/runs/"+req.RunID, nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res GetRunResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
 	return &res, nil
 }
 
@@ -3733,40 +3884,11 @@ type UpdateRunResponse = Run
 
 // https://platform.openai.com/docs/api-reference/runs/modifyRun
 func (c *Client) UpdateRun(ctx context.Context, req *UpdateRunRequest) (*UpdateRunResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/threads/"+req.ThreadID+"/runs/"+req.RunID, bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res UpdateRunResponse
+	err := c.do(ctx, http.MethodPost, "/threads/"+req.ThreadID+"/runs/"+req.RunID, req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res UpdateRunResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
 	return &res, nil
 }
 
@@ -3785,7 +3907,7 @@ type ListRunsRequest struct {
 	// https://platform.openai.com/docs/api-reference/runs/listRuns#runs-listruns-order
 	//
 	// Optional. Defaults to "desc".
-	Order string `json:"order,omitempty"`
+	Order SortOrder `json:"order,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/runs/listRuns#runs-listruns-after
 	//
@@ -3799,8 +3921,21 @@ type ListRunsRequest struct {
 }
 
 // https://platform.openai.com/docs/api-reference/runs/listRuns#runs-listruns-response
-type ListRunsResponse struct {
-	Data []Run `json:"data"`
+type ListRunsResponse = Page[Run]
+
+// https://platform.openai.com/docs/api-reference/runs/listRuns
+func (c *Client) ListRuns(ctx context.Context, req *ListRunsRequest) (*ListRunsResponse, error) {
+	if req.Order != "" && !req.Order.IsValid() {
+		return nil, fmt.Errorf("invalid order: %q", req.Order)
+	}
+
+	var res ListRunsResponse
+	err := c.do(ctx, http.MethodGet, "/threads/"+req.ThreadID+"/runs"+listQuery(req.Limit, req.Order, req.After, req.Before), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListRunsRequest, after string) { r.After = after }, c.ListRuns)
+	return &res, nil
 }
 
 type AssistantToolOutput struct {
@@ -3831,40 +3966,11 @@ type SubmitToolOutputsResponse = Run
 
 // https://platform.openai.com/docs/api-reference/runs/submitToolOutputs
 func (c *Client) SubmitToolOutputs(ctx context.Context, req *SubmitToolOutputsRequest) (*SubmitToolOutputsResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/threads/"+req.ThreadID+"/runs/"+req.RunID+"/submit_tool_outputs", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res SubmitToolOutputsResponse
+	err := c.do(ctx, http.MethodPost, "/threads/"+req.ThreadID+"/runs/"+req.RunID+"/submit_tool_outputs", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res SubmitToolOutputsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
 	return &res, nil
 }
 
@@ -3882,45 +3988,36 @@ type CancelRunRequest struct {
 }
 
 // https://platform.openai.com/docs/api-reference/runs/cancelRun
-func (c *Client) CancelRun(ctx context.Context, req *CancelRunRequest) error {
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/threads/"+req.ThreadID+"/runs/"+req.RunID+"/cancel", nil)
-	if err != nil {
-		return err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
+type CancelRunResponse = Run
 
-	resp, err := c.HTTPClient.Do(r)
+// CancelRun cancels an in-progress run, returning it with its status, usually
+// "cancelling" until it's cancelled.
+//
+// https://platform.openai.com/docs/api-reference/runs/cancelRun
+func (c *Client) CancelRun(ctx context.Context, req *CancelRunRequest) (*CancelRunResponse, error) {
+	var res CancelRunResponse
+	err := c.do(ctx, http.MethodPost, "/threads/"+req.ThreadID+"/runs/"+req.RunID+"/cancel", nil, &res)
 	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
+		return nil, err
 	}
-
-	return nil
+	return &res, nil
 }
 
 // https://platform.openai.com/docs/api-reference/runs/createThreadAndRun#runs-createthreadandrun-thread
 type CreateThreadAndRunRequestInitialThreadMessage struct {
-	Role     string         `json:"role"`
-	Content  string         `json:"content"`
-	FilesIDs []string       `json:"file_ids,omitempty"`
-	Metadata map[string]any `json:"metadata,omitempty"`
+	Role        string              `json:"role"`
+	Content     string              `json:"content"`
+	Attachments []MessageAttachment `json:"attachments,omitempty"`
+	Metadata    map[string]any      `json:"metadata,omitempty"`
+
+	// Deprecated: FilesIDs is only supported by assistants v1. Use Attachments instead.
+	FilesIDs []string `json:"file_ids,omitempty"`
 }
 
 type CreateThreadAndRunRequestInitialThread struct {
-	Messages []*CreateThreadAndRunRequestInitialThreadMessage `json:"messages,omitempty"`
-	Metadata map[string]any                                   `json:"metadata,omitempty"`
+	Messages      []*CreateThreadAndRunRequestInitialThreadMessage `json:"messages,omitempty"`
+	ToolResources *ToolResources                                   `json:"tool_resources,omitempty"`
+	Metadata      map[string]any                                   `json:"metadata,omitempty"`
 }
 
 // https://platform.openai.com/docs/api-reference/runs/createThreadAndRun
@@ -3950,6 +4047,11 @@ type CreateThreadAndRunRequest struct {
 	// Optional. Defaults to the tools associated with the assistant.
 	Tools []map[string]any `json:"tools,omitempty"`
 
+	// https://platform.openai.com/docs/api-reference/runs/createThreadAndRun#runs-createthreadandrun-tool_resources
+	//
+	// Optional. Defaults to the tool resources of the assistant.
+	ToolResources *ToolResources `json:"tool_resources,omitempty"`
+
 	// https://platform.openai.com/docs/api-reference/runs/createThreadAndRun#runs-createthreadandrun-metadata
 	//
 	// Optional.
@@ -3961,36 +4063,11 @@ type CreateThreadAndRunResponse = Run
 
 // https://platform.openai.com/docs/api-reference/runs/createThreadAndRun
 func (c *Client) CreateThreadAndRun(ctx context.Context, req *CreateThreadAndRunRequest) (*CreateThreadAndRunResponse, error) {
-	b, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/threads/runs", bytes.NewReader(b))
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	resp, err := c.HTTPClient.Do(r)
+	var res CreateThreadAndRunResponse
+	err := c.do(ctx, http.MethodPost, "/threads/runs", req, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-
-	var res CreateThreadAndRunResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
 	return &res, nil
 }
 
@@ -4002,15 +4079,21 @@ type RunStep struct {
 	AssistantID string         `json:"assistant_id"`
 	ThreadID    string         `json:"thread_id"`
 	RunID       string         `json:"run_id"`
-	Type        string         `json:"type"`
+	Type        RunStepType    `json:"type"`
 	Status      string         `json:"status"`
-	StepDetails map[string]any `json:"step_details"`
+	StepDetails RunStepDetails `json:"step_details"`
 	LastError   map[string]any `json:"last_error,omitempty"`
 	ExpiredAt   int            `json:"expired_at,omitempty"`
 	CanceledAt  int            `json:"canceled_at,omitempty"`
 	FailedAt    int            `json:"failed_at,omitempty"`
 	CompletedAt int            `json:"completed_at,omitempty"`
 	Metadata    map[string]any `json:"metadata,omitempty"`
+
+	// Usage is the token usage of the step, once it's completed, which can be
+	// priced with the run's model, such as by ChatUsage.Cost.
+	//
+	// https://platform.openai.com/docs/api-reference/run-steps/step-object#run-steps/step-object-usage
+	Usage *ChatUsage `json:"usage,omitempty"`
 }
 
 // https://platform.openai.com/docs/api-reference/runs/getRunStep
@@ -4029,6 +4112,13 @@ type GetRunStepRequest struct {
 	//
 	// Required.
 	StepID string
+	// Include are additional fields to include in the step, such as
+	// RunStepIncludeFileSearchContent.
+	//
+	// https://platform.openai.com/docs/api-reference/run-steps/getRunStep#run-steps-getrunstep-include
+	//
+	// Optional.
+	Include []string
 }
 
 // https://platform.openai.com/docs/api-reference/runs/getRunStep
@@ -4036,35 +4126,11 @@ type GetRunStepResponse = RunStep
 
 // https://platform.openai.com/docs/api-reference/runs/getRunStep
 func (c *Client) GetRunStep(ctx context.Context, req *GetRunStepRequest) (*GetRunStepResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/threads/"+req.ThreadID+"/runs/"+req.RunID+"/steps/"+req.StepID, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	resp, err := c.HTTPClient.Do(r)
+	var res GetRunStepResponse
+	err := c.do(ctx, http.MethodGet, withInclude("/threads/"+req.ThreadID+"/runs/"+req.RunID+"/steps/"+req.StepID, req.Include), nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res GetRunStepResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
 	return &res, nil
 }
 
@@ -4088,7 +4154,7 @@ type ListRunStepsRequest struct {
 	// https://platform.openai.com/docs/api-reference/runs/listRunSteps#runs-listrunsteps-order
 	//
 	// Optional. Defaults to "desc".
-	Order string
+	Order SortOrder
 
 	// https://platform.openai.com/docs/api-reference/runs/listRunSteps#runs-listrunsteps-after
 	//
@@ -4099,64 +4165,30 @@ type ListRunStepsRequest struct {
 	//
 	// Optional.
 	Before string
+	// Include are additional fields to include in the steps, such as
+	// RunStepIncludeFileSearchContent.
+	//
+	// https://platform.openai.com/docs/api-reference/run-steps/listRunSteps#run-steps-listrunsteps-include
+	//
+	// Optional.
+	Include []string
 }
 
 // https://platform.openai.com/docs/api-reference/runs/listRunSteps
-type ListRunStepsResponse struct {
-	Data []RunStep `json:"data"`
-}
+type ListRunStepsResponse = Page[RunStep]
 
 // https://platform.openai.com/docs/api-reference/runs/listRunSteps
 func (c *Client) ListRunSteps(ctx context.Context, req *ListRunStepsRequest) (*ListRunStepsResponse, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.openai.com/v1/threads/"+req.ThreadID+"/runs/"+req.RunID+"/steps", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
-	r.Header.Set("OpenAI-Beta", "assistants=v1")
-
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
-	}
-
-	q := r.URL.Query()
-
-	if req.Limit != 0 {
-		q.Set("limit", strconv.Itoa(req.Limit))
-	}
-
-	if req.Order != "" {
-		q.Set("order", req.Order)
-	}
-
-	if req.After != "" {
-		q.Set("after", req.After)
-	}
-
-	if req.Before != "" {
-		q.Set("before", req.Before)
+	if req.Order != "" && !req.Order.IsValid() {
+		return nil, fmt.Errorf("invalid order: %q", req.Order)
 	}
 
-	r.URL.RawQuery = q.Encode()
-
-	resp, err := c.HTTPClient.Do(r)
+	var res ListRunStepsResponse
+	err := c.do(ctx, http.MethodGet, withInclude("/threads/"+req.ThreadID+"/runs/"+req.RunID+"/steps"+listQuery(req.Limit, req.Order, req.After, req.Before), req.Include), nil, &res)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
-	}
-	defer resp.Body.Close()
-
-	var res ListRunStepsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+	paginate(&res, req, func(r *ListRunStepsRequest, after string) { r.After = after }, c.ListRunSteps)
 	return &res, nil
 }
 
@@ -4175,82 +4207,172 @@ type CreateSpeechRequest struct {
 	// https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-voice
 	//
 	// Required.
-	Voice string `json:"voice,omitempty"`
+	Voice SpeechVoice `json:"voice,omitempty"`
 
 	// https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-response_format
 	//
 	// Optional. Defaults to "mp3".
-	ResponseFormat string `json:"response_format,omitempty"`
+	ResponseFormat SpeechFormat `json:"response_format,omitempty"`
 
+	// Speed is the speed of the generated speech, from 0.25 to 4.0.
+	//
 	// https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-speed
 	//
 	// Optional. Defaults to 1.
 	Speed float64 `json:"speed,omitempty"`
+
+	// Instructions control the voice of the generated speech, such as its tone,
+	// accent or emotional range. They aren't supported by tts-1 or tts-1-hd.
+	//
+	// https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-instructions
+	//
+	// Optional.
+	Instructions string `json:"instructions,omitempty"`
+
+	// StreamFormat is the format the speech is streamed in. Use
+	// CreateSpeechStream to read either format.
+	//
+	// https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-stream_format
+	//
+	// Optional. Defaults to "audio".
+	StreamFormat SpeechStreamFormat `json:"stream_format,omitempty"`
 }
 
 // https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-response
 func (c *Client) CreateSpeech(ctx context.Context, req *CreateSpeechRequest) (io.ReadCloser, error) {
+	if req.Voice != "" && !req.Voice.IsValid() {
+		return nil, fmt.Errorf("invalid voice: %q", req.Voice)
+	}
+
+	if req.ResponseFormat != "" && !req.ResponseFormat.IsValid() {
+		return nil, fmt.Errorf("invalid speech format: %q", req.ResponseFormat)
+	}
+
+	if req.Speed != 0 && (req.Speed < 0.25 || req.Speed > 4) {
+		return nil, fmt.Errorf("invalid speech speed: %v, must be between 0.25 and 4.0", req.Speed)
+	}
+
 	b, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.openai.com/v1/audio/speech", bytes.NewReader(b))
+	resp, err := c.doRequest(ctx, http.MethodPost, "/audio/speech", "application/json", bytes.NewReader(b))
 	if err != nil {
 		return nil, err
 	}
 
-	r.Header.Add("Content-Type", "application/json")
-	r.Header.Add("Authorization", "Bearer "+c.APIKey)
+	return resp.Body, nil
+}
 
-	if c.Organization != "" {
-		r.Header.Set("OpenAI-Organization", c.Organization)
+// WaitForRunOptions configures WaitForRun.
+type WaitForRunOptions struct {
+	// Interval is the delay before polling the run again after first checking
+	// it, doubled after each poll.
+	//
+	// Optional. Defaults to 500ms.
+	Interval time.Duration
+
+	// MaxInterval caps the delay between polls.
+	//
+	// Optional. Defaults to 5s.
+	MaxInterval time.Duration
+
+	// Timeout is the maximum time to wait for the run overall.
+	//
+	// Optional. Defaults to no limit other than the context's.
+	Timeout time.Duration
+
+	// OnRequiresAction is called when the run requires action, such as to
+	// submit the outputs of its tool calls with SubmitToolOutputs, after
+	// which waiting continues. If it returns an error, waiting stops and the
+	// error is returned along with the run.
+	//
+	// Optional. If nil, WaitForRun returns the run once it requires action.
+	OnRequiresAction func(ctx context.Context, run *Run) error
+}
+
+// WaitForRun polls the API until the run is completed, failed, cancelled,
+// expired, or incomplete, backing off exponentially between polls, and returns the final run.
+// It returns an error along with the run if the run didn't complete.
+//
+// # Example
+//
+//	run, err := openai.WaitForRun(ctx, client, thread.ID, run.ID, &openai.WaitForRunOptions{
+//		Timeout: 2 * time.Minute,
+//		OnRequiresAction: func(ctx context.Context, run *openai.Run) error {
+//			_, err := client.SubmitToolOutputs(ctx, &openai.SubmitToolOutputsRequest{
+//				ThreadID:    run.ThreadID,
+//				RunID:       run.ID,
+//				ToolOuputs: dispatch(run.ToolCalls()),
+//			})
+//			return err
+//		},
+//	})
+func WaitForRun(ctx context.Context, client *Client, threadID, runID string, opts *WaitForRunOptions) (*Run, error) {
+	if opts == nil {
+		opts = &WaitForRunOptions{}
 	}
 
-	resp, err := c.HTTPClient.Do(r)
-	if err != nil {
-		return nil, err
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
-		return nil, fmt.Errorf("unexpected status code: %d: %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), body)
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 5 * time.Second
 	}
 
-	return resp.Body, nil
-}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
 
-// WaitForRun polls the API at the given inter until the run is completed, failed, cancelled, or expired.
-//
-// It returns nil if the run completed successfully, or an error if the run failed, was cancelled, or expired.
-func WaitForRun(ctx context.Context, client *Client, threadID, runID string, interval time.Duration) error {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	delay := interval
 
 	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-ticker.C:
-			run, err := client.GetRun(ctx, &GetRunRequest{
-				ThreadID: threadID,
-				RunID:    runID,
-			})
-			if err != nil {
-				return err
+		run, err := client.GetRun(ctx, &GetRunRequest{
+			ThreadID: threadID,
+			RunID:    runID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		switch run.Status {
+		case RunStatusCompleted:
+			return run, nil
+		case RunStatusFailed:
+			return run, fmt.Errorf("run %q failed: %v", runID, run.LastError)
+		case RunStatusCancelled:
+			return run, fmt.Errorf("run %q cancelled", runID)
+		case RunStatusExpired:
+			return run, fmt.Errorf("run %q expired", runID)
+		case RunStatusIncomplete:
+			return run, fmt.Errorf("run %q incomplete: %v", runID, run.IncompleteDetails)
+		case RunStatusRequiresAction:
+			if opts.OnRequiresAction == nil {
+				return run, nil
 			}
 
-			switch run.Status {
-			case RunStatusCompleted:
-				return nil
-			case RunStatusFailed:
-				return fmt.Errorf("run %q failed: %v", runID, run.LastError)
-			case RunStatusCancelled:
-				return fmt.Errorf("run %q cancelled", runID)
-			case RunStatusExpired:
-				return fmt.Errorf("run %q expired", runID)
+			if err := opts.OnRequiresAction(ctx, run); err != nil {
+				return run, err
 			}
+
+			// The run resumes, so check on it again soon.
+			delay = interval
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return run, ctx.Err()
+		case <-t.C:
 		}
+
+		delay = min(delay*2, maxInterval)
 	}
 }
@@ -1,10 +1,15 @@
 package openai_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -19,6 +24,26 @@ func testCtx(t *testing.T) context.Context {
 	return ctx
 }
 
+// handlerTransport is an http.RoundTripper that serves requests with an
+// http.Handler, allowing tests to run without reaching the real API.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t *handlerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	t.handler.ServeHTTP(w, r)
+	return w.Result(), nil
+}
+
+// testClient returns a client that sends all requests to the given handler.
+func testClient(t *testing.T, h http.HandlerFunc) *openai.Client {
+	t.Helper()
+	return openai.NewClient("test", openai.WithHTTPClient(&http.Client{
+		Transport: &handlerTransport{handler: h},
+	}))
+}
+
 func TestCreateCompletion(t *testing.T) {
 	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"))
 
@@ -1208,3 +1233,243 @@ func TestAssistant_beta(t *testing.T) {
 		}
 	})
 }
+
+func TestWithBaseURL(t *testing.T) {
+	var urls []string
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		urls = append(urls, r.URL.String())
+		if got := r.Header.Get("Authorization"); got != "Bearer test" {
+			t.Errorf("unexpected authorization header: %q", got)
+		}
+		if r.URL.Path == "/openai/v1/assistants" && r.Header.Get("OpenAI-Beta") == "" {
+			t.Error("expected beta header for assistants")
+		}
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	c := openai.NewClient("test",
+		openai.WithBaseURL("http://localhost:8000/openai/v1/"),
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+	)
+
+	ctx := testCtx(t)
+
+	_, err := c.ListModels(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.ListAssistants(ctx, &openai.ListAssistantsRequest{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"http://localhost:8000/openai/v1/models",
+		"http://localhost:8000/openai/v1/assistants?limit=1",
+	}
+
+	if strings.Join(urls, " ") != strings.Join(want, " ") {
+		t.Fatalf("unexpected request URLs: %v", urls)
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	var header http.Header
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	c := openai.NewClient("test",
+		openai.WithHeaders(http.Header{
+			"X-Trace-Id":    {"trace-1"},
+			"OpenAI-Beta":   {"assistants=v1"},
+			"Authorization": {"Bearer other"},
+		}),
+		openai.WithHeaders(http.Header{"X-Trace-Id": {"trace-2"}}),
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+	)
+
+	_, err := c.ListAssistants(testCtx(t), &openai.ListAssistantsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := header.Values("X-Trace-Id"); strings.Join(got, ",") != "trace-1,trace-2" {
+		t.Errorf("unexpected trace headers: %q", got)
+	}
+
+	if got := header.Get("OpenAI-Beta"); got != "assistants=v1" {
+		t.Errorf("unexpected beta header: %q", got)
+	}
+
+	if got := header.Get("Authorization"); got != "Bearer test" {
+		t.Errorf("unexpected authorization header: %q", got)
+	}
+}
+
+func TestCreateChat_ServiceTier(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ServiceTier string `json:"service_tier"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.ServiceTier != "flex" {
+			t.Errorf("unexpected service tier: %q", body.ServiceTier)
+		}
+		fmt.Fprint(w, `{"service_tier":"flex","choices":[{"index":0,"message":{"role":"assistant","content":"Hi!"}}]}`)
+	}
+
+	c := testClient(t, h)
+
+	resp, err := c.CreateChat(testCtx(t), &openai.CreateChatRequest{
+		Model:       "o3",
+		Messages:    []openai.ChatMessage{openai.User("Hello!")},
+		ServiceTier: openai.ServiceTierFlex,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.ServiceTier != openai.ServiceTierFlex {
+		t.Fatalf("unexpected response service tier: %q", resp.ServiceTier)
+	}
+}
+
+// trackedBody records whether a response body was closed.
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestGzipResponses(t *testing.T) {
+	var bodies []*trackedBody
+
+	gzipResponse := func(status int, body string) *http.Response {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(body))
+		zw.Close()
+
+		b := &trackedBody{Reader: &buf}
+		bodies = append(bodies, b)
+
+		return &http.Response{
+			StatusCode: status,
+			Header: http.Header{
+				"Content-Type":     {"application/json"},
+				"Content-Encoding": {"gzip"},
+			},
+			Body: b,
+		}
+	}
+
+	c := openai.NewClient("test", openai.WithHTTPClient(&http.Client{
+		Transport: openai.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+				t.Errorf("unexpected accept encoding: %q", got)
+			}
+			if strings.HasSuffix(r.URL.Path, "/missing") {
+				return gzipResponse(http.StatusNotFound, `{"error":{"message":"No thread found","type":"invalid_request_error"}}`), nil
+			}
+			return gzipResponse(http.StatusOK, `{"id":"thread_123","object":"thread"}`), nil
+		}),
+	}))
+
+	ctx := testCtx(t)
+
+	thread, err := c.GetThread(ctx, &openai.GetThreadRequest{ID: "thread_123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if thread.ID != "thread_123" {
+		t.Errorf("unexpected thread ID: %q", thread.ID)
+	}
+
+	_, err = c.GetThread(ctx, &openai.GetThreadRequest{ID: "missing"})
+
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "No thread found" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, b := range bodies {
+		if !b.closed {
+			t.Errorf("response body %d was not closed", i)
+		}
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		body      io.Reader
+		sizeKnown bool
+	}{
+		{"known size", bytes.NewReader([]byte(`{"prompt":"a","completion":"b"}`)), true},
+		{"unknown size", io.MultiReader(strings.NewReader(`{"prompt":"a",`), strings.NewReader(`"completion":"b"}`)), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if tc.sizeKnown && r.ContentLength != int64(len(body)) {
+					t.Errorf("expected content length %d, got %d", len(body), r.ContentLength)
+				}
+
+				if !tc.sizeKnown && r.ContentLength > 0 {
+					t.Errorf("unexpected content length %d", r.ContentLength)
+				}
+
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				if err := r.ParseMultipartForm(1 << 20); err != nil {
+					t.Fatalf("failed to parse form: %v", err)
+				}
+
+				f, h, err := r.FormFile("file")
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, _ := io.ReadAll(f)
+
+				if h.Filename != "train.jsonl" || string(b) != `{"prompt":"a","completion":"b"}` {
+					t.Errorf("unexpected file: %q %q", h.Filename, b)
+				}
+
+				if got := r.FormValue("purpose"); got != "fine-tune" {
+					t.Errorf("unexpected purpose: %q", got)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id":"file-123","object":"file","bytes":%d,"filename":%q}`, len(b), h.Filename)
+			})
+
+			resp, err := c.UploadFile(testCtx(t), &openai.UploadFileRequest{
+				Name:    "train.jsonl",
+				Purpose: string(openai.FilePurposeFineTune),
+				Body:    tc.body,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.ID != "file-123" {
+				t.Errorf("unexpected file ID: %q", resp.ID)
+			}
+		})
+	}
+}
@@ -858,7 +858,7 @@ func startAssistantChat(client *openai.Client, model, assistantID string) error
 			}
 
 			for _, f := range listFilesResp.Data {
-				if f.Purpose != "assistants" {
+				if f.Purpose != string(openai.FilePurposeAssistants) {
 					continue
 				}
 				bt.WriteString(fmt.Sprintf("%s: %s (%d)\n", f.ID, f.Filename, f.Bytes))
@@ -1037,7 +1037,7 @@ func startAssistantChat(client *openai.Client, model, assistantID string) error
 			return fmt.Errorf("failed to create run: %w", err)
 		}
 
-		err = openai.WaitForRun(ctx, client, thread.ID, runResp.ID, 700*time.Millisecond)
+		_, err = openai.WaitForRun(ctx, client, thread.ID, runResp.ID, &openai.WaitForRunOptions{Interval: 700 * time.Millisecond})
 		if err != nil {
 			return fmt.Errorf("failed to wait for run: %w", err)
 		}
@@ -1070,7 +1070,7 @@ func startAssistantChat(client *openai.Client, model, assistantID string) error
 
 			audioStream, err := client.CreateSpeech(ctx, &openai.CreateSpeechRequest{
 				Model:          openai.ModelTTS1HD1106,
-				Voice:          "fable",
+				Voice:          openai.SpeechVoiceFable,
 				Input:          nextMsg,
 				ResponseFormat: "mp3",
 			})
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/picatz/openai"
+	"github.com/spf13/cobra"
+)
+
+var embedCommand = &cobra.Command{
+	Use:   "embed <text>",
+	Short: "Create an embedding vector for text",
+	Long:  "Create an embedding vector for text, printing it as a JSON array. If no text is given, it is read from stdin.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var input string
+		if len(args) == 1 {
+			input = args[0]
+		} else {
+			b, err := io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			input = strings.TrimSpace(string(b))
+		}
+
+		model := cmd.Flag("model").Value.String()
+
+		resp, err := client.CreateEmbedding(cmd.Context(), &openai.CreateEmbeddingRequest{
+			Model: model,
+			Input: input,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Data) == 0 {
+			return errors.New("no embedding returned")
+		}
+
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(resp.Data[0].Embedding)
+	},
+}
+
+func init() {
+	embedCommand.Flags().String("model", openai.ModelTextEmbedding3Small, "model to use")
+
+	rootCmd.AddCommand(embedCommand)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+// handlerTransport sends requests to an http.Handler instead of the network.
+type handlerTransport struct {
+	handler http.HandlerFunc
+}
+
+func (t *handlerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	t.handler(w, r)
+	return w.Result(), nil
+}
+
+// withTestClient sets the client used by commands to one sending requests to
+// the handler, for the duration of the test.
+func withTestClient(t *testing.T, h http.HandlerFunc) {
+	t.Helper()
+
+	prev := client
+	client = openai.NewClient("test", openai.WithHTTPClient(&http.Client{Transport: &handlerTransport{handler: h}}))
+	t.Cleanup(func() { client = prev })
+}
+
+func runEmbed(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	embedCommand.SetIn(strings.NewReader(stdin))
+	embedCommand.SetOut(&out)
+	embedCommand.SetContext(context.Background())
+
+	err := embedCommand.RunE(embedCommand, args)
+	return out.String(), err
+}
+
+func TestEmbedCommand(t *testing.T) {
+	var input string
+
+	withTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		b, _ := io.ReadAll(r.Body)
+		input = string(b)
+
+		fmt.Fprint(w, `{"object": "list", "data": [{"object": "embedding", "index": 0, "embedding": [0.5, -0.25]}]}`)
+	})
+
+	out, err := runEmbed(t, "", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out != "[0.5,-0.25]\n" || !strings.Contains(input, `"input":"hello"`) {
+		t.Fatalf("unexpected output %q for request %s", out, input)
+	}
+
+	// Without arguments, the text is read from stdin.
+	if _, err := runEmbed(t, "  from stdin\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(input, `"input":"from stdin"`) {
+		t.Fatalf("expected the text from stdin, got request %s", input)
+	}
+}
+
+func TestEmbedCommand_NoData(t *testing.T) {
+	withTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"object": "list", "data": []}`)
+	})
+
+	if _, err := runEmbed(t, "", "hello"); err == nil {
+		t.Fatal("expected an error for a response without embeddings")
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/picatz/openai"
+	"github.com/spf13/cobra"
+)
+
+var filesCommand = &cobra.Command{
+	Use:   "files",
+	Short: "Manage uploaded files",
+}
+
+var filesListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "List uploaded files",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		purpose := cmd.Flag("purpose").Value.String()
+
+		resp, err := client.ListFiles(cmd.Context(), &openai.ListFilesRequest{
+			Purpose: purpose,
+		})
+		if err != nil {
+			return err
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tFILENAME\tPURPOSE\tBYTES\tCREATED")
+		for _, f := range resp.Data {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", f.ID, f.Filename, f.Purpose, f.Bytes, time.Unix(int64(f.CreatedAt), 0).Format(time.RFC3339))
+		}
+		return tw.Flush()
+	},
+}
+
+var filesUploadCommand = &cobra.Command{
+	Use:   "upload <file>",
+	Short: "Upload a file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		purpose := cmd.Flag("purpose").Value.String()
+
+		resp, err := client.UploadFile(cmd.Context(), &openai.UploadFileRequest{
+			Name:    filepath.Base(args[0]),
+			Purpose: purpose,
+			Body:    f,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.ID)
+
+		return nil
+	},
+}
+
+var filesDeleteCommand = &cobra.Command{
+	Use:   "delete <file-id>",
+	Short: "Delete a file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := client.DeleteFile(cmd.Context(), &openai.DeleteFileRequest{
+			ID: args[0],
+		})
+		return err
+	},
+}
+
+var filesContentCommand = &cobra.Command{
+	Use:   "content <file-id>",
+	Short: "Write the contents of a file to stdout",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.GetFileContent(cmd.Context(), &openai.GetFileContentRequest{
+			ID: args[0],
+		})
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		_, err = io.Copy(os.Stdout, resp.Body)
+		return err
+	},
+}
+
+func init() {
+	filesListCommand.Flags().String("purpose", "", "only list files with this purpose")
+	filesUploadCommand.Flags().String("purpose", string(openai.FilePurposeAssistants), "intended purpose of the file")
+
+	filesCommand.AddCommand(
+		filesListCommand,
+		filesUploadCommand,
+		filesDeleteCommand,
+		filesContentCommand,
+	)
+
+	rootCmd.AddCommand(filesCommand)
+}
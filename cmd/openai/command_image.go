@@ -28,8 +28,8 @@ var imageCommand = &cobra.Command{
 			Prompt:  prompt,
 			Model:   model,
 			N:       n,
-			Quality: quality,
-			Size:    size,
+			Quality: openai.ImageQuality(quality),
+			Size:    openai.ImageSize(size),
 			Style:   style,
 		})
 		if err != nil {
@@ -53,10 +53,10 @@ var imageCommand = &cobra.Command{
 }
 
 func init() {
-	imageCommand.Flags().String("quality", "hd", "image quality")
+	imageCommand.Flags().String("quality", string(openai.ImageQualityHD), "image quality")
 	imageCommand.Flags().String("style", "vivid", "image style")
 	imageCommand.Flags().String("model", openai.ModelDallE3, "model to use")
-	imageCommand.Flags().String("size", "1792x1024", "image size")
+	imageCommand.Flags().String("size", string(openai.ImageSize1792x1024), "image size")
 	imageCommand.Flags().Int("n", 1, "number of images to generate")
 
 	rootCmd.AddCommand(imageCommand)
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var modelsCommand = &cobra.Command{
+	Use:   "models",
+	Short: "List available models",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp, err := client.ListModels(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(resp.Data))
+		for _, m := range resp.Data {
+			ids = append(ids, m.ID)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			fmt.Println(id)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(modelsCommand)
+}
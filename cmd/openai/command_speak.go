@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/picatz/openai"
+	"github.com/spf13/cobra"
+)
+
+var speakCommand = &cobra.Command{
+	Use:   "speak <text>",
+	Short: "Convert text to speech",
+	Long:  "Convert text to speech, writing the audio to the output file. If no text is given, it is read from stdin.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var input string
+		if len(args) == 1 {
+			input = args[0]
+		} else {
+			b, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return err
+			}
+			input = strings.TrimSpace(string(b))
+		}
+
+		model := cmd.Flag("model").Value.String()
+		voice := cmd.Flag("voice").Value.String()
+		format := cmd.Flag("format").Value.String()
+		output := cmd.Flag("output").Value.String()
+		speed, err := cmd.Flags().GetFloat64("speed")
+		if err != nil {
+			return err
+		}
+
+		audio, err := client.CreateSpeech(cmd.Context(), &openai.CreateSpeechRequest{
+			Model:          model,
+			Input:          input,
+			Voice:          openai.SpeechVoice(voice),
+			ResponseFormat: openai.SpeechFormat(format),
+			Speed:          speed,
+		})
+		if err != nil {
+			return err
+		}
+		defer audio.Close()
+
+		var w io.Writer = os.Stdout
+		if output != "-" {
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		_, err = io.Copy(w, audio)
+		return err
+	},
+}
+
+func init() {
+	speakCommand.Flags().String("model", openai.ModelTTS1, "model to use")
+	speakCommand.Flags().String("voice", string(openai.SpeechVoiceAlloy), "voice to use")
+	speakCommand.Flags().String("format", string(openai.SpeechFormatMP3), "audio format")
+	speakCommand.Flags().Float64("speed", 1, "speed of the generated audio")
+	speakCommand.Flags().StringP("output", "o", "speech.mp3", "output file, or - for stdout")
+
+	rootCmd.AddCommand(speakCommand)
+}
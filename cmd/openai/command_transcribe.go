@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/picatz/openai"
+	"github.com/spf13/cobra"
+)
+
+var transcribeCommand = &cobra.Command{
+	Use:   "transcribe <audio-file>",
+	Short: "Transcribe an audio file with Whisper",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		model := cmd.Flag("model").Value.String()
+		language := cmd.Flag("language").Value.String()
+		prompt := cmd.Flag("prompt").Value.String()
+
+		resp, err := client.CreateAudioTranscription(cmd.Context(), &openai.CreateAudioTranscriptionRequest{
+			File:     f,
+			Model:    model,
+			Language: language,
+			Prompt:   prompt,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(resp.Text())
+
+		return nil
+	},
+}
+
+func init() {
+	transcribeCommand.Flags().String("model", openai.ModelWhisper1, "model to use")
+	transcribeCommand.Flags().String("language", "", "language of the audio (ISO-639-1)")
+	transcribeCommand.Flags().String("prompt", "", "optional text to guide the transcription")
+
+	rootCmd.AddCommand(transcribeCommand)
+}
@@ -15,11 +15,6 @@ var (
 )
 
 func init() {
-	if apiKey == "" {
-		fmt.Fprintln(os.Stderr, "OPENAI_API_KEY environment variable is not set")
-		os.Exit(1)
-	}
-
 	if model == "" {
 		model = openai.ModelGPT4TurboPreview
 	}
@@ -28,6 +23,11 @@ func init() {
 }
 
 func main() {
+	if apiKey == "" {
+		fmt.Fprintln(os.Stderr, "OPENAI_API_KEY environment variable is not set")
+		os.Exit(1)
+	}
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
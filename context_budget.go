@@ -0,0 +1,176 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/picatz/openai/tokenizer"
+)
+
+// ErrContextWindowExceeded is returned by ContextBudget.Fit when the messages
+// can't be made to fit the context window, such as when the system messages
+// alone are too long.
+var ErrContextWindowExceeded = errors.New("messages exceed the context window")
+
+// ContextWindow returns the context window size of the given model in tokens,
+// which is shared by the prompt and the response. It returns false for models
+// whose context window isn't known.
+func ContextWindow(model string) (int, bool) {
+	caps, ok := Capabilities(model)
+	if !ok {
+		return 0, false
+	}
+
+	return caps.ContextWindow, true
+}
+
+// ContextBudget fits a conversation into a model's context window, leaving room
+// for the response, by dropping the oldest messages.
+//
+// # Example
+//
+//	budget := openai.ContextBudget{Model: openai.ModelGPT4, MaxResponseTokens: 512}
+//
+//	messages, err := budget.Fit(history)
+//	if err != nil {
+//		return err
+//	}
+//
+//	resp, err := client.CreateChat(ctx, &openai.CreateChatRequest{
+//		Model:     openai.ModelGPT4,
+//		Messages:  messages,
+//		MaxTokens: 512,
+//	})
+type ContextBudget struct {
+	// Model is the model the messages are sent to, used to count tokens and
+	// look up its context window.
+	//
+	// Required.
+	Model string
+
+	// MaxResponseTokens is the number of tokens reserved for the response.
+	//
+	// Optional.
+	MaxResponseTokens int
+
+	// ContextWindow is the context window size of the model in tokens.
+	//
+	// Optional. Defaults to the model's known context window.
+	ContextWindow int
+}
+
+// limit returns the number of tokens available to the prompt.
+func (b *ContextBudget) limit() (int, error) {
+	window := b.ContextWindow
+	if window == 0 {
+		var ok bool
+		window, ok = ContextWindow(b.Model)
+		if !ok {
+			return 0, fmt.Errorf("unknown context window for model %q", b.Model)
+		}
+	}
+
+	return window - b.MaxResponseTokens, nil
+}
+
+// Fit returns the messages that fit the budget. If they don't fit as-is, the
+// oldest non-system messages are dropped, along with the results of any tool
+// calls they made. If the newest message still doesn't fit on its own, the
+// start of its text content is trimmed.
+//
+// System messages are always kept, and the given slice isn't modified. Token
+// counts are estimated unless the model's encoding is loaded, as described by
+// CountChatTokens.
+func (b *ContextBudget) Fit(messages []ChatMessage) ([]ChatMessage, error) {
+	limit, err := b.limit()
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := CountChatTokens(b.Model, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	if n <= limit {
+		return messages, nil
+	}
+
+	msgs := slices.Clone(messages)
+
+	for n > limit {
+		i := slices.IndexFunc(msgs, func(m ChatMessage) bool { return m.Role != ChatRoleSystem })
+
+		// Keep the newest message, which is trimmed below instead.
+		if i < 0 || i == lastNonSystem(msgs) {
+			break
+		}
+
+		msgs = slices.Delete(msgs, i, i+1)
+
+		// Tool results can't be sent without the message that called them.
+		for i < len(msgs) && msgs[i].Role == ChatRoleTool {
+			msgs = slices.Delete(msgs, i, i+1)
+		}
+
+		n, err = CountChatTokens(b.Model, msgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if n <= limit {
+		return msgs, nil
+	}
+
+	i := lastNonSystem(msgs)
+	if i < 0 || len(msgs[i].ContentParts) > 0 {
+		return nil, ErrContextWindowExceeded
+	}
+
+	enc, err := tokenizer.ForModel(b.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := enc.Count(msgs[i].Content) - (n - limit)
+	if keep <= 0 {
+		return nil, ErrContextWindowExceeded
+	}
+
+	msgs[i].Content = keepLastTokens(enc, msgs[i].Content, keep)
+
+	return msgs, nil
+}
+
+// lastNonSystem returns the index of the last non-system message, or -1.
+func lastNonSystem(msgs []ChatMessage) int {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role != ChatRoleSystem {
+			return i
+		}
+	}
+	return -1
+}
+
+// keepLastTokens returns the longest suffix of text, starting on a character
+// boundary, with at most n tokens.
+func keepLastTokens(enc *tokenizer.Encoding, text string, n int) string {
+	offsets := make([]int, 0, utf8.RuneCountInString(text))
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+
+	i := sort.Search(len(offsets), func(i int) bool {
+		return enc.Count(text[offsets[i]:]) <= n
+	})
+
+	if i == len(offsets) {
+		return ""
+	}
+
+	return text[offsets[i]:]
+}
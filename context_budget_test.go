@@ -0,0 +1,107 @@
+package openai_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestContextBudget_Fit(t *testing.T) {
+	history := []openai.ChatMessage{
+		openai.System("Be brief."),
+		openai.User("What's the weather in Paris?"),
+		{
+			Role: openai.ChatRoleAssistant,
+			ToolCalls: []openai.ToolCall{{
+				ID:       "call_123",
+				Type:     openai.ToolTypeFunction,
+				Function: &openai.FunctionCall{Name: "get_weather", Arguments: openai.FunctionCallArguments{"city": "Paris"}},
+			}},
+		},
+		openai.ToolResult("call_123", "Sunny, 20C"),
+		openai.AssistantMsg("It's sunny and 20C in Paris."),
+		openai.User("And tomorrow?"),
+	}
+
+	want := []openai.ChatMessage{history[0], history[4], history[5]}
+
+	n, err := openai.CountChatTokens(openai.ModelGPT4, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	budget := openai.ContextBudget{Model: openai.ModelGPT4, MaxResponseTokens: 100, ContextWindow: n + 100}
+
+	got, err := budget.Fit(history)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d messages, got %d: %#+v", len(want), len(got), got)
+	}
+
+	for i := range want {
+		if got[i].Role != want[i].Role || got[i].Content != want[i].Content {
+			t.Fatalf("unexpected message %d: %#+v", i, got[i])
+		}
+	}
+
+	if len(history) != 6 {
+		t.Fatal("expected the history to be unmodified")
+	}
+
+	// Messages that already fit are returned as-is.
+	budget.ContextWindow = 8192
+	if got, err := budget.Fit(history); err != nil || len(got) != len(history) {
+		t.Fatalf("expected all messages to fit, got %d: %v", len(got), err)
+	}
+}
+
+func TestContextBudget_FitTrimsNewestMessage(t *testing.T) {
+	text := strings.Repeat("word ", 100) + "question?"
+
+	budget := openai.ContextBudget{Model: openai.ModelGPT4, ContextWindow: 50}
+
+	got, err := budget.Fit([]openai.ChatMessage{openai.System("Be brief."), openai.User(text)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || !strings.HasSuffix(got[1].Content, "question?") || len(got[1].Content) >= len(text) {
+		t.Fatalf("expected the start of the newest message to be trimmed: %#+v", got)
+	}
+
+	n, err := openai.CountChatTokens(openai.ModelGPT4, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n > 50 {
+		t.Fatalf("expected at most 50 tokens, got %d", n)
+	}
+
+	budget.ContextWindow = 5
+	if _, err := budget.Fit(got); !errors.Is(err, openai.ErrContextWindowExceeded) {
+		t.Fatalf("expected ErrContextWindowExceeded, got %v", err)
+	}
+}
+
+func TestContextWindow(t *testing.T) {
+	for model, want := range map[string]int{
+		"gpt-4":             8192,
+		"gpt-4-32k":         32768,
+		"gpt-4o-mini":       128000,
+		"ft:gpt-4o:org::id": 128000,
+	} {
+		if got, ok := openai.ContextWindow(model); !ok || got != want {
+			t.Errorf("ContextWindow(%q) = %d, %v, want %d", model, got, ok, want)
+		}
+	}
+
+	if _, ok := openai.ContextWindow("davinci"); ok {
+		t.Error("expected unknown context window for davinci")
+	}
+}
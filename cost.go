@@ -0,0 +1,98 @@
+package openai
+
+import "strings"
+
+// ModelPricing is the price of a model's tokens, in US dollars per million tokens.
+//
+// https://openai.com/api/pricing
+type ModelPricing struct {
+	Input  float64
+	Output float64
+}
+
+// modelPricing maps model name prefixes to their standard pricing, checked in
+// order, so more specific prefixes come first.
+var modelPricing = []struct {
+	prefix  string
+	pricing ModelPricing
+}{
+	{"gpt-5-nano", ModelPricing{Input: 0.05, Output: 0.40}},
+	{"gpt-5-mini", ModelPricing{Input: 0.25, Output: 2.00}},
+	{"gpt-5", ModelPricing{Input: 1.25, Output: 10.00}},
+	{"gpt-4.1-nano", ModelPricing{Input: 0.10, Output: 0.40}},
+	{"gpt-4.1-mini", ModelPricing{Input: 0.40, Output: 1.60}},
+	{"gpt-4.1", ModelPricing{Input: 2.00, Output: 8.00}},
+	{"gpt-4.5", ModelPricing{Input: 75.00, Output: 150.00}},
+	{"gpt-4o-mini", ModelPricing{Input: 0.15, Output: 0.60}},
+	{"gpt-4o-2024-05-13", ModelPricing{Input: 5.00, Output: 15.00}},
+	{"gpt-4o", ModelPricing{Input: 2.50, Output: 10.00}},
+	{"chatgpt-4o", ModelPricing{Input: 5.00, Output: 15.00}},
+	{"o1-mini", ModelPricing{Input: 1.10, Output: 4.40}},
+	{"o1-pro", ModelPricing{Input: 150.00, Output: 600.00}},
+	{"o1", ModelPricing{Input: 15.00, Output: 60.00}},
+	{"o3-mini", ModelPricing{Input: 1.10, Output: 4.40}},
+	{"o3-pro", ModelPricing{Input: 20.00, Output: 80.00}},
+	{"o3", ModelPricing{Input: 2.00, Output: 8.00}},
+	{"o4-mini", ModelPricing{Input: 1.10, Output: 4.40}},
+	{"gpt-4-turbo", ModelPricing{Input: 10.00, Output: 30.00}},
+	{"gpt-4-1106", ModelPricing{Input: 10.00, Output: 30.00}},
+	{"gpt-4-0125", ModelPricing{Input: 10.00, Output: 30.00}},
+	{"gpt-4-vision", ModelPricing{Input: 10.00, Output: 30.00}},
+	{"gpt-4-32k", ModelPricing{Input: 60.00, Output: 120.00}},
+	{"gpt-4", ModelPricing{Input: 30.00, Output: 60.00}},
+	{"gpt-3.5-turbo-instruct", ModelPricing{Input: 1.50, Output: 2.00}},
+	{"gpt-3.5-turbo", ModelPricing{Input: 0.50, Output: 1.50}},
+	{"text-embedding-3-small", ModelPricing{Input: 0.02}},
+	{"text-embedding-3-large", ModelPricing{Input: 0.13}},
+	{"text-embedding-ada-002", ModelPricing{Input: 0.10}},
+}
+
+// Pricing returns the standard pricing of the given model. It returns false
+// for models whose pricing isn't known, including fine-tuned models, which are
+// priced per training job.
+func Pricing(model string) (ModelPricing, bool) {
+	for _, p := range modelPricing {
+		if strings.HasPrefix(model, p.prefix) {
+			return p.pricing, true
+		}
+	}
+
+	return ModelPricing{}, false
+}
+
+// Cost returns the price in US dollars of the given numbers of input and output tokens.
+func (p ModelPricing) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*p.Input + float64(outputTokens)*p.Output) / 1e6
+}
+
+// EstimateCost returns the estimated price in US dollars of a chat completion's
+// token usage with the given model, at standard pricing, without discounts for
+// cached or batched tokens. It returns false if the model's pricing isn't known.
+//
+// # Example
+//
+//	resp, _ := client.CreateChat(ctx, req)
+//
+//	if cost, ok := openai.EstimateCost(resp.Usage, resp.Model); ok {
+//		fmt.Printf("$%.4f\n", cost)
+//	}
+func EstimateCost(usage ChatUsage, model string) (float64, bool) {
+	p, ok := Pricing(model)
+	if !ok {
+		return 0, false
+	}
+
+	return p.Cost(usage.PromptTokens, usage.CompletionTokens), true
+}
+
+// Cost returns the estimated price in US dollars of the usage with the given
+// model. See EstimateCost.
+func (u ChatUsage) Cost(model string) (float64, bool) {
+	return EstimateCost(u, model)
+}
+
+// Cost returns the estimated price in US dollars of the usage with the given
+// model. See EstimateCost.
+func (u ResponseUsage) Cost(model string) (float64, bool) {
+	return EstimateCost(ChatUsage{PromptTokens: u.InputTokens, CompletionTokens: u.OutputTokens}, model)
+}
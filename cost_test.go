@@ -0,0 +1,38 @@
+package openai_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestEstimateCost(t *testing.T) {
+	tests := []struct {
+		model string
+		usage openai.ChatUsage
+		want  float64
+		ok    bool
+	}{
+		{"gpt-4o-mini-2024-07-18", openai.ChatUsage{PromptTokens: 1_000_000, CompletionTokens: 1_000_000}, 0.75, true},
+		{"gpt-4o", openai.ChatUsage{PromptTokens: 1000, CompletionTokens: 500}, 0.0075, true},
+		{"gpt-4-32k-0613", openai.ChatUsage{PromptTokens: 1000}, 0.06, true},
+		{"gpt-4-0613", openai.ChatUsage{PromptTokens: 1000}, 0.03, true},
+		{"ft:gpt-4o-mini:org::id", openai.ChatUsage{PromptTokens: 1000}, 0, false},
+		{"unknown", openai.ChatUsage{PromptTokens: 1000}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, ok := tt.usage.Cost(tt.model)
+			if ok != tt.ok || math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("got %v, %v; want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	got, ok := openai.ResponseUsage{InputTokens: 1000, OutputTokens: 1000}.Cost("o3")
+	if !ok || math.Abs(got-0.01) > 1e-9 {
+		t.Fatalf("unexpected response usage cost: %v, %v", got, ok)
+	}
+}
@@ -0,0 +1,237 @@
+package embeddings
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Record is an embedding stored in a VectorIndex.
+type Record struct {
+	// ID uniquely identifies the record in the index.
+	ID string `json:"id"`
+
+	// Embedding is the embedding of the record.
+	Embedding []float32 `json:"embedding"`
+
+	// Metadata is arbitrary data associated with the record, such as the text
+	// that was embedded or the document it came from.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Result is a record returned by VectorIndex.Query.
+type Result struct {
+	Record
+
+	// Score is the cosine similarity of the record to the query.
+	Score float64
+}
+
+// Filter reports whether a record should be considered by a query, such as by
+// checking its metadata.
+type Filter func(r *Record) bool
+
+// VectorIndex is an in-memory index of embeddings, queried by exhaustive cosine
+// similarity search. It is suitable for up to hundreds of thousands of records,
+// and is safe for concurrent use.
+//
+// # Example
+//
+//	index := embeddings.NewVectorIndex()
+//
+//	for i, d := range resp.Data {
+//		index.Add(ids[i], d.Float32(), map[string]string{"text": texts[i]})
+//	}
+//
+//	results, _ := index.Query(query, 3, nil)
+type VectorIndex struct {
+	mu sync.RWMutex
+
+	// records are the records in the index, and norms their magnitudes.
+	records []*Record
+	norms   []float64
+
+	// ids maps the ID of each record to its position in records.
+	ids map[string]int
+
+	// dims is the number of dimensions of the embeddings in the index, set by
+	// the first record added.
+	dims int
+}
+
+// NewVectorIndex returns an empty VectorIndex.
+func NewVectorIndex() *VectorIndex {
+	return &VectorIndex{ids: map[string]int{}}
+}
+
+// Len returns the number of records in the index.
+func (x *VectorIndex) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return len(x.records)
+}
+
+// Add adds a record to the index, replacing any record with the same ID. All
+// embeddings in the index must have the same number of dimensions. The index
+// retains the embedding and metadata, which must not be modified afterwards.
+func (x *VectorIndex) Add(id string, embedding []float32, metadata map[string]string) error {
+	if id == "" {
+		return errors.New("record ID is empty")
+	}
+
+	if len(embedding) == 0 {
+		return errors.New("embedding is empty")
+	}
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	if len(x.records) == 0 {
+		x.dims = len(embedding)
+	} else if len(embedding) != x.dims {
+		return fmt.Errorf("embedding has %d dimensions, but the index has %d", len(embedding), x.dims)
+	}
+
+	r := &Record{ID: id, Embedding: embedding, Metadata: metadata}
+
+	if i, ok := x.ids[id]; ok {
+		x.records[i] = r
+		x.norms[i] = magnitude(embedding)
+		return nil
+	}
+
+	x.ids[id] = len(x.records)
+	x.records = append(x.records, r)
+	x.norms = append(x.norms, magnitude(embedding))
+
+	return nil
+}
+
+// Get returns the record with the given ID.
+func (x *VectorIndex) Get(id string) (Record, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	i, ok := x.ids[id]
+	if !ok {
+		return Record{}, false
+	}
+
+	return *x.records[i], true
+}
+
+// Delete removes the record with the given ID, reporting whether it existed.
+func (x *VectorIndex) Delete(id string) bool {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	i, ok := x.ids[id]
+	if !ok {
+		return false
+	}
+
+	// Move the last record into the deleted record's place.
+	last := len(x.records) - 1
+	if i != last {
+		x.records[i], x.norms[i] = x.records[last], x.norms[last]
+		x.ids[x.records[i].ID] = i
+	}
+
+	x.records[last] = nil
+	x.records, x.norms = x.records[:last], x.norms[:last]
+	delete(x.ids, id)
+
+	return true
+}
+
+// Query returns the k records most similar to the given embedding, most similar
+// first, considering only records matching the filter if it isn't nil.
+func (x *VectorIndex) Query(embedding []float32, k int, filter Filter) ([]Result, error) {
+	qmag := magnitude(embedding)
+	if qmag == 0 {
+		return nil, errors.New("query embedding is empty or has a magnitude of zero")
+	}
+
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	if len(x.records) > 0 && len(embedding) != x.dims {
+		return nil, fmt.Errorf("query embedding has %d dimensions, but the index has %d", len(embedding), x.dims)
+	}
+
+	if k <= 0 {
+		return nil, nil
+	}
+
+	top := make(matches, 0, min(k, len(x.records))+1)
+
+	for i, r := range x.records {
+		if x.norms[i] == 0 || (filter != nil && !filter(r)) {
+			continue
+		}
+
+		dot, _ := DotProduct(embedding, r.Embedding)
+		score := dot / (qmag * x.norms[i])
+
+		if len(top) < k {
+			heap.Push(&top, Match{Index: i, Score: score})
+		} else if score > top[0].Score {
+			top[0] = Match{Index: i, Score: score}
+			heap.Fix(&top, 0)
+		}
+	}
+
+	results := make([]Result, len(top))
+	for i := len(results) - 1; i >= 0; i-- {
+		m := heap.Pop(&top).(Match)
+		results[i] = Result{Record: *x.records[m.Index], Score: m.Score}
+	}
+
+	return results, nil
+}
+
+// Save writes the records in the index to w as JSON Lines, one record per line,
+// which can be read back with LoadVectorIndex.
+func (x *VectorIndex) Save(w io.Writer) error {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	for _, r := range x.records {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// LoadVectorIndex reads an index written by VectorIndex.Save.
+func LoadVectorIndex(r io.Reader) (*VectorIndex, error) {
+	x := NewVectorIndex()
+
+	dec := json.NewDecoder(r)
+
+	for line := 1; ; line++ {
+		var rec Record
+
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			return x, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode record %d: %w", line, err)
+		}
+
+		if err := x.Add(rec.ID, rec.Embedding, rec.Metadata); err != nil {
+			return nil, fmt.Errorf("invalid record %d: %w", line, err)
+		}
+	}
+}
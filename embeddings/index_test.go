@@ -0,0 +1,120 @@
+package embeddings
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestVectorIndex(t *testing.T) {
+	x := NewVectorIndex()
+
+	records := []Record{
+		{ID: "a", Embedding: []float32{1, 0}, Metadata: map[string]string{"lang": "en"}},
+		{ID: "b", Embedding: []float32{1, 1}, Metadata: map[string]string{"lang": "fr"}},
+		{ID: "c", Embedding: []float32{0, 1}, Metadata: map[string]string{"lang": "en"}},
+		{ID: "d", Embedding: []float32{-1, 0}, Metadata: map[string]string{"lang": "en"}},
+	}
+
+	for _, r := range records {
+		if err := x.Add(r.ID, r.Embedding, r.Metadata); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := x.Add("e", []float32{1, 2, 3}, nil); err == nil {
+		t.Fatal("expected error for embedding with the wrong dimensions")
+	}
+
+	ids := func(results []Result) []string {
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	t.Run("query", func(t *testing.T) {
+		results, err := x.Query([]float32{1, 0.1}, 2, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := ids(results); !slices.Equal(got, []string{"a", "b"}) {
+			t.Fatalf("unexpected results: %v", got)
+		}
+
+		if results[0].Metadata["lang"] != "en" || results[0].Score <= results[1].Score {
+			t.Fatalf("unexpected result: %+v", results[0])
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		results, err := x.Query([]float32{1, 1}, 2, func(r *Record) bool {
+			return r.Metadata["lang"] == "en"
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := ids(results); len(got) != 2 || got[0] == "b" || got[1] == "b" {
+			t.Fatalf("unexpected results: %v", got)
+		}
+	})
+
+	t.Run("replace and delete", func(t *testing.T) {
+		if err := x.Add("d", []float32{0.9, 0.1}, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if !x.Delete("a") || x.Delete("a") {
+			t.Fatal("expected only the first delete to succeed")
+		}
+
+		if x.Len() != 3 {
+			t.Fatalf("expected 3 records, got %d", x.Len())
+		}
+
+		if _, ok := x.Get("a"); ok {
+			t.Fatal("expected deleted record to be gone")
+		}
+
+		results, err := x.Query([]float32{1, 0}, 1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := ids(results); !slices.Equal(got, []string{"d"}) {
+			t.Fatalf("unexpected results: %v", got)
+		}
+	})
+
+	t.Run("save and load", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := x.Save(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		y, err := LoadVectorIndex(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if y.Len() != x.Len() {
+			t.Fatalf("expected %d records, got %d", x.Len(), y.Len())
+		}
+
+		r, ok := y.Get("b")
+		if !ok || r.Metadata["lang"] != "fr" || !slices.Equal(r.Embedding, []float32{1, 1}) {
+			t.Fatalf("unexpected record: %+v", r)
+		}
+	})
+}
+
+func TestLoadVectorIndex_Invalid(t *testing.T) {
+	input := `{"id":"a","embedding":[1,0]}` + "\n" + `{"id":"b","embedding":[1,0,0]}` + "\n"
+
+	if _, err := LoadVectorIndex(bytes.NewBufferString(input)); err == nil {
+		t.Fatal("expected error for mismatched dimensions")
+	}
+}
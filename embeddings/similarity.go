@@ -10,7 +10,7 @@ import (
 // CosineSimilarity calculates the cosine similarity between two embeddings.
 //
 // https://en.wikipedia.org/wiki/Cosine_similarity
-func CosineSimilarity(a, b []float64) (float64, error) {
+func CosineSimilarity[T Float](a, b []T) (float64, error) {
 	var dotProduct, magnitude1, magnitude2 float64
 
 	if len(a) == 0 || len(b) == 0 {
@@ -22,8 +22,8 @@ func CosineSimilarity(a, b []float64) (float64, error) {
 	}
 
 	for i := 0; i < len(a); i++ {
-		value1 := a[i]
-		value2 := b[i]
+		value1 := float64(a[i])
+		value2 := float64(b[i])
 		dotProduct += value1 * value2
 		magnitude1 += value1 * value1
 		magnitude2 += value2 * value2
@@ -1,4 +1,4 @@
-package embeddings
+package embeddings_test
 
 import (
 	"context"
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/picatz/openai"
+	"github.com/picatz/openai/embeddings"
 )
 
 func ptrFloat64(f float64) *float64 {
@@ -19,7 +20,7 @@ func ptrFloat64(f float64) *float64 {
 
 func TestCosignSimilariy(t *testing.T) {
 	t.Run("return 1.0 for identical embeddings", func(t *testing.T) {
-		sim, err := CosineSimilarity([]float64{1, 2, 3}, []float64{1, 2, 3})
+		sim, err := embeddings.CosineSimilarity([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -30,7 +31,7 @@ func TestCosignSimilariy(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for orthogonal embeddings", func(t *testing.T) {
-		sim, err := CosineSimilarity([]float64{1, 0, 0}, []float64{0, 1, 0})
+		sim, err := embeddings.CosineSimilarity([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -41,7 +42,7 @@ func TestCosignSimilariy(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for zero embeddings", func(t *testing.T) {
-		sim, err := CosineSimilarity([]float64{0, 0, 0}, []float64{0, 0, 0})
+		sim, err := embeddings.CosineSimilarity([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -99,7 +100,7 @@ func TestCosignSimilariy(t *testing.T) {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				got, err := CosineSimilarity(tt.a, tt.b)
+				got, err := embeddings.CosineSimilarity(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -197,7 +198,7 @@ func TestCosignSimilariy(t *testing.T) {
 						continue
 					}
 
-					sim, err := CosineSimilarity(thingToEmbedding[thing], thingToEmbedding[otherThing])
+					sim, err := embeddings.CosineSimilarity(thingToEmbedding[thing], thingToEmbedding[otherThing])
 					if err != nil {
 						t.Fatalf("error: %v", err)
 					}
@@ -244,14 +245,14 @@ func TestCosignSimilariy(t *testing.T) {
 
 func TestEuclideanDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := EuclideanDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.EuclideanDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := EuclideanDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.EuclideanDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -262,7 +263,7 @@ func TestEuclideanDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for zero embeddings", func(t *testing.T) {
-		dist, err := EuclideanDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.EuclideanDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -273,7 +274,7 @@ func TestEuclideanDistance(t *testing.T) {
 	})
 
 	t.Run("orthogonal embeddings", func(t *testing.T) {
-		dist, err := EuclideanDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.EuclideanDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -284,7 +285,7 @@ func TestEuclideanDistance(t *testing.T) {
 	})
 
 	t.Run("other embeddings", func(t *testing.T) {
-		dist, err := EuclideanDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.EuclideanDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -357,7 +358,7 @@ func TestEuclideanDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := EuclideanDistance(tt.a, tt.b)
+				got, err := embeddings.EuclideanDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -377,14 +378,14 @@ func TestEuclideanDistance(t *testing.T) {
 
 func TestHammingDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := HammingDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.HammingDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := HammingDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.HammingDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -397,7 +398,7 @@ func TestHammingDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for zero embeddings", func(t *testing.T) {
-		dist, err := HammingDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.HammingDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -410,7 +411,7 @@ func TestHammingDistance(t *testing.T) {
 	})
 
 	t.Run("return 2.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := HammingDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.HammingDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -423,7 +424,7 @@ func TestHammingDistance(t *testing.T) {
 	})
 
 	t.Run("return 3.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := HammingDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.HammingDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -440,14 +441,14 @@ func TestHammingDistance(t *testing.T) {
 
 func TestManhattanDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := ManhattanDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.ManhattanDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := ManhattanDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.ManhattanDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -460,7 +461,7 @@ func TestManhattanDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for zero embeddings", func(t *testing.T) {
-		dist, err := ManhattanDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.ManhattanDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -473,7 +474,7 @@ func TestManhattanDistance(t *testing.T) {
 	})
 
 	t.Run("return -0.5 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := PearsonCorrelationCoefficient([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.PearsonCorrelationCoefficient([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -486,7 +487,7 @@ func TestManhattanDistance(t *testing.T) {
 	})
 
 	t.Run("return -1.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := PearsonCorrelationCoefficient([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.PearsonCorrelationCoefficient([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -580,7 +581,7 @@ func TestManhattanDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := ManhattanDistance(tt.a, tt.b)
+				got, err := embeddings.ManhattanDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -599,14 +600,14 @@ func TestManhattanDistance(t *testing.T) {
 
 func TestPearsonCorrelationCoefficient(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := PearsonCorrelationCoefficient([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.PearsonCorrelationCoefficient([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 1.0 for identical embeddings", func(t *testing.T) {
-		dist, err := PearsonCorrelationCoefficient([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.PearsonCorrelationCoefficient([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -617,7 +618,7 @@ func TestPearsonCorrelationCoefficient(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := PearsonCorrelationCoefficient([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.PearsonCorrelationCoefficient([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -630,7 +631,7 @@ func TestPearsonCorrelationCoefficient(t *testing.T) {
 	})
 
 	t.Run("return -0.5 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := PearsonCorrelationCoefficient([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.PearsonCorrelationCoefficient([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -641,7 +642,7 @@ func TestPearsonCorrelationCoefficient(t *testing.T) {
 	})
 
 	t.Run("return -1.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := PearsonCorrelationCoefficient([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.PearsonCorrelationCoefficient([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -735,7 +736,7 @@ func TestPearsonCorrelationCoefficient(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := PearsonCorrelationCoefficient(tt.a, tt.b)
+				got, err := embeddings.PearsonCorrelationCoefficient(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -754,14 +755,14 @@ func TestPearsonCorrelationCoefficient(t *testing.T) {
 
 func TestSpearmanRankCorrelationCoefficient(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := SpearmanRankCorrelationCoefficient([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.SpearmanRankCorrelationCoefficient([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return NaN for identical embeddings", func(t *testing.T) {
-		dist, err := SpearmanRankCorrelationCoefficient([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.SpearmanRankCorrelationCoefficient([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -774,7 +775,7 @@ func TestSpearmanRankCorrelationCoefficient(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := SpearmanRankCorrelationCoefficient([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.SpearmanRankCorrelationCoefficient([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -787,7 +788,7 @@ func TestSpearmanRankCorrelationCoefficient(t *testing.T) {
 	})
 
 	t.Run("return -0.5 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := SpearmanRankCorrelationCoefficient([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.SpearmanRankCorrelationCoefficient([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -798,7 +799,7 @@ func TestSpearmanRankCorrelationCoefficient(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := SpearmanRankCorrelationCoefficient([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.SpearmanRankCorrelationCoefficient([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -892,7 +893,7 @@ func TestSpearmanRankCorrelationCoefficient(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := SpearmanRankCorrelationCoefficient(tt.a, tt.b)
+				got, err := embeddings.SpearmanRankCorrelationCoefficient(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -928,14 +929,14 @@ func TestSpearmanRankCorrelationCoefficient(t *testing.T) {
 
 func TestJaquardSimilarity(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := JaquardSimilarity([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.JaquardSimilarity([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 1.0 for identical embeddings", func(t *testing.T) {
-		dist, err := JaquardSimilarity([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.JaquardSimilarity([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -948,7 +949,7 @@ func TestJaquardSimilarity(t *testing.T) {
 	})
 
 	t.Run("return +Inf for zero embeddings", func(t *testing.T) {
-		dist, err := JaquardSimilarity([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.JaquardSimilarity([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -961,7 +962,7 @@ func TestJaquardSimilarity(t *testing.T) {
 	})
 
 	t.Run("return 0.5 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := JaquardSimilarity([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.JaquardSimilarity([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -974,7 +975,7 @@ func TestJaquardSimilarity(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := JaquardSimilarity([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.JaquardSimilarity([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -991,14 +992,14 @@ func TestJaquardSimilarity(t *testing.T) {
 
 func TestBrayCurtisDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := BrayCurtisDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.BrayCurtisDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := BrayCurtisDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.BrayCurtisDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1011,7 +1012,7 @@ func TestBrayCurtisDistance(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := BrayCurtisDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.BrayCurtisDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1024,7 +1025,7 @@ func TestBrayCurtisDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := BrayCurtisDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.BrayCurtisDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1037,7 +1038,7 @@ func TestBrayCurtisDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := BrayCurtisDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.BrayCurtisDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1131,7 +1132,7 @@ func TestBrayCurtisDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := BrayCurtisDistance(tt.a, tt.b)
+				got, err := embeddings.BrayCurtisDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -1173,7 +1174,7 @@ func TestMahalanobisDistance(t *testing.T) {
 			{1, 2, 3},
 		}
 
-		_, err := MahalanobisDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4}, covarianceMatrix)
+		_, err := embeddings.MahalanobisDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4}, covarianceMatrix)
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -1186,7 +1187,7 @@ func TestMahalanobisDistance(t *testing.T) {
 			// covariance matrix must be square and have the same dimensions as the embeddings
 		}
 
-		_, err := MahalanobisDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4}, covarianceMatrix)
+		_, err := embeddings.MahalanobisDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4}, covarianceMatrix)
 		if err == nil {
 			t.Fatal("expected error")
 		}
@@ -1199,7 +1200,7 @@ func TestMahalanobisDistance(t *testing.T) {
 			{1, 2, 3},
 		}
 
-		dist, err := MahalanobisDistance([]float64{1, 2, 3}, []float64{1, 2, 3}, covarianceMatrix)
+		dist, err := embeddings.MahalanobisDistance([]float64{1, 2, 3}, []float64{1, 2, 3}, covarianceMatrix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1218,7 +1219,7 @@ func TestMahalanobisDistance(t *testing.T) {
 			{1, 2, 3},
 		}
 
-		dist, err := MahalanobisDistance([]float64{0, 0, 0}, []float64{0, 0, 0}, covarianceMatrix)
+		dist, err := embeddings.MahalanobisDistance([]float64{0, 0, 0}, []float64{0, 0, 0}, covarianceMatrix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1237,7 +1238,7 @@ func TestMahalanobisDistance(t *testing.T) {
 			{1, 2, 3},
 		}
 
-		dist, err := MahalanobisDistance([]float64{1, 0, 0}, []float64{0, 1, 0}, covarianceMatrix)
+		dist, err := embeddings.MahalanobisDistance([]float64{1, 0, 0}, []float64{0, 1, 0}, covarianceMatrix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1256,7 +1257,7 @@ func TestMahalanobisDistance(t *testing.T) {
 			{1, 2, 3},
 		}
 
-		dist, err := MahalanobisDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0}, covarianceMatrix)
+		dist, err := embeddings.MahalanobisDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0}, covarianceMatrix)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1372,7 +1373,7 @@ func TestMahalanobisDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := MahalanobisDistance(tt.a, tt.b, covarianceMatrix)
+				got, err := embeddings.MahalanobisDistance(tt.a, tt.b, covarianceMatrix)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -1516,7 +1517,7 @@ func TestMahalanobisDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := MahalanobisDistance(tt.a, tt.b, covarianceMatrix)
+				got, err := embeddings.MahalanobisDistance(tt.a, tt.b, covarianceMatrix)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -1564,14 +1565,14 @@ func TestBhattacharyyaDistance(t *testing.T) {
 
 func TestWassersteinDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := WassersteinDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.WassersteinDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := WassersteinDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.WassersteinDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1584,7 +1585,7 @@ func TestWassersteinDistance(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := WassersteinDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.WassersteinDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1597,7 +1598,7 @@ func TestWassersteinDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := WassersteinDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.WassersteinDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1610,7 +1611,7 @@ func TestWassersteinDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.5 for opposite embeddings", func(t *testing.T) {
-		dist, err := WassersteinDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.WassersteinDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1704,7 +1705,7 @@ func TestWassersteinDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := WassersteinDistance(tt.a, tt.b)
+				got, err := embeddings.WassersteinDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -1740,14 +1741,14 @@ func TestWassersteinDistance(t *testing.T) {
 
 func TestAngularDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := AngularDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.AngularDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := AngularDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.AngularDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1760,7 +1761,7 @@ func TestAngularDistance(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := AngularDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.AngularDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1773,7 +1774,7 @@ func TestAngularDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := AngularDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.AngularDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1786,7 +1787,7 @@ func TestAngularDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.5 for opposite embeddings", func(t *testing.T) {
-		dist, err := AngularDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.AngularDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1880,7 +1881,7 @@ func TestAngularDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := AngularDistance(tt.a, tt.b)
+				got, err := embeddings.AngularDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -1916,14 +1917,14 @@ func TestAngularDistance(t *testing.T) {
 
 func TestCorrelationDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := CorrelationDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.CorrelationDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := CorrelationDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.CorrelationDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1936,7 +1937,7 @@ func TestCorrelationDistance(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := CorrelationDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.CorrelationDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1949,7 +1950,7 @@ func TestCorrelationDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := CorrelationDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.CorrelationDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1962,7 +1963,7 @@ func TestCorrelationDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := CorrelationDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.CorrelationDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2056,7 +2057,7 @@ func TestCorrelationDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := CorrelationDistance(tt.a, tt.b)
+				got, err := embeddings.CorrelationDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -2092,14 +2093,14 @@ func TestCorrelationDistance(t *testing.T) {
 
 func TestPairwiseDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := PairwiseDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.PairwiseDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := PairwiseDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.PairwiseDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2112,7 +2113,7 @@ func TestPairwiseDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for zero embeddings", func(t *testing.T) {
-		dist, err := PairwiseDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.PairwiseDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2125,7 +2126,7 @@ func TestPairwiseDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.4 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := PairwiseDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.PairwiseDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2138,7 +2139,7 @@ func TestPairwiseDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.9 for opposite embeddings", func(t *testing.T) {
-		dist, err := PairwiseDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.PairwiseDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2232,7 +2233,7 @@ func TestPairwiseDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := PairwiseDistance(tt.a, tt.b)
+				got, err := embeddings.PairwiseDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -2278,14 +2279,14 @@ func TestTanimotoDistance(t *testing.T) {
 
 func TestChebyshevDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := ChebyshevDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.ChebyshevDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := ChebyshevDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.ChebyshevDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2298,7 +2299,7 @@ func TestChebyshevDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for zero embeddings", func(t *testing.T) {
-		dist, err := ChebyshevDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.ChebyshevDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2311,7 +2312,7 @@ func TestChebyshevDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := ChebyshevDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.ChebyshevDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2324,7 +2325,7 @@ func TestChebyshevDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.5 for opposite embeddings", func(t *testing.T) {
-		dist, err := ChebyshevDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.ChebyshevDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2418,7 +2419,7 @@ func TestChebyshevDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := ChebyshevDistance(tt.a, tt.b)
+				got, err := embeddings.ChebyshevDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -2454,14 +2455,14 @@ func TestChebyshevDistance(t *testing.T) {
 
 func TestRuzickaDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := RuzickaDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.RuzickaDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 6.0 for identical embeddings", func(t *testing.T) {
-		dist, err := RuzickaDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.RuzickaDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2474,7 +2475,7 @@ func TestRuzickaDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for zero embeddings", func(t *testing.T) {
-		dist, err := RuzickaDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.RuzickaDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2487,7 +2488,7 @@ func TestRuzickaDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := RuzickaDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.RuzickaDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2500,7 +2501,7 @@ func TestRuzickaDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := RuzickaDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.RuzickaDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2594,7 +2595,7 @@ func TestRuzickaDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := RuzickaDistance(tt.a, tt.b)
+				got, err := embeddings.RuzickaDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -2630,14 +2631,14 @@ func TestRuzickaDistance(t *testing.T) {
 
 func TestWaveHedgesDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := WaveHedgesDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.WaveHedgesDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := WaveHedgesDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.WaveHedgesDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2650,7 +2651,7 @@ func TestWaveHedgesDistance(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := WaveHedgesDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.WaveHedgesDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2663,7 +2664,7 @@ func TestWaveHedgesDistance(t *testing.T) {
 	})
 
 	t.Run("return 2.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := WaveHedgesDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.WaveHedgesDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2676,7 +2677,7 @@ func TestWaveHedgesDistance(t *testing.T) {
 	})
 
 	t.Run("return 3.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := WaveHedgesDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.WaveHedgesDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2770,7 +2771,7 @@ func TestWaveHedgesDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := WaveHedgesDistance(tt.a, tt.b)
+				got, err := embeddings.WaveHedgesDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -2806,14 +2807,14 @@ func TestWaveHedgesDistance(t *testing.T) {
 
 func TestClarkDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := ClarkDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.ClarkDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := ClarkDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.ClarkDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2826,7 +2827,7 @@ func TestClarkDistance(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := ClarkDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.ClarkDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2839,7 +2840,7 @@ func TestClarkDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := ClarkDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.ClarkDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2852,7 +2853,7 @@ func TestClarkDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.5 for opposite embeddings", func(t *testing.T) {
-		dist, err := ClarkDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.ClarkDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -2946,7 +2947,7 @@ func TestClarkDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := ClarkDistance(tt.a, tt.b)
+				got, err := embeddings.ClarkDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -2982,14 +2983,14 @@ func TestClarkDistance(t *testing.T) {
 
 func TestMotykaSimpsonDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := MotykaSimpsonDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.MotykaSimpsonDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := MotykaSimpsonDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.MotykaSimpsonDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3002,7 +3003,7 @@ func TestMotykaSimpsonDistance(t *testing.T) {
 	})
 
 	t.Run("return NaN for zero embeddings", func(t *testing.T) {
-		dist, err := MotykaSimpsonDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.MotykaSimpsonDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3015,7 +3016,7 @@ func TestMotykaSimpsonDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := MotykaSimpsonDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.MotykaSimpsonDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3028,7 +3029,7 @@ func TestMotykaSimpsonDistance(t *testing.T) {
 	})
 
 	t.Run("return 1.0 for opposite embeddings", func(t *testing.T) {
-		dist, err := MotykaSimpsonDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.MotykaSimpsonDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3122,7 +3123,7 @@ func TestMotykaSimpsonDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := MotykaSimpsonDistance(tt.a, tt.b)
+				got, err := embeddings.MotykaSimpsonDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
@@ -3158,14 +3159,14 @@ func TestMotykaSimpsonDistance(t *testing.T) {
 
 func TestLorentzianDistance(t *testing.T) {
 	t.Run("return error for unequal length embeddings", func(t *testing.T) {
-		_, err := LorentzianDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
+		_, err := embeddings.LorentzianDistance([]float64{1, 2, 3}, []float64{1, 2, 3, 4})
 		if err == nil {
 			t.Fatal("expected error")
 		}
 	})
 
 	t.Run("return 0.0 for identical embeddings", func(t *testing.T) {
-		dist, err := LorentzianDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
+		dist, err := embeddings.LorentzianDistance([]float64{1, 2, 3}, []float64{1, 2, 3})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3178,7 +3179,7 @@ func TestLorentzianDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.0 for zero embeddings", func(t *testing.T) {
-		dist, err := LorentzianDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
+		dist, err := embeddings.LorentzianDistance([]float64{0, 0, 0}, []float64{0, 0, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3191,7 +3192,7 @@ func TestLorentzianDistance(t *testing.T) {
 	})
 
 	t.Run("return 2.0 for orthogonal embeddings", func(t *testing.T) {
-		dist, err := LorentzianDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
+		dist, err := embeddings.LorentzianDistance([]float64{1, 0, 0}, []float64{0, 1, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3204,7 +3205,7 @@ func TestLorentzianDistance(t *testing.T) {
 	})
 
 	t.Run("return 0.5 for opposite embeddings", func(t *testing.T) {
-		dist, err := LorentzianDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
+		dist, err := embeddings.LorentzianDistance([]float64{0, 0, 0.5}, []float64{0.5, 0.5, 0})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3298,7 +3299,7 @@ func TestLorentzianDistance(t *testing.T) {
 
 		for ti, tt := range tests {
 			t.Run(fmt.Sprintf("%d", ti), func(t *testing.T) {
-				got, err := LorentzianDistance(tt.a, tt.b)
+				got, err := embeddings.LorentzianDistance(tt.a, tt.b)
 				if err != nil {
 					t.Fatalf("error: %v", err)
 				}
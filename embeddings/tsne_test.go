@@ -1,4 +1,4 @@
-package embeddings
+package embeddings_test
 
 import (
 	"context"
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/picatz/openai"
+	"github.com/picatz/openai/embeddings"
 )
 
 func TestTSNEVisualizePNG(t *testing.T) {
@@ -35,7 +36,7 @@ func TestTSNEVisualizePNG(t *testing.T) {
 	b := getEmbedding(t, "DOLPHINS")
 	c := getEmbedding(t, "BIRDS")
 
-	embeddings := [][]float64{
+	vectors := [][]float64{
 		a, // red
 		b, // green
 		c, // blue
@@ -49,14 +50,14 @@ func TestTSNEVisualizePNG(t *testing.T) {
 		outputDimensions int     = 2 // 2D or 3D space (2 or 3)
 	)
 
-	tSNEDimensions := TSNE(embeddings, perplexity, nIter, outputDimensions)
+	tSNEDimensions := embeddings.TSNE(vectors, perplexity, nIter, outputDimensions)
 
 	for i, dimension := range tSNEDimensions {
 		t.Logf("embedding %d dimensions: %-2.f, %-2.f", i, dimension[0], dimension[1])
 	}
 
 	// We should see close red and green dots, and further away blue dots.
-	img, err := Visualize(tSNEDimensions, 5, 800, 800)
+	img, err := embeddings.Visualize(tSNEDimensions, 5, 800, 800)
 	if err != nil {
 		t.Fatalf("failed to visualize embeddings: %v", err)
 	}
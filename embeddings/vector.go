@@ -0,0 +1,144 @@
+package embeddings
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+)
+
+// Float is the element type of an embedding, either float64 as returned by the
+// API, or float32 for compact storage.
+type Float interface {
+	~float32 | ~float64
+}
+
+// DotProduct calculates the dot product of two embeddings. For normalized
+// embeddings, such as OpenAI's, it is equal to their cosine similarity, and is
+// cheaper to calculate.
+//
+// https://en.wikipedia.org/wiki/Dot_product
+func DotProduct[T Float](a, b []T) (float64, error) {
+	if len(a) != len(b) {
+		return 0, errors.New("embeddings must have equal lengths")
+	}
+
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+
+	return sum, nil
+}
+
+// Normalize returns a copy of the embedding scaled to a magnitude of 1, such as
+// after shortening an embedding by truncating its dimensions. A zero embedding
+// is returned as-is.
+//
+// https://en.wikipedia.org/wiki/Unit_vector
+func Normalize[T Float](v []T) []T {
+	n := make([]T, len(v))
+	copy(n, v)
+
+	mag := magnitude(v)
+	if mag == 0 {
+		return n
+	}
+
+	for i := range n {
+		n[i] = T(float64(n[i]) / mag)
+	}
+
+	return n
+}
+
+// Match is a result of TopK.
+type Match struct {
+	// Index is the index of the matching candidate.
+	Index int
+
+	// Score is the cosine similarity of the candidate to the query.
+	Score float64
+}
+
+// matches is a min-heap of matches by score, used to keep the top k.
+type matches []Match
+
+func (m matches) Len() int           { return len(m) }
+func (m matches) Less(i, j int) bool { return m[i].Score < m[j].Score }
+func (m matches) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m *matches) Push(x any)        { *m = append(*m, x.(Match)) }
+
+func (m *matches) Pop() any {
+	old := *m
+	x := old[len(old)-1]
+	*m = old[:len(old)-1]
+	return x
+}
+
+// TopK returns the k candidates most similar to the query by cosine similarity,
+// most similar first. Candidates with a magnitude of zero are skipped.
+//
+// # Example
+//
+//	matches, err := embeddings.TopK(query, documents, 3)
+//	if err != nil {
+//		return err
+//	}
+//
+//	for _, m := range matches {
+//		fmt.Printf("%.3f %s\n", m.Score, texts[m.Index])
+//	}
+func TopK[T Float](query []T, candidates [][]T, k int) ([]Match, error) {
+	if len(query) == 0 {
+		return nil, errors.New("query embedding is empty")
+	}
+
+	if k <= 0 {
+		return nil, nil
+	}
+
+	qmag := magnitude(query)
+	if qmag == 0 {
+		return nil, errors.New("query embedding magnitude is zero")
+	}
+
+	top := make(matches, 0, min(k, len(candidates))+1)
+
+	for i, c := range candidates {
+		dot, err := DotProduct(query, c)
+		if err != nil {
+			return nil, err
+		}
+
+		cmag := magnitude(c)
+		if cmag == 0 {
+			continue
+		}
+
+		score := dot / (qmag * cmag)
+
+		if len(top) < k {
+			heap.Push(&top, Match{Index: i, Score: score})
+		} else if score > top[0].Score {
+			top[0] = Match{Index: i, Score: score}
+			heap.Fix(&top, 0)
+		}
+	}
+
+	// Pop the matches from least to most similar into place.
+	result := make([]Match, len(top))
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(&top).(Match)
+	}
+
+	return result, nil
+}
+
+// magnitude returns the Euclidean norm of the embedding.
+func magnitude[T Float](v []T) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
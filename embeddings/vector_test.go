@@ -0,0 +1,107 @@
+package embeddings
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDotProduct(t *testing.T) {
+	dot, err := DotProduct([]float64{1, 2, 3}, []float64{4, 5, 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dot != 32 {
+		t.Fatalf("expected dot product to be 32, got %f", dot)
+	}
+
+	dot32, err := DotProduct([]float32{1, 2}, []float32{3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dot32 != 11 {
+		t.Fatalf("expected dot product to be 11, got %f", dot32)
+	}
+
+	if _, err := DotProduct([]float64{1}, []float64{1, 2}); err == nil {
+		t.Fatal("expected error for embeddings of different lengths")
+	}
+}
+
+func TestCosineSimilarity_Float32(t *testing.T) {
+	sim, err := CosineSimilarity([]float32{1, 0}, []float32{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(sim-math.Sqrt2/2) > 1e-6 {
+		t.Fatalf("expected similarity to be %f, got %f", math.Sqrt2/2, sim)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	v := []float32{3, 4}
+
+	n := Normalize(v)
+	if n[0] != 0.6 || n[1] != 0.8 {
+		t.Fatalf("expected [0.6 0.8], got %v", n)
+	}
+
+	if v[0] != 3 {
+		t.Fatal("expected the input to be unmodified")
+	}
+
+	if mag := magnitude(Normalize([]float64{1, 2, 3, 4, 5})); math.Abs(mag-1) > 1e-12 {
+		t.Fatalf("expected magnitude 1, got %f", mag)
+	}
+
+	if z := Normalize([]float64{0, 0}); z[0] != 0 || z[1] != 0 {
+		t.Fatalf("expected zero embedding, got %v", z)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	candidates := [][]float64{
+		{0, 1},    // orthogonal
+		{1, 0.1},  // close
+		{-1, 0},   // opposite
+		{0, 0},    // zero, skipped
+		{1, 0},    // identical
+		{1, 0.5},  // further
+		{0.5, -1}, // far
+	}
+
+	matches, err := TopK([]float64{2, 0}, candidates, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{4, 1, 5}
+	if len(matches) != len(want) {
+		t.Fatalf("expected %d matches, got %d", len(want), len(matches))
+	}
+
+	for i, m := range matches {
+		if m.Index != want[i] {
+			t.Fatalf("expected match %d to be candidate %d, got %d", i, want[i], m.Index)
+		}
+	}
+
+	if matches[0].Score != 1 {
+		t.Fatalf("expected the identical candidate to score 1, got %f", matches[0].Score)
+	}
+
+	all, err := TopK([]float64{1, 0}, candidates, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(all) != len(candidates)-1 {
+		t.Fatalf("expected %d matches, got %d", len(candidates)-1, len(all))
+	}
+
+	if _, err := TopK([]float64{1, 0, 0}, candidates, 1); err == nil {
+		t.Fatal("expected error for embeddings of different lengths")
+	}
+}
@@ -1,4 +1,4 @@
-package embeddings
+package embeddings_test
 
 import (
 	"context"
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/picatz/openai"
+	"github.com/picatz/openai/embeddings"
 )
 
 func TestVisualizePNG(t *testing.T) {
@@ -36,7 +37,7 @@ func TestVisualizePNG(t *testing.T) {
 	f := getEmbedding(t, "In a hole in the ground there lived a hobbit. Not a nasty, dirty, wet hole, filled with the ends of worms and an oozy smell, nor yet a dry, bare, sandy hole with nothing in it to sit down on or to eat: it was a hobbit-hole, and that means comfort.")
 	g := getEmbedding(t, "Fly me to the moon, let me play among the stars, let me see what spring is like on Jupiter and Mars. In other words, hold my hand. In other words, baby, kiss me.")
 
-	embeddings := [][]float64{
+	vectors := [][]float64{
 		// {},
 		e,
 		f,
@@ -47,7 +48,7 @@ func TestVisualizePNG(t *testing.T) {
 		// e,
 	}
 
-	img, err := Visualize(embeddings, 2, 256, 256)
+	img, err := embeddings.Visualize(vectors, 2, 256, 256)
 	if err != nil {
 		t.Fatalf("failed to visualize embeddings: %v", err)
 	}
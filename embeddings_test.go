@@ -0,0 +1,78 @@
+package openai_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestCreateEmbedding_Base64(t *testing.T) {
+	want := []float32{0.5, -0.25, 1}
+
+	raw := make([]byte, 0, len(want)*4)
+	for _, f := range want {
+		raw = binary.LittleEndian.AppendUint32(raw, math.Float32bits(f))
+	}
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if req["dimensions"] != float64(3) || req["encoding_format"] != "base64" {
+			t.Errorf("unexpected request: %v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{
+			"object": "list",
+			"data": [
+				{"object": "embedding", "index": 0, "embedding": %q},
+				{"object": "embedding", "index": 1, "embedding": [0.5, -0.25, 1]}
+			],
+			"model": "text-embedding-3-small",
+			"usage": {"prompt_tokens": 2, "total_tokens": 2}
+		}`, base64.StdEncoding.EncodeToString(raw))
+	})
+
+	resp, err := c.CreateEmbedding(testCtx(t), &openai.CreateEmbeddingRequest{
+		Model:          "text-embedding-3-small",
+		Input:          "hello",
+		Dimensions:     3,
+		EncodingFormat: openai.EmbeddingEncodingBase64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range resp.Data {
+		if got := d.Float32(); !slices.Equal(got, want) {
+			t.Fatalf("expected embedding %d to be %v, got %v", d.Index, want, got)
+		}
+	}
+
+	// The base64 embedding is decoded into float32s only.
+	if d := resp.Data[0]; d.Embedding != nil || !slices.Equal(d.EmbeddingFloat32, want) {
+		t.Fatalf("unexpected base64 embedding: %v, %v", d.Embedding, d.EmbeddingFloat32)
+	}
+
+	if d := resp.Data[1]; d.EmbeddingFloat32 != nil || len(d.Embedding) != 3 {
+		t.Fatalf("unexpected float embedding: %v, %v", d.Embedding, d.EmbeddingFloat32)
+	}
+}
+
+func TestEmbeddingData_InvalidBase64(t *testing.T) {
+	var d openai.EmbeddingData
+
+	if err := json.Unmarshal([]byte(`{"embedding":"AAA="}`), &d); err == nil {
+		t.Fatal("expected error for truncated embedding")
+	}
+}
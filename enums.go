@@ -0,0 +1,323 @@
+package openai
+
+// FilePurpose is the intended purpose of an uploaded file.
+//
+// https://platform.openai.com/docs/api-reference/files/create#files-create-purpose
+type FilePurpose string
+
+const (
+	FilePurposeAssistants       FilePurpose = "assistants"
+	FilePurposeAssistantsOutput FilePurpose = "assistants_output"
+	FilePurposeBatch            FilePurpose = "batch"
+	FilePurposeBatchOutput      FilePurpose = "batch_output"
+	FilePurposeFineTune         FilePurpose = "fine-tune"
+	FilePurposeFineTuneResults  FilePurpose = "fine-tune-results"
+	FilePurposeVision           FilePurpose = "vision"
+	FilePurposeUserData         FilePurpose = "user_data"
+	FilePurposeEvals            FilePurpose = "evals"
+)
+
+// IsValid reports whether p is a known file purpose.
+func (p FilePurpose) IsValid() bool {
+	switch p {
+	case FilePurposeAssistants, FilePurposeAssistantsOutput, FilePurposeBatch, FilePurposeBatchOutput,
+		FilePurposeFineTune, FilePurposeFineTuneResults, FilePurposeVision, FilePurposeUserData, FilePurposeEvals:
+		return true
+	}
+	return false
+}
+
+// ImageSize is the size of a generated image. Not every size is supported by
+// every image model.
+//
+// https://platform.openai.com/docs/api-reference/images/create#images-create-size
+type ImageSize string
+
+const (
+	ImageSize256x256   ImageSize = "256x256"
+	ImageSize512x512   ImageSize = "512x512"
+	ImageSize1024x1024 ImageSize = "1024x1024"
+	ImageSize1792x1024 ImageSize = "1792x1024"
+	ImageSize1024x1792 ImageSize = "1024x1792"
+	ImageSize1536x1024 ImageSize = "1536x1024"
+	ImageSize1024x1536 ImageSize = "1024x1536"
+	ImageSizeAuto      ImageSize = "auto"
+)
+
+// IsValid reports whether s is a known image size.
+func (s ImageSize) IsValid() bool {
+	switch s {
+	case ImageSize256x256, ImageSize512x512, ImageSize1024x1024, ImageSize1792x1024,
+		ImageSize1024x1792, ImageSize1536x1024, ImageSize1024x1536, ImageSizeAuto:
+		return true
+	}
+	return false
+}
+
+// ImageQuality is the quality of a generated image. "standard" and "hd" are
+// supported by dall-e-3, the others by gpt-image-1.
+//
+// https://platform.openai.com/docs/api-reference/images/create#images-create-quality
+type ImageQuality string
+
+const (
+	ImageQualityStandard ImageQuality = "standard"
+	ImageQualityHD       ImageQuality = "hd"
+	ImageQualityLow      ImageQuality = "low"
+	ImageQualityMedium   ImageQuality = "medium"
+	ImageQualityHigh     ImageQuality = "high"
+	ImageQualityAuto     ImageQuality = "auto"
+)
+
+// IsValid reports whether q is a known image quality.
+func (q ImageQuality) IsValid() bool {
+	switch q {
+	case ImageQualityStandard, ImageQualityHD, ImageQualityLow, ImageQualityMedium, ImageQualityHigh, ImageQualityAuto:
+		return true
+	}
+	return false
+}
+
+// ImageBackground is the background of an image generated by gpt-image-1.
+//
+// https://platform.openai.com/docs/api-reference/images/create#images-create-background
+type ImageBackground string
+
+const (
+	ImageBackgroundTransparent ImageBackground = "transparent"
+	ImageBackgroundOpaque      ImageBackground = "opaque"
+	ImageBackgroundAuto        ImageBackground = "auto"
+)
+
+// IsValid reports whether b is a known image background.
+func (b ImageBackground) IsValid() bool {
+	switch b {
+	case ImageBackgroundTransparent, ImageBackgroundOpaque, ImageBackgroundAuto:
+		return true
+	}
+	return false
+}
+
+// ImageOutputFormat is the file format of an image generated by gpt-image-1.
+//
+// https://platform.openai.com/docs/api-reference/images/create#images-create-output_format
+type ImageOutputFormat string
+
+const (
+	ImageOutputFormatPNG  ImageOutputFormat = "png"
+	ImageOutputFormatJPEG ImageOutputFormat = "jpeg"
+	ImageOutputFormatWebP ImageOutputFormat = "webp"
+)
+
+// IsValid reports whether f is a known image output format.
+func (f ImageOutputFormat) IsValid() bool {
+	switch f {
+	case ImageOutputFormatPNG, ImageOutputFormatJPEG, ImageOutputFormatWebP:
+		return true
+	}
+	return false
+}
+
+// ImageModeration is the content moderation level of images generated by
+// gpt-image-1.
+//
+// https://platform.openai.com/docs/api-reference/images/create#images-create-moderation
+type ImageModeration string
+
+const (
+	ImageModerationLow  ImageModeration = "low"
+	ImageModerationAuto ImageModeration = "auto"
+)
+
+// IsValid reports whether m is a known image moderation level.
+func (m ImageModeration) IsValid() bool {
+	return m == ImageModerationLow || m == ImageModerationAuto
+}
+
+// SpeechVoice is a voice used to generate speech.
+//
+// https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-voice
+type SpeechVoice string
+
+const (
+	SpeechVoiceAlloy   SpeechVoice = "alloy"
+	SpeechVoiceAsh     SpeechVoice = "ash"
+	SpeechVoiceBallad  SpeechVoice = "ballad"
+	SpeechVoiceCedar   SpeechVoice = "cedar"
+	SpeechVoiceCoral   SpeechVoice = "coral"
+	SpeechVoiceEcho    SpeechVoice = "echo"
+	SpeechVoiceFable   SpeechVoice = "fable"
+	SpeechVoiceMarin   SpeechVoice = "marin"
+	SpeechVoiceOnyx    SpeechVoice = "onyx"
+	SpeechVoiceNova    SpeechVoice = "nova"
+	SpeechVoiceSage    SpeechVoice = "sage"
+	SpeechVoiceShimmer SpeechVoice = "shimmer"
+	SpeechVoiceVerse   SpeechVoice = "verse"
+)
+
+// IsValid reports whether v is a known voice.
+func (v SpeechVoice) IsValid() bool {
+	switch v {
+	case SpeechVoiceAlloy, SpeechVoiceAsh, SpeechVoiceBallad, SpeechVoiceCedar, SpeechVoiceCoral, SpeechVoiceEcho,
+		SpeechVoiceFable, SpeechVoiceMarin, SpeechVoiceOnyx, SpeechVoiceNova, SpeechVoiceSage, SpeechVoiceShimmer,
+		SpeechVoiceVerse:
+		return true
+	}
+	return false
+}
+
+// SpeechFormat is the audio format of generated speech.
+//
+// https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-response_format
+type SpeechFormat string
+
+const (
+	SpeechFormatMP3  SpeechFormat = "mp3"
+	SpeechFormatOpus SpeechFormat = "opus"
+	SpeechFormatAAC  SpeechFormat = "aac"
+	SpeechFormatFLAC SpeechFormat = "flac"
+	SpeechFormatWAV  SpeechFormat = "wav"
+	SpeechFormatPCM  SpeechFormat = "pcm"
+)
+
+// IsValid reports whether f is a known speech format.
+func (f SpeechFormat) IsValid() bool {
+	switch f {
+	case SpeechFormatMP3, SpeechFormatOpus, SpeechFormatAAC, SpeechFormatFLAC, SpeechFormatWAV, SpeechFormatPCM:
+		return true
+	}
+	return false
+}
+
+// SpeechStreamFormat is the format generated speech is streamed in.
+//
+// https://platform.openai.com/docs/api-reference/audio/createSpeech#audio-createspeech-stream_format
+type SpeechStreamFormat string
+
+const (
+	// SpeechStreamFormatAudio streams the raw audio.
+	SpeechStreamFormatAudio SpeechStreamFormat = "audio"
+
+	// SpeechStreamFormatSSE streams the audio as server-sent events, which
+	// isn't supported by tts-1 or tts-1-hd.
+	SpeechStreamFormatSSE SpeechStreamFormat = "sse"
+)
+
+// IsValid reports whether f is a known speech stream format.
+func (f SpeechStreamFormat) IsValid() bool {
+	return f == SpeechStreamFormatAudio || f == SpeechStreamFormatSSE
+}
+
+// RunStatus is the status of a run. It's an alias of string, so unlike the
+// other enums, it has no IsValid method.
+//
+// https://platform.openai.com/docs/api-reference/runs/object#runs/object-status
+type RunStatus = string
+
+const (
+	RunStatusQueued         RunStatus = "queued"
+	RunStatusInProgress     RunStatus = "in_progress"
+	RunStatusRequiresAction RunStatus = "requires_action"
+	RunStatusCancelling     RunStatus = "cancelling"
+	RunStatusCancelled      RunStatus = "cancelled"
+	RunStatusFailed         RunStatus = "failed"
+	RunStatusCompleted      RunStatus = "completed"
+	RunStatusExpired        RunStatus = "expired"
+	RunStatusIncomplete     RunStatus = "incomplete"
+)
+
+// https://platform.openai.com/docs/api-reference/run-steps/step-object#run-steps/step-object-type
+type RunStepType string
+
+const (
+	RunStepTypeMessageCreation RunStepType = "message_creation"
+	RunStepTypeToolCalls       RunStepType = "tool_calls"
+)
+
+// IsValid reports whether t is a known run step type.
+func (t RunStepType) IsValid() bool {
+	return t == RunStepTypeMessageCreation || t == RunStepTypeToolCalls
+}
+
+// https://platform.openai.com/docs/api-reference/batch/object#batch/object-status
+type BatchStatus string
+
+const (
+	BatchStatusValidating BatchStatus = "validating"
+	BatchStatusFailed     BatchStatus = "failed"
+	BatchStatusInProgress BatchStatus = "in_progress"
+	BatchStatusFinalizing BatchStatus = "finalizing"
+	BatchStatusCompleted  BatchStatus = "completed"
+	BatchStatusExpired    BatchStatus = "expired"
+	BatchStatusCancelling BatchStatus = "cancelling"
+	BatchStatusCancelled  BatchStatus = "cancelled"
+)
+
+// IsValid reports whether s is a known batch status.
+func (s BatchStatus) IsValid() bool {
+	switch s {
+	case BatchStatusValidating, BatchStatusFailed, BatchStatusInProgress, BatchStatusFinalizing,
+		BatchStatusCompleted, BatchStatusExpired, BatchStatusCancelling, BatchStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// ReasoningEffort is how much a reasoning model thinks before it answers.
+// "minimal" is only supported by gpt-5 models.
+//
+// https://platform.openai.com/docs/guides/reasoning#reasoning-effort
+type ReasoningEffort string
+
+const (
+	ReasoningEffortMinimal ReasoningEffort = "minimal"
+	ReasoningEffortLow     ReasoningEffort = "low"
+	ReasoningEffortMedium  ReasoningEffort = "medium"
+	ReasoningEffortHigh    ReasoningEffort = "high"
+)
+
+// IsValid reports whether e is a known reasoning effort.
+func (e ReasoningEffort) IsValid() bool {
+	switch e {
+	case ReasoningEffortMinimal, ReasoningEffortLow, ReasoningEffortMedium, ReasoningEffortHigh:
+		return true
+	}
+	return false
+}
+
+// ServiceTier is the processing tier used to serve a request.
+//
+// https://platform.openai.com/docs/api-reference/chat/create#chat-create-service_tier
+type ServiceTier string
+
+const (
+	ServiceTierAuto     ServiceTier = "auto"
+	ServiceTierDefault  ServiceTier = "default"
+	ServiceTierFlex     ServiceTier = "flex"
+	ServiceTierPriority ServiceTier = "priority"
+	ServiceTierScale    ServiceTier = "scale"
+)
+
+// IsValid reports whether t is a known service tier.
+func (t ServiceTier) IsValid() bool {
+	switch t {
+	case ServiceTierAuto, ServiceTierDefault, ServiceTierFlex, ServiceTierPriority, ServiceTierScale:
+		return true
+	}
+	return false
+}
+
+// SortOrder is the sort order of a list request, by creation time.
+//
+// https://platform.openai.com/docs/api-reference/assistants/listAssistants#assistants-listassistants-order
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// IsValid reports whether o is a known sort order.
+func (o SortOrder) IsValid() bool {
+	return o == SortOrderAsc || o == SortOrderDesc
+}
@@ -0,0 +1,78 @@
+package openai_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestEnumsIsValid(t *testing.T) {
+	if !openai.FilePurposeFineTune.IsValid() || !openai.FilePurposeEvals.IsValid() || openai.FilePurpose("fine_tune").IsValid() {
+		t.Error("unexpected file purpose validity")
+	}
+
+	if !openai.ImageSize1024x1024.IsValid() || openai.ImageSize("1024").IsValid() {
+		t.Error("unexpected image size validity")
+	}
+
+	if !openai.ImageBackgroundTransparent.IsValid() || openai.ImageBackground("clear").IsValid() {
+		t.Error("unexpected image background validity")
+	}
+
+	if !openai.ImageOutputFormatWebP.IsValid() || openai.ImageOutputFormat("gif").IsValid() {
+		t.Error("unexpected image output format validity")
+	}
+
+	if !openai.SpeechVoiceNova.IsValid() || openai.SpeechVoice("hal").IsValid() {
+		t.Error("unexpected voice validity")
+	}
+
+	if !openai.RunStepTypeToolCalls.IsValid() || openai.RunStepType("thinking").IsValid() {
+		t.Error("unexpected run step type validity")
+	}
+
+	if !openai.BatchStatusFinalizing.IsValid() || openai.BatchStatus("finished").IsValid() {
+		t.Error("unexpected batch status validity")
+	}
+
+	if !openai.ReasoningEffortLow.IsValid() || openai.ReasoningEffort("max").IsValid() {
+		t.Error("unexpected reasoning effort validity")
+	}
+
+	if !openai.ServiceTierFlex.IsValid() || openai.ServiceTier("fast").IsValid() {
+		t.Error("unexpected service tier validity")
+	}
+
+	if !openai.SortOrderDesc.IsValid() || openai.SortOrder("descending").IsValid() {
+		t.Error("unexpected sort order validity")
+	}
+}
+
+func TestEnumsRequestValidation(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+
+	ctx := testCtx(t)
+
+	_, err := c.CreateImage(ctx, &openai.CreateImageRequest{Prompt: "a gopher", Size: "huge"})
+	if err == nil {
+		t.Error("expected invalid image size error")
+	}
+
+	_, err = c.CreateSpeech(ctx, &openai.CreateSpeechRequest{Model: openai.ModelTTS1, Input: "hi", Voice: "hal"})
+	if err == nil {
+		t.Error("expected invalid voice error")
+	}
+
+	_, err = c.CreateSpeech(ctx, &openai.CreateSpeechRequest{Model: openai.ModelTTS1, Input: "hi", Voice: openai.SpeechVoiceMarin, Speed: 5})
+	if err == nil {
+		t.Error("expected invalid speed error")
+	}
+
+	_, err = c.ListAssistants(ctx, &openai.ListAssistantsRequest{Order: "newest"})
+	if err == nil {
+		t.Error("expected invalid order error")
+	}
+}
@@ -0,0 +1,183 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/picatz/openai/sse"
+)
+
+// APIError is an error returned by the OpenAI API, either as the body of an
+// unsuccessful response, or as an error event within a stream.
+//
+// https://platform.openai.com/docs/guides/error-codes/api-errors
+type APIError struct {
+	// StatusCode is the HTTP status code of the response, or 0 if the error
+	// was received mid-stream.
+	StatusCode int `json:"-"`
+
+	Message string `json:"message"`
+	Type    string `json:"type,omitempty"`
+	Param   string `json:"param,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if e.Code != "" {
+		msg = e.Code + ": " + msg
+	}
+
+	if e.StatusCode == 0 {
+		return "stream error: " + msg
+	}
+
+	return fmt.Sprintf("unexpected status code: %d: %s: %s", e.StatusCode, http.StatusText(e.StatusCode), msg)
+}
+
+// Sentinel errors matched by an *APIError with errors.Is, for the common classes
+// of API failures, so callers can branch on them while errors.As still returns
+// the *APIError.
+//
+// # Example
+//
+//	resp, err := client.CreateChat(ctx, req)
+//	if errors.Is(err, openai.ErrContextLengthExceeded) {
+//		// Shorten the conversation and try again.
+//	}
+var (
+	// ErrRateLimited matches errors with a 429 status code or the
+	// "rate_limit_exceeded" code, other than those for an exhausted quota,
+	// which waiting won't fix.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrContextLengthExceeded matches errors for requests longer than the
+	// model's context window. Requests can be fit to it beforehand with
+	// ContextBudget.
+	ErrContextLengthExceeded = errors.New("context length exceeded")
+
+	// ErrInvalidAPIKey matches errors with a 401 status code or the
+	// "invalid_api_key" code.
+	ErrInvalidAPIKey = errors.New("invalid API key")
+
+	// ErrModelNotFound matches errors for models that don't exist, or that
+	// the API key doesn't have access to.
+	ErrModelNotFound = errors.New("model not found")
+
+	// ErrContentFiltered matches errors for requests rejected by the content
+	// filter or the usage policies.
+	ErrContentFiltered = errors.New("content filtered")
+)
+
+// Is reports whether the error matches the target, one of the sentinel errors
+// such as ErrRateLimited, for errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return (e.StatusCode == http.StatusTooManyRequests || e.Code == "rate_limit_exceeded") &&
+			e.Code != "insufficient_quota" && e.Type != "insufficient_quota"
+	case ErrContextLengthExceeded:
+		return e.Code == "context_length_exceeded"
+	case ErrInvalidAPIKey:
+		return e.StatusCode == http.StatusUnauthorized || e.Code == "invalid_api_key"
+	case ErrModelNotFound:
+		return e.Code == "model_not_found"
+	case ErrContentFiltered:
+		return e.Code == "content_filter" || e.Code == "content_policy_violation"
+	}
+	return false
+}
+
+// newAPIError decodes the body of an unsuccessful response into an *APIError.
+// If the body isn't an error payload, it is used as the message as-is.
+func newAPIError(statusCode int, body []byte) *APIError {
+	var payload struct {
+		Error *APIError `json:"error"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Error == nil {
+		return &APIError{StatusCode: statusCode, Message: string(bytes.TrimSpace(body))}
+	}
+
+	payload.Error.StatusCode = statusCode
+	return payload.Error
+}
+
+// streamError returns an *APIError if the given stream event data is an error
+// payload, such as {"error": {"message": "..."}}, or nil otherwise.
+func streamError(data []byte) error {
+	if !bytes.Contains(data, []byte(`"error"`)) {
+		return nil
+	}
+
+	var payload struct {
+		Error *APIError `json:"error"`
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil || payload.Error == nil {
+		return nil
+	}
+
+	return payload.Error
+}
+
+// StreamError is returned when a stream fails partway through, either due to an
+// error event sent by the API, or a chunk that could not be decoded. Partial is
+// the content received before the failure.
+type StreamError struct {
+	// Err is the underlying error, an *APIError for error events.
+	Err error
+
+	// Partial is the text content received before the error.
+	Partial string
+}
+
+// Error implements the error interface.
+func (e *StreamError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// ErrStreamEventTooLarge is returned when reading a server-sent event larger than
+// the client's MaxStreamEventSize.
+var ErrStreamEventTooLarge = sse.ErrEventTooLarge
+
+// ResponseTooLargeError is returned when a response body is larger than the
+// client's MaxResponseBytes.
+type ResponseTooLargeError struct {
+	// Limit is the maximum size of the response body in bytes.
+	Limit int64
+}
+
+// Error implements the error interface.
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body exceeds %d bytes", e.Limit)
+}
+
+// limitedReader reads at most limit bytes, returning a *ResponseTooLargeError
+// if there is more to read. r should be limited to limit+1 bytes.
+type limitedReader struct {
+	r     io.Reader
+	n     int64
+	limit int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+
+	if l.n > l.limit {
+		return n - int(l.n-l.limit), &ResponseTooLargeError{Limit: l.limit}
+	}
+
+	return n, err
+}
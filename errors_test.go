@@ -0,0 +1,266 @@
+package openai_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestAPIError_StreamingRequest(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":{"message":"Rate limit reached","type":"requests","param":null,"code":"rate_limit_exceeded"}}`)
+	})
+
+	_, err := c.CreateChat(testCtx(t), &openai.CreateChatRequest{
+		Model:    openai.ModelGPT35Turbo,
+		Messages: []openai.ChatMessage{openai.User("Hello!")},
+		Stream:   true,
+	})
+
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *openai.APIError, got %T: %v", err, err)
+	}
+
+	if apiErr.StatusCode != http.StatusTooManyRequests || apiErr.Code != "rate_limit_exceeded" || apiErr.Message != "Rate limit reached" {
+		t.Fatalf("unexpected error: %#+v", apiErr)
+	}
+}
+
+func TestAPIError_AllEndpoints(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":{"message":"Incorrect API key provided","type":"invalid_request_error","param":null,"code":"invalid_api_key"}}`)
+	})
+
+	ctx := testCtx(t)
+
+	calls := map[string]func() error{
+		"ListModels": func() error {
+			_, err := c.ListModels(ctx)
+			return err
+		},
+		"ListFiles": func() error {
+			_, err := c.ListFiles(ctx, &openai.ListFilesRequest{})
+			return err
+		},
+		"CreateEmbedding": func() error {
+			_, err := c.CreateEmbedding(ctx, &openai.CreateEmbeddingRequest{Model: "text-embedding-3-small", Input: "Hi"})
+			return err
+		},
+		"ListAssistants": func() error {
+			_, err := c.ListAssistants(ctx, &openai.ListAssistantsRequest{})
+			return err
+		},
+		"ListProjectUsers": func() error {
+			_, err := c.ListProjectUsers(ctx, &openai.ListProjectUsersRequest{ProjectID: "proj_123"})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		var apiErr *openai.APIError
+		if err := call(); !errors.As(err, &apiErr) {
+			t.Errorf("%s: expected *openai.APIError, got %T: %v", name, err, err)
+			continue
+		}
+
+		if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Type != "invalid_request_error" || apiErr.Code != "invalid_api_key" {
+			t.Errorf("%s: unexpected error: %#+v", name, apiErr)
+		}
+	}
+}
+
+func TestAPIError_ChatStreamErrorPayload(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"id\":\"chatcmpl-123\",\"choices\":[{\"delta\":{\"content\":\"Hel\"},\"index\":0}]}\n\n")
+		fmt.Fprint(w, "data: {\"error\":{\"message\":\"The server had an error\",\"type\":\"server_error\"}}\n\n")
+		fmt.Fprint(w, "data: {\"id\":\"chatcmpl-123\",\"choices\":[{\"delta\":{\"content\":\"lo\"},\"index\":0}]}\n\n")
+	})
+
+	ctx := testCtx(t)
+
+	resp, err := c.CreateChat(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT35Turbo,
+		Messages: []openai.ChatMessage{openai.User("Hello!")},
+		Stream:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var chunks int
+	err = resp.ReadStream(ctx, func(chunk *openai.ChatMessageStreamChunk) error {
+		chunks++
+		return nil
+	})
+
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.Type != "server_error" {
+		t.Fatalf("expected server_error *openai.APIError, got %T: %v", err, err)
+	}
+
+	if chunks != 1 {
+		t.Fatalf("expected 1 chunk before the error, got %d", chunks)
+	}
+}
+
+func TestAPIError_FineTuneEventStream(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("stream") != "true" {
+			t.Errorf("expected stream query parameter, got %q", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, "data: {\"object\":\"fine-tune-event\",\"level\":\"info\",\"message\":\"Job started\"}\n\n")
+		fmt.Fprint(w, "data: {\"error\":{\"message\":\"Fine-tune failed\",\"code\":\"internal_error\"}}\n\n")
+	})
+
+	ctx := testCtx(t)
+
+	resp, err := c.ListFineTuneEvents(ctx, &openai.ListFineTuneEventsRequest{ID: "ft-abc", Stream: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []string
+	err = resp.ReadStream(ctx, func(e *openai.FineTuneEvent) error {
+		events = append(events, e.Message)
+		return nil
+	})
+
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "internal_error" {
+		t.Fatalf("expected internal_error *openai.APIError, got %T: %v", err, err)
+	}
+
+	if len(events) != 1 || events[0] != "Job started" {
+		t.Fatalf("unexpected events: %v", events)
+	}
+}
+
+func TestStreamError_ChatErrorEvent(t *testing.T) {
+	tests := map[string]struct {
+		frame   string
+		wantAPI bool
+	}{
+		"error event": {
+			frame:   "event: error\ndata: {\"message\":\"Overloaded\",\"type\":\"server_error\"}\n\n",
+			wantAPI: true,
+		},
+		"malformed chunk": {
+			frame: "data: {\"id\":\"chatcmpl-123\",\"choices\":[{\"delta\":{\"content\":\n\n",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"},\"index\":0}]}\n\n")
+				fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Hello\"},\"index\":0}]}\n\n")
+				fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\", wor\"},\"index\":0}]}\n\n")
+				fmt.Fprint(w, tt.frame)
+				fmt.Fprint(w, "data: [DONE]\n\n")
+			})
+
+			ctx := testCtx(t)
+
+			resp, err := c.CreateChat(ctx, &openai.CreateChatRequest{
+				Model:    openai.ModelGPT35Turbo,
+				Messages: []openai.ChatMessage{openai.User("Hello!")},
+				Stream:   true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = resp.ReadStream(ctx, func(chunk *openai.ChatMessageStreamChunk) error { return nil })
+
+			var streamErr *openai.StreamError
+			if !errors.As(err, &streamErr) {
+				t.Fatalf("expected *openai.StreamError, got %T: %v", err, err)
+			}
+
+			if streamErr.Partial != "Hello, wor" {
+				t.Fatalf("unexpected partial content: %q", streamErr.Partial)
+			}
+
+			var apiErr *openai.APIError
+			if errors.As(err, &apiErr) != tt.wantAPI {
+				t.Fatalf("unexpected underlying error: %T: %v", streamErr.Err, streamErr.Err)
+			}
+		})
+	}
+}
+
+func TestWithMaxResponseBytes(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"object":"list","data":[{"id":%q}]}`, strings.Repeat("x", 4096))
+	}
+
+	c := openai.NewClient("test", openai.WithMaxResponseBytes(1024), openai.WithHTTPClient(testClient(t, h).HTTPClient))
+
+	_, err := c.ListModels(testCtx(t))
+
+	var tooLarge *openai.ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || tooLarge.Limit != 1024 {
+		t.Fatalf("expected *openai.ResponseTooLargeError, got %T: %v", err, err)
+	}
+
+	c = openai.NewClient("test", openai.WithMaxResponseBytes(1<<20), openai.WithHTTPClient(testClient(t, h).HTTPClient))
+
+	_, err = c.ListModels(testCtx(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{http.StatusTooManyRequests, `{"error":{"message":"Rate limit reached","code":"rate_limit_exceeded"}}`, openai.ErrRateLimited},
+		{http.StatusBadRequest, `{"error":{"message":"This model's maximum context length is 8192 tokens.","code":"context_length_exceeded"}}`, openai.ErrContextLengthExceeded},
+		{http.StatusUnauthorized, `{"error":{"message":"Incorrect API key provided","code":"invalid_api_key"}}`, openai.ErrInvalidAPIKey},
+		{http.StatusNotFound, `{"error":{"message":"The model does not exist","code":"model_not_found"}}`, openai.ErrModelNotFound},
+		{http.StatusBadRequest, `{"error":{"message":"Your request was rejected","code":"content_policy_violation"}}`, openai.ErrContentFiltered},
+		{http.StatusTooManyRequests, `{"error":{"message":"You exceeded your current quota","type":"insufficient_quota","code":"insufficient_quota"}}`, nil},
+	}
+
+	sentinels := []error{
+		openai.ErrRateLimited,
+		openai.ErrContextLengthExceeded,
+		openai.ErrInvalidAPIKey,
+		openai.ErrModelNotFound,
+		openai.ErrContentFiltered,
+	}
+
+	for _, test := range tests {
+		c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			fmt.Fprint(w, test.body)
+		})
+
+		_, err := c.CreateChat(testCtx(t), &openai.CreateChatRequest{
+			Model:    openai.ModelGPT4o,
+			Messages: []openai.ChatMessage{openai.User("Hello!")},
+		})
+
+		for _, sentinel := range sentinels {
+			if got := errors.Is(err, sentinel); got != (sentinel == test.want) {
+				t.Errorf("errors.Is(%v, %v) = %t", err, sentinel, got)
+			}
+		}
+
+		var apiErr *openai.APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("expected *openai.APIError, got %T", err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/picatz/openai/embeddings"
+)
+
+// FewShotExample is an example input and the output expected for it.
+type FewShotExample struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// FewShot stores example input and output pairs, and adds the examples most
+// relevant to a chat request to it, as found by the similarity of the
+// embeddings of their inputs to the request's last user message.
+//
+// A FewShot is safe for concurrent use.
+//
+// # Example
+//
+//	examples := openai.NewFewShot(client, openai.ModelTextEmbedding3Small)
+//
+//	err := examples.Add(ctx,
+//		openai.FewShotExample{Input: "I love it!", Output: "positive"},
+//		openai.FewShotExample{Input: "It broke after a day.", Output: "negative"},
+//	)
+//
+//	req, err := examples.Inject(ctx, &openai.CreateChatRequest{
+//		Model: openai.ModelGPT4o,
+//		Messages: []openai.ChatMessage{
+//			openai.System("Classify the sentiment of the review."),
+//			openai.User("Works great, would buy again."),
+//		},
+//	}, &openai.FewShotOptions{K: 2, MaxTokens: 500})
+//
+//	resp, err := client.CreateChat(ctx, req)
+type FewShot struct {
+	client *Client
+	model  string
+	index  *embeddings.VectorIndex
+}
+
+// NewFewShot returns an empty FewShot that embeds inputs with the given client
+// and embedding model, such as ModelTextEmbedding3Small.
+func NewFewShot(c *Client, embeddingModel string) *FewShot {
+	return &FewShot{
+		client: c,
+		model:  embeddingModel,
+		index:  embeddings.NewVectorIndex(),
+	}
+}
+
+// FewShotOptions configures FewShot.Inject.
+type FewShotOptions struct {
+	// K is the maximum number of examples to add.
+	//
+	// Optional. Defaults to 3.
+	K int
+
+	// MaxTokens is the maximum number of tokens of the added examples. The most
+	// relevant examples are added first, and those that don't fit are skipped.
+	//
+	// Optional. Defaults to no limit.
+	MaxTokens int
+}
+
+// Len returns the number of stored examples.
+func (f *FewShot) Len() int {
+	return f.index.Len()
+}
+
+// Add embeds the inputs of the examples and stores them. An example with the
+// same input as a stored one replaces it.
+func (f *FewShot) Add(ctx context.Context, examples ...FewShotExample) error {
+	for _, ex := range examples {
+		if ex.Input == "" {
+			return errors.New("example input is empty")
+		}
+
+		embedding, err := f.embed(ctx, ex.Input)
+		if err != nil {
+			return err
+		}
+
+		err = f.index.Add(ex.Input, embedding, map[string]string{"output": ex.Output})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Select returns the k stored examples most relevant to the input, most
+// relevant first.
+func (f *FewShot) Select(ctx context.Context, input string, k int) ([]FewShotExample, error) {
+	if f.index.Len() == 0 || k <= 0 {
+		return nil, nil
+	}
+
+	embedding, err := f.embed(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := f.index.Query(embedding, k, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := make([]FewShotExample, len(results))
+	for i, r := range results {
+		examples[i] = FewShotExample{Input: r.ID, Output: r.Metadata["output"]}
+	}
+	return examples, nil
+}
+
+// Inject returns a copy of the chat request with the examples most relevant
+// to its last user message added as user and assistant message pairs, after
+// its leading system messages. The most relevant example is added last,
+// closest to the conversation. The given request isn't modified.
+//
+// Token counts are estimated unless the encoding of the request's model is
+// loaded, as described by CountChatTokens.
+func (f *FewShot) Inject(ctx context.Context, req *CreateChatRequest, opts *FewShotOptions) (*CreateChatRequest, error) {
+	if opts == nil {
+		opts = &FewShotOptions{}
+	}
+
+	k := opts.K
+	if k <= 0 {
+		k = 3
+	}
+
+	var query string
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role == ChatRoleUser {
+			query = req.Messages[i].text()
+			break
+		}
+	}
+
+	if query == "" {
+		return nil, errors.New("chat request has no user message text")
+	}
+
+	examples, err := f.Select(ctx, query, k)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		shots  []ChatMessage
+		tokens int
+	)
+
+	for _, ex := range examples {
+		pair := []ChatMessage{User(ex.Input), AssistantMsg(ex.Output)}
+
+		if opts.MaxTokens > 0 {
+			n, err := CountChatTokens(req.Model, pair)
+			if err != nil {
+				return nil, err
+			}
+
+			if tokens+n > opts.MaxTokens {
+				continue
+			}
+			tokens += n
+		}
+
+		shots = append(pair, shots...)
+	}
+
+	r := *req
+
+	i := slices.IndexFunc(req.Messages, func(m ChatMessage) bool { return m.Role != ChatRoleSystem })
+	if i < 0 {
+		i = len(req.Messages)
+	}
+
+	r.Messages = slices.Concat(req.Messages[:i], shots, req.Messages[i:])
+	return &r, nil
+}
+
+// embed returns the embedding of the text.
+func (f *FewShot) embed(ctx context.Context, text string) ([]float32, error) {
+	resp, err := f.client.CreateEmbedding(ctx, &CreateEmbeddingRequest{Model: f.model, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed text: %w", err)
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, errors.New("failed to embed text: no embedding returned")
+	}
+
+	return resp.Data[0].Float32(), nil
+}
@@ -0,0 +1,96 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestFewShot(t *testing.T) {
+	vectors := map[string]string{
+		"I love it!":                    "[1, 0, 0]",
+		"It broke after a day.":         "[0, 1, 0]",
+		"Where is my order?":            "[0, 0, 1]",
+		"Works great, would buy again.": "[0.9, 0.3, 0]",
+	}
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req openai.CreateEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if req.Model != openai.ModelTextEmbedding3Small {
+			t.Errorf("unexpected model: %q", req.Model)
+		}
+
+		v, ok := vectors[req.Input]
+		if !ok {
+			t.Fatalf("unexpected input: %q", req.Input)
+		}
+		fmt.Fprintf(w, `{"data":[{"index":0,"embedding":%s}]}`, v)
+	})
+
+	examples := openai.NewFewShot(c, openai.ModelTextEmbedding3Small)
+
+	err := examples.Add(testCtx(t),
+		openai.FewShotExample{Input: "I love it!", Output: "positive"},
+		openai.FewShotExample{Input: "It broke after a day.", Output: "negative"},
+		openai.FewShotExample{Input: "Where is my order?", Output: "neutral"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if examples.Len() != 3 {
+		t.Fatalf("expected 3 examples, got %d", examples.Len())
+	}
+
+	req := &openai.CreateChatRequest{
+		Model: openai.ModelGPT4o,
+		Messages: []openai.ChatMessage{
+			openai.System("Classify the sentiment of the review."),
+			openai.User("Works great, would buy again."),
+		},
+	}
+
+	got, err := examples.Inject(testCtx(t), req, &openai.FewShotOptions{K: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var contents []string
+	for _, m := range got.Messages {
+		contents = append(contents, m.Role+": "+m.Content)
+	}
+
+	want := []string{
+		"system: Classify the sentiment of the review.",
+		"user: It broke after a day.",
+		"assistant: negative",
+		"user: I love it!",
+		"assistant: positive",
+		"user: Works great, would buy again.",
+	}
+
+	if fmt.Sprint(contents) != fmt.Sprint(want) {
+		t.Errorf("unexpected messages:\n got: %q\nwant: %q", contents, want)
+	}
+
+	if len(req.Messages) != 2 {
+		t.Errorf("request was modified: %+v", req.Messages)
+	}
+
+	// A budget that only fits the most relevant example.
+	got, err = examples.Inject(testCtx(t), req, &openai.FewShotOptions{K: 2, MaxTokens: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Messages) != 4 || got.Messages[1].Content != "I love it!" {
+		t.Errorf("expected only the most relevant example, got %+v", got.Messages)
+	}
+}
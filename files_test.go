@@ -0,0 +1,59 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestListFiles_Pagination(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		if r.URL.Path != "/v1/files" || q.Get("purpose") != "batch" || q.Get("limit") != "1" || q.Get("order") != "asc" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+
+		switch q.Get("after") {
+		case "":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"file-a","purpose":"batch"}],"first_id":"file-a","last_id":"file-a","has_more":true}`)
+		case "file-a":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"file-b","purpose":"batch"}],"first_id":"file-b","last_id":"file-b","has_more":false}`)
+		default:
+			t.Errorf("unexpected cursor: %s", q.Get("after"))
+		}
+	})
+
+	ctx := testCtx(t)
+
+	page, err := c.ListFiles(ctx, &openai.ListFilesRequest{
+		Purpose: string(openai.FilePurposeBatch),
+		Limit:   1,
+		Order:   openai.SortOrderAsc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !page.HasMore {
+		t.Fatal("expected more files")
+	}
+
+	var ids []string
+	for f, err := range page.All(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, f.ID)
+	}
+
+	if len(ids) != 2 || ids[0] != "file-a" || ids[1] != "file-b" {
+		t.Fatalf("unexpected files: %v", ids)
+	}
+
+	if _, err := c.ListFiles(ctx, &openai.ListFilesRequest{Order: "newest"}); err == nil {
+		t.Fatal("expected invalid order error")
+	}
+}
@@ -0,0 +1,80 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+)
+
+// FineTuningJobCheckpoint is a model checkpoint saved at the end of an epoch of
+// a fine-tuning job, which can be used like any other fine-tuned model.
+//
+// https://platform.openai.com/docs/api-reference/fine-tuning/checkpoint-object
+type FineTuningJobCheckpoint struct {
+	Object                   string                         `json:"object"`
+	ID                       string                         `json:"id"`
+	CreatedAt                int                            `json:"created_at"`
+	FineTunedModelCheckpoint string                         `json:"fine_tuned_model_checkpoint"`
+	FineTuningJobID          string                         `json:"fine_tuning_job_id"`
+	StepNumber               int                            `json:"step_number"`
+	Metrics                  FineTuningJobCheckpointMetrics `json:"metrics"`
+}
+
+// FineTuningJobCheckpointMetrics are the metrics of a fine-tuning job at the
+// step of a checkpoint.
+//
+// https://platform.openai.com/docs/api-reference/fine-tuning/checkpoint-object#fine-tuning/checkpoint-object-metrics
+type FineTuningJobCheckpointMetrics struct {
+	Step                       float64 `json:"step"`
+	TrainLoss                  float64 `json:"train_loss"`
+	TrainMeanTokenAccuracy     float64 `json:"train_mean_token_accuracy"`
+	ValidLoss                  float64 `json:"valid_loss"`
+	ValidMeanTokenAccuracy     float64 `json:"valid_mean_token_accuracy"`
+	FullValidLoss              float64 `json:"full_valid_loss"`
+	FullValidMeanTokenAccuracy float64 `json:"full_valid_mean_token_accuracy"`
+}
+
+// https://platform.openai.com/docs/api-reference/fine-tuning/list-checkpoints
+type ListFineTuningJobCheckpointsRequest struct {
+	// https://platform.openai.com/docs/api-reference/fine-tuning/list-checkpoints#fine-tuning-list-checkpoints-fine_tuning_job_id
+	//
+	// Required.
+	FineTuningJobID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/fine-tuning/list-checkpoints#fine-tuning-list-checkpoints-limit
+	//
+	// Optional. Defaults to 10.
+	Limit int `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/fine-tuning/list-checkpoints#fine-tuning-list-checkpoints-after
+	//
+	// Optional.
+	After string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/fine-tuning/list-checkpoints
+type ListFineTuningJobCheckpointsResponse = Page[FineTuningJobCheckpoint]
+
+// ListFineTuningJobCheckpoints lists the checkpoints of a fine-tuning job, most
+// recent first. The FineTunedModelCheckpoint of a checkpoint is the name of the
+// model to use in place of the job's final model.
+//
+// # Example
+//
+//	checkpoints, _ := c.ListFineTuningJobCheckpoints(ctx, &openai.ListFineTuningJobCheckpointsRequest{
+//		FineTuningJobID: "ftjob-abc123",
+//	})
+//
+//	for _, cp := range checkpoints.Data {
+//		fmt.Println(cp.StepNumber, cp.Metrics.ValidLoss, cp.FineTunedModelCheckpoint)
+//	}
+//
+// https://platform.openai.com/docs/api-reference/fine-tuning/list-checkpoints
+func (c *Client) ListFineTuningJobCheckpoints(ctx context.Context, req *ListFineTuningJobCheckpointsRequest) (*ListFineTuningJobCheckpointsResponse, error) {
+	var res ListFineTuningJobCheckpointsResponse
+	err := c.do(ctx, http.MethodGet, "/fine_tuning/jobs/"+req.FineTuningJobID+"/checkpoints"+listQuery(req.Limit, "", req.After, ""), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListFineTuningJobCheckpointsRequest, after string) { r.After = after }, c.ListFineTuningJobCheckpoints)
+	return &res, nil
+}
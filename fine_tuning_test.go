@@ -0,0 +1,63 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestListFineTuningJobCheckpoints(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/fine_tuning/jobs/ftjob-abc/checkpoints" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.Query().Get("after") {
+		case "":
+			fmt.Fprint(w, `{"object":"list","data":[{
+				"object": "fine_tuning.job.checkpoint",
+				"id": "ftckpt_b",
+				"fine_tuned_model_checkpoint": "ft:gpt-4o-mini:org::abc:ckpt-step-200",
+				"fine_tuning_job_id": "ftjob-abc",
+				"step_number": 200,
+				"metrics": {"step": 200, "train_loss": 0.5, "valid_loss": 0.75, "full_valid_mean_token_accuracy": 0.9}
+			}],"first_id":"ftckpt_b","last_id":"ftckpt_b","has_more":true}`)
+		case "ftckpt_b":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"ftckpt_a","step_number":100}],"first_id":"ftckpt_a","last_id":"ftckpt_a","has_more":false}`)
+		default:
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	page, err := c.ListFineTuningJobCheckpoints(ctx, &openai.ListFineTuningJobCheckpointsRequest{FineTuningJobID: "ftjob-abc", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cp := page.Data[0]
+	if cp.StepNumber != 200 || cp.FineTunedModelCheckpoint != "ft:gpt-4o-mini:org::abc:ckpt-step-200" {
+		t.Fatalf("unexpected checkpoint: %+v", cp)
+	}
+
+	if cp.Metrics.ValidLoss != 0.75 || cp.Metrics.FullValidMeanTokenAccuracy != 0.9 {
+		t.Fatalf("unexpected metrics: %+v", cp.Metrics)
+	}
+
+	var steps []int
+	for cp, err := range page.All(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		steps = append(steps, cp.StepNumber)
+	}
+
+	if len(steps) != 2 || steps[1] != 100 {
+		t.Fatalf("unexpected steps: %v", steps)
+	}
+}
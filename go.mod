@@ -1,6 +1,6 @@
 module github.com/picatz/openai
 
-go 1.19
+go 1.23
 
 require (
 	github.com/charmbracelet/glamour v0.6.0
@@ -0,0 +1,68 @@
+package openai
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// idempotencyKeyHeader is the header that identifies a request, so that the
+// API doesn't create a resource twice when it is retried.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx that sends the given key as
+// the Idempotency-Key header of requests made with it, instead of a generated
+// one, such as to safely retry an operation across process restarts.
+//
+// # Example
+//
+//	ctx = openai.ContextWithIdempotencyKey(ctx, "upload-"+checksum)
+//
+//	file, err := client.UploadFile(ctx, req)
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// WithIdempotencyKeys is a ClientOption that enables or disables the random
+// Idempotency-Key header sent with each POST request, which is kept by its
+// retries so they never create duplicate resources, such as uploaded files or
+// fine-tuning jobs.
+//
+// Idempotency keys are enabled by default. Keys given with
+// ContextWithIdempotencyKey are sent either way.
+func WithIdempotencyKeys(enabled bool) ClientOption {
+	return func(client *Client) {
+		client.DisableIdempotencyKeys = !enabled
+	}
+}
+
+// setIdempotencyKey sets the Idempotency-Key header of a POST request, from its
+// context or a new random key, unless it is already set.
+func (c *Client) setIdempotencyKey(r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get(idempotencyKeyHeader) != "" {
+		return
+	}
+
+	if key, ok := r.Context().Value(idempotencyKeyContextKey{}).(string); ok && key != "" {
+		r.Header.Set(idempotencyKeyHeader, key)
+		return
+	}
+
+	if !c.DisableIdempotencyKeys {
+		r.Header.Set(idempotencyKeyHeader, newIdempotencyKey())
+	}
+}
+
+// newIdempotencyKey returns a random (version 4) UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+	rand.Read(b[:])
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
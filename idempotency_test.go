@@ -0,0 +1,63 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	var keys []string
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if len(keys) == 1 {
+			http.Error(w, `{"error":{"message":"unavailable"}}`, http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id":"asst_1","object":"assistant"}`)
+	}
+
+	newClient := func(opts ...openai.ClientOption) *openai.Client {
+		return openai.NewClient("test", append([]openai.ClientOption{
+			openai.WithHTTPClient(testClient(t, h).HTTPClient),
+			openai.WithRetry(2, openai.RetryPolicy{BaseDelay: time.Millisecond}),
+		}, opts...)...)
+	}
+
+	ctx := testCtx(t)
+
+	_, err := newClient().CreateAssistant(ctx, &openai.CreateAssistantRequest{Model: openai.ModelGPT4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected retried request to keep its idempotency key, got %q", keys)
+	}
+
+	keys = nil
+
+	_, err = newClient().CreateAssistant(openai.ContextWithIdempotencyKey(ctx, "my-key"), &openai.CreateAssistantRequest{Model: openai.ModelGPT4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys[0] != "my-key" {
+		t.Fatalf("expected idempotency key from context, got %q", keys[0])
+	}
+
+	keys = nil
+
+	_, err = newClient(openai.WithIdempotencyKeys(false)).CreateAssistant(ctx, &openai.CreateAssistantRequest{Model: openai.ModelGPT4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if keys[0] != "" {
+		t.Fatalf("expected no idempotency key, got %q", keys[0])
+	}
+}
@@ -0,0 +1,407 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register the GIF decoder for CreateImageResponse.Decode.
+	_ "image/jpeg" // Register the JPEG decoder for CreateImageResponse.Decode.
+	_ "image/png"  // Register the PNG decoder for CreateImageResponse.Decode.
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// https://platform.openai.com/docs/api-reference/images/createEdit
+type CreateImageEditRequest struct {
+	// Image is the image to edit. For dall-e-2, it must be a square PNG less
+	// than 4MB; if Mask isn't provided, it must have transparency, which is
+	// used as the mask.
+	//
+	// It is streamed to the API as it is read, so it may be a file or any other
+	// reader.
+	//
+	// https://platform.openai.com/docs/api-reference/images/createEdit#images-createedit-image
+	//
+	// Required.
+	Image io.Reader
+
+	// ImageName is the file name of the image, which the API uses to determine
+	// its format.
+	//
+	// Optional. Defaults to the name of Image if it has a Name method, such as
+	// an *os.File, or "image.png" otherwise.
+	ImageName string
+
+	// Mask is a PNG whose fully transparent areas indicate where the image
+	// should be edited. It must have the same dimensions as the image.
+	//
+	// https://platform.openai.com/docs/api-reference/images/createEdit#images-createedit-mask
+	//
+	// Optional.
+	Mask io.Reader
+
+	// MaskName is the file name of the mask.
+	//
+	// Optional. Defaults to the name of Mask if it has a Name method, or
+	// "mask.png" otherwise.
+	MaskName string
+
+	// https://platform.openai.com/docs/api-reference/images/createEdit#images-createedit-prompt
+	//
+	// Required.
+	Prompt string
+
+	// https://platform.openai.com/docs/api-reference/images/createEdit#images-createedit-model
+	//
+	// Optional. Defaults to "dall-e-2".
+	Model string
+
+	// https://platform.openai.com/docs/api-reference/images/createEdit#images-createedit-n
+	//
+	// Optional. Defaults to 1.
+	N int
+
+	// https://platform.openai.com/docs/api-reference/images/createEdit#images-createedit-size
+	//
+	// Optional. Defaults to "1024x1024".
+	Size ImageSize
+
+	// https://platform.openai.com/docs/api-reference/images/createEdit#images-createedit-response_format
+	//
+	// Optional. Either "url" or "b64_json", defaults to "url".
+	ResponseFormat string
+
+	// https://platform.openai.com/docs/api-reference/images/createEdit#images-createedit-user
+	//
+	// Optional.
+	User string
+}
+
+// fileName returns the name of r if it has one, or def otherwise.
+func fileName(r io.Reader, name, def string) string {
+	if name != "" {
+		return name
+	}
+
+	if n, ok := r.(interface{ Name() string }); ok && n.Name() != "" {
+		return n.Name()
+	}
+
+	return def
+}
+
+// form returns the request as a multipart form.
+func (req *CreateImageEditRequest) form() *multipartForm {
+	var form multipartForm
+	form.addFile("image", fileName(req.Image, req.ImageName, "image.png"), req.Image)
+
+	if req.Mask != nil {
+		form.addFile("mask", fileName(req.Mask, req.MaskName, "mask.png"), req.Mask)
+	}
+
+	form.addField("prompt", req.Prompt)
+	form.addField("model", req.Model)
+	form.addField("size", string(req.Size))
+	form.addField("response_format", req.ResponseFormat)
+	form.addField("user", req.User)
+
+	if req.N != 0 {
+		form.addField("n", strconv.Itoa(req.N))
+	}
+
+	return &form
+}
+
+// CreateImageEdit edits or extends an image given a prompt, and an optional
+// mask of the area to edit.
+//
+// The image and mask are streamed to the API rather than buffered in memory,
+// so the request isn't retried.
+//
+// # Example
+//
+//	fh, _ := os.Open("gopher.png")
+//	defer fh.Close()
+//
+//	resp, _ := c.CreateImageEdit(ctx, &openai.CreateImageEditRequest{
+//		Image:  fh,
+//		Prompt: "A gopher wearing a party hat",
+//		Size:   openai.ImageSize512x512,
+//	})
+//
+// https://platform.openai.com/docs/api-reference/images/createEdit
+func (c *Client) CreateImageEdit(ctx context.Context, req *CreateImageEditRequest) (*CreateImageResponse, error) {
+	if req.Image == nil {
+		return nil, errors.New("image is required")
+	}
+
+	if req.Prompt == "" {
+		return nil, errors.New("prompt is required")
+	}
+
+	if req.Size != "" && !req.Size.IsValid() {
+		return nil, fmt.Errorf("invalid image size: %q", req.Size)
+	}
+
+	resp, err := c.doMultipart(ctx, "/images/edits", req.form())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	res := CreateImageResponse{client: c}
+	if err := json.NewDecoder(c.limitBody(resp.Body)).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &res, nil
+}
+
+// Bytes returns the encoded data of the i'th image, decoding it if it was
+// returned as base64, or downloading it with the client's HTTPClient if it was
+// returned as a URL.
+func (r *CreateImageResponse) Bytes(ctx context.Context, i int) ([]byte, error) {
+	if i < 0 || i >= len(r.Data) {
+		return nil, fmt.Errorf("image index %d out of range [0, %d)", i, len(r.Data))
+	}
+
+	d := r.Data[i]
+
+	switch {
+	case d.B64JSON != nil:
+		return base64.StdEncoding.DecodeString(*d.B64JSON)
+	case d.URL != nil:
+		return r.download(ctx, *d.URL)
+	default:
+		return nil, fmt.Errorf("image %d has no data", i)
+	}
+}
+
+// download fetches an image URL. The URL is pre-signed, so no API headers are
+// sent with the request.
+func (r *CreateImageResponse) download(ctx context.Context, url string) ([]byte, error) {
+	c := r.client
+	if c == nil {
+		c = &Client{HTTPClient: http.DefaultClient}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download image: unexpected status code: %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(c.limitBody(resp.Body))
+}
+
+// Decode decodes the i'th image, downloading it first if it was returned as a
+// URL. PNG, JPEG and GIF images are supported; decoding WebP images requires
+// importing a decoder such as golang.org/x/image/webp.
+func (r *CreateImageResponse) Decode(i int) (image.Image, error) {
+	b, err := r.Bytes(context.Background(), i)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(b))
+	return img, err
+}
+
+// SaveAll writes every image to the given directory, creating it if needed,
+// and returns the paths of the written files. Files are named after the
+// creation time and index of the image, such as "1700000000-0.png", with an
+// extension matching the image format.
+//
+// # Example
+//
+//	resp, _ := c.CreateImage(ctx, &openai.CreateImageRequest{
+//		Prompt: "Golang-style gopher mascot wearing an OpenAI t-shirt",
+//		N:      2,
+//	})
+//
+//	paths, _ := resp.SaveAll(ctx, "gophers")
+func (r *CreateImageResponse) SaveAll(ctx context.Context, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(r.Data))
+
+	for i := range r.Data {
+		b, err := r.Bytes(ctx, i)
+		if err != nil {
+			return paths, err
+		}
+
+		path := filepath.Join(dir, fmt.Sprintf("%d-%d%s", r.Created, i, imageExt(b)))
+
+		if err := os.WriteFile(path, b, 0o644); err != nil {
+			return paths, err
+		}
+
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// imageExt returns the file extension for the given encoded image.
+func imageExt(b []byte) string {
+	switch http.DetectContentType(b) {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ".png"
+	}
+}
+
+// CreateImagesOptions configures CreateImages.
+type CreateImagesOptions struct {
+	// Concurrency is the maximum number of requests sent at once.
+	//
+	// Optional. Defaults to 4.
+	Concurrency int
+}
+
+// CreateImagesResponse is the result of CreateImages, with the response or
+// error of each request, in order.
+type CreateImagesResponse struct {
+	// Responses are the responses to the requests, nil for those that failed.
+	Responses []*CreateImageResponse
+
+	// Errors are the errors of the requests, nil for those that succeeded.
+	Errors []error
+}
+
+// Images returns a response with the images of all successful requests, in
+// order, so they can be saved with SaveAll or read with Bytes.
+func (r *CreateImagesResponse) Images() *CreateImageResponse {
+	var images *CreateImageResponse
+
+	for _, resp := range r.Responses {
+		if resp == nil {
+			continue
+		}
+
+		if images == nil {
+			merged := *resp
+			merged.Data = nil
+			images = &merged
+		}
+
+		images.Created = max(images.Created, resp.Created)
+		images.Data = append(images.Data, resp.Data...)
+	}
+
+	if images == nil {
+		return &CreateImageResponse{}
+	}
+	return images
+}
+
+// RevisedPrompts returns the prompt the model used for each request, as
+// revised from the given prompt, or an empty string for requests that failed
+// or whose prompt wasn't revised.
+func (r *CreateImagesResponse) RevisedPrompts() []string {
+	prompts := make([]string, len(r.Responses))
+	for i, resp := range r.Responses {
+		if resp != nil && len(resp.Data) > 0 && resp.Data[0].RevisedPrompt != nil {
+			prompts[i] = *resp.Data[0].RevisedPrompt
+		}
+	}
+	return prompts
+}
+
+// CreateImages sends n concurrent CreateImage requests for one image each, for
+// models that only generate one image per request, such as dall-e-3.
+//
+// The response holds the result of every request. If any failed, their errors
+// are also returned joined, along with the response, so the images that were
+// generated aren't lost.
+//
+// # Example
+//
+//	resp, err := c.CreateImages(ctx, &openai.CreateImageRequest{
+//		Model:  openai.ModelDallE3,
+//		Prompt: "Golang-style gopher mascot wearing an OpenAI t-shirt",
+//	}, 4, nil)
+//	if err != nil {
+//		log.Printf("some images failed: %v", err)
+//	}
+//
+//	paths, _ := resp.Images().SaveAll(ctx, "gophers")
+func (c *Client) CreateImages(ctx context.Context, req *CreateImageRequest, n int, opts *CreateImagesOptions) (*CreateImagesResponse, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid number of images: %d", n)
+	}
+
+	if opts == nil {
+		opts = &CreateImagesOptions{}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	res := &CreateImagesResponse{
+		Responses: make([]*CreateImageResponse, n),
+		Errors:    make([]error, n),
+	}
+
+	r := *req
+	r.N = 1
+
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			res.Errors[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			res.Responses[i], res.Errors[i] = c.CreateImage(ctx, &r)
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for i, err := range res.Errors {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("image %d: %w", i, err))
+		}
+	}
+
+	return res, errors.Join(errs...)
+}
@@ -0,0 +1,316 @@
+package openai_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestCreateImageEdit(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/images/edits" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+
+		for name, want := range map[string]string{
+			"prompt": "add a hat",
+			"model":  openai.ModelDallE2,
+			"n":      "2",
+			"size":   "512x512",
+		} {
+			if got := r.FormValue(name); got != want {
+				t.Errorf("expected %s %q, got %q", name, want, got)
+			}
+		}
+
+		for name, want := range map[string][2]string{
+			"image": {"gopher.png", "image data"},
+			"mask":  {"mask.png", "mask data"},
+		} {
+			f, h, err := r.FormFile(name)
+			if err != nil {
+				t.Fatalf("missing %s: %v", name, err)
+			}
+			b, _ := io.ReadAll(f)
+			if h.Filename != want[0] || string(b) != want[1] {
+				t.Errorf("unexpected %s: %q %q", name, h.Filename, b)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"created":1,"data":[{"url":"https://example.com/1.png"},{"url":"https://example.com/2.png"}]}`)
+	})
+
+	resp, err := c.CreateImageEdit(testCtx(t), &openai.CreateImageEditRequest{
+		Image:     strings.NewReader("image data"),
+		ImageName: "gopher.png",
+		Mask:      strings.NewReader("mask data"),
+		Prompt:    "add a hat",
+		Model:     openai.ModelDallE2,
+		N:         2,
+		Size:      openai.ImageSize512x512,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Data) != 2 || *resp.Data[1].URL != "https://example.com/2.png" {
+		t.Fatalf("unexpected response: %+v", resp.Data)
+	}
+}
+
+func TestCreateImageEdit_Error(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Don't read the body, so the upload is abandoned.
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"error":{"message":"invalid image","type":"invalid_request_error"}}`)
+	})
+
+	_, err := c.CreateImageEdit(testCtx(t), &openai.CreateImageEditRequest{
+		Image:  strings.NewReader(strings.Repeat("x", 1<<20)),
+		Prompt: "add a hat",
+	})
+
+	apiErr, ok := err.(*openai.APIError)
+	if !ok || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "invalid image" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := c.CreateImageEdit(testCtx(t), &openai.CreateImageEditRequest{Prompt: "add a hat"}); err == nil {
+		t.Fatal("expected error for missing image")
+	}
+}
+
+func TestCreateImage_GPTImage1(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		for name, want := range map[string]any{
+			"model":              openai.ModelGPTImage1,
+			"background":         "transparent",
+			"output_format":      "webp",
+			"output_compression": float64(0),
+			"moderation":         "low",
+		} {
+			if req[name] != want {
+				t.Errorf("expected %s %v, got %v", name, want, req[name])
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{
+			"created": 1,
+			"background": "transparent",
+			"output_format": "webp",
+			"quality": "high",
+			"size": "1024x1024",
+			"data": [{"b64_json": "aW1hZ2U="}],
+			"usage": {
+				"total_tokens": 100,
+				"input_tokens": 50,
+				"output_tokens": 50,
+				"input_tokens_details": {"text_tokens": 10, "image_tokens": 40}
+			}
+		}`)
+	})
+
+	compression := 0
+
+	resp, err := c.CreateImage(testCtx(t), &openai.CreateImageRequest{
+		Prompt:            "a gopher",
+		Model:             openai.ModelGPTImage1,
+		Background:        openai.ImageBackgroundTransparent,
+		OutputFormat:      openai.ImageOutputFormatWebP,
+		OutputCompression: &compression,
+		Moderation:        openai.ImageModerationLow,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.OutputFormat != openai.ImageOutputFormatWebP || resp.Quality != openai.ImageQualityHigh || resp.Size != openai.ImageSize1024x1024 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	if resp.Usage == nil || resp.Usage.TotalTokens != 100 || resp.Usage.InputTokensDetails.ImageTokens != 40 {
+		t.Fatalf("unexpected usage: %+v", resp.Usage)
+	}
+
+	compression = 101
+	if _, err := c.CreateImage(testCtx(t), &openai.CreateImageRequest{Prompt: "a gopher", OutputCompression: &compression}); err == nil {
+		t.Fatal("expected invalid output compression error")
+	}
+}
+
+func TestCreateImageResponse_SaveAll(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 3))
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.String() {
+		case "https://api.openai.com/v1/images/generations":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]any{
+				"created": 1700000000,
+				"data": []map[string]string{
+					{"url": "https://images.example.com/0.png"},
+					{"b64_json": base64.StdEncoding.EncodeToString(buf.Bytes())},
+				},
+			})
+		case "https://images.example.com/0.png":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("unexpected authorization header sent to image URL")
+			}
+			w.Write(buf.Bytes())
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	resp, err := c.CreateImage(ctx, &openai.CreateImageRequest{Prompt: "a gopher", N: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range resp.Data {
+		got, err := resp.Decode(i)
+		if err != nil {
+			t.Fatalf("failed to decode image %d: %v", i, err)
+		}
+		if got.Bounds() != img.Bounds() {
+			t.Fatalf("unexpected bounds of image %d: %v", i, got.Bounds())
+		}
+	}
+
+	if _, err := resp.Decode(2); err == nil {
+		t.Fatal("expected out of range error")
+	}
+
+	dir := t.TempDir()
+
+	paths, err := resp.SaveAll(ctx, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{filepath.Join(dir, "1700000000-0.png"), filepath.Join(dir, "1700000000-1.png")}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("expected paths %q, got %q", want, paths)
+	}
+
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, buf.Bytes()) {
+			t.Fatalf("unexpected contents of %s", path)
+		}
+	}
+}
+
+func TestCreateImages(t *testing.T) {
+	var (
+		mu             sync.Mutex
+		requests       int
+		inFlight, peak int
+	)
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req openai.CreateImageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if req.N != 1 || req.Model != openai.ModelDallE3 {
+			t.Errorf("unexpected request: %+v", req)
+		}
+
+		mu.Lock()
+		requests++
+		n := requests
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if n == 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error": {"message": "Your request was rejected.", "type": "invalid_request_error", "code": "content_policy_violation"}}`)
+			return
+		}
+
+		fmt.Fprintf(w, `{"created": %d, "data": [{"url": "https://example.com/%d.png", "revised_prompt": "a gopher, take %d"}]}`, n, n, n)
+	})
+
+	resp, err := c.CreateImages(testCtx(t), &openai.CreateImageRequest{
+		Model:  openai.ModelDallE3,
+		Prompt: "a gopher",
+	}, 5, &openai.CreateImagesOptions{Concurrency: 2})
+
+	var apiErr *openai.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected the failed image's error, got %v", err)
+	}
+
+	if requests != 5 || peak > 2 {
+		t.Fatalf("expected 5 requests, at most 2 at once, got %d with %d at once", requests, peak)
+	}
+
+	var failed int
+	for i, err := range resp.Errors {
+		if (err != nil) == (resp.Responses[i] != nil) {
+			t.Fatalf("expected either a response or an error for image %d", i)
+		}
+		if err != nil {
+			failed++
+		}
+	}
+
+	if failed != 1 {
+		t.Fatalf("expected 1 failed image, got %d", failed)
+	}
+
+	images := resp.Images()
+	if len(images.Data) != 4 || images.Created != 5 {
+		t.Fatalf("unexpected images: %+v", images)
+	}
+
+	prompts := resp.RevisedPrompts()
+	if n := slices.Index(prompts, ""); n < 0 || resp.Errors[n] == nil || !strings.HasPrefix(prompts[(n+1)%5], "a gopher, take") {
+		t.Fatalf("unexpected revised prompts: %q", prompts)
+	}
+}
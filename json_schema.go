@@ -0,0 +1,155 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// JSONSchemaFor returns the JSON schema of the Go type T, derived from its
+// structure with reflection, for use as function parameters or a structured
+// output schema.
+//
+// Struct fields are named by their "json" tags, following the rules of
+// encoding/json, and are required unless tagged "omitempty". Fields can be
+// described with a "description" tag, and restricted to a comma-separated list
+// of values with an "enum" tag.
+//
+// # Example
+//
+//	type WeatherArgs struct {
+//		Location string `json:"location" description:"The city, e.g. Paris"`
+//		Unit     string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+//	}
+//
+//	schema, _ := openai.JSONSchemaFor[WeatherArgs]()
+func JSONSchemaFor[T any]() (*JSONSchema, error) {
+	return jsonSchemaOf(reflect.TypeFor[T](), map[reflect.Type]bool{})
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	textMarshalerType = reflect.TypeFor[interface{ MarshalText() ([]byte, error) }]()
+)
+
+// jsonSchemaOf returns the schema of t. seen holds the struct types being
+// derived, to reject recursive types, which can't be described without refs.
+func jsonSchemaOf(t reflect.Type, seen map[reflect.Type]bool) (*JSONSchema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &JSONSchema{Type: "string"}, nil
+	case t == rawMessageType:
+		return &JSONSchema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &JSONSchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}, nil
+	case reflect.Interface:
+		return &JSONSchema{}, nil
+	case reflect.Slice, reflect.Array:
+		// Byte slices are encoded as base64 strings.
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &JSONSchema{Type: "string"}, nil
+		}
+
+		items, err := jsonSchemaOf(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+		return &JSONSchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String && !t.Key().Implements(textMarshalerType) {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+
+		values, err := jsonSchemaOf(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+		return &JSONSchema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if seen[t] {
+			return nil, fmt.Errorf("recursive type %s", t)
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		schema := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+		if err := addStructFields(schema, t, seen); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// addStructFields adds the fields of the struct type t to the object schema,
+// including the fields of embedded structs without a json name.
+func addStructFields(schema *JSONSchema, t reflect.Type, seen map[reflect.Type]bool) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := addStructFields(schema, ft, seen); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		prop, err := jsonSchemaOf(f.Type, seen)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+
+		prop.Description = f.Tag.Get("description")
+
+		if enum := f.Tag.Get("enum"); enum != "" {
+			prop.Enum = strings.Split(enum, ",")
+		}
+
+		schema.Properties[name] = prop
+
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return nil
+}
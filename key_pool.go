@@ -0,0 +1,237 @@
+package openai
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// PoolKey is an API key in a KeyPool, with the organization it belongs to.
+type PoolKey struct {
+	// Key is the API key.
+	//
+	// Required.
+	Key string
+
+	// Organization is the organization the key's requests are made for.
+	//
+	// Optional.
+	Organization string
+}
+
+// KeySelection is how a KeyPool selects the key for each request.
+type KeySelection string
+
+const (
+	// KeySelectionRoundRobin uses the available keys in turn.
+	KeySelectionRoundRobin KeySelection = "round_robin"
+
+	// KeySelectionLeastRecentlyThrottled uses the available key that was
+	// rate limited the longest time ago, or never, taking the keys in turn
+	// when tied.
+	KeySelectionLeastRecentlyThrottled KeySelection = "least_recently_throttled"
+)
+
+// KeyPool spreads the requests of a client over several API keys, such as
+// those of different organizations, for higher throughput. A key that is rate
+// limited is cooled down, and not used again until the time the API asked to
+// wait for has passed, while the request is sent again with the next available
+// key right away.
+//
+// If all keys are cooling down, the one available soonest is used. A KeyPool
+// is safe for concurrent use, and can be shared by clients.
+//
+// # Example
+//
+//	pool, err := openai.NewKeyPool(openai.KeySelectionLeastRecentlyThrottled,
+//		openai.PoolKey{Key: os.Getenv("OPENAI_API_KEY_1"), Organization: "org-1"},
+//		openai.PoolKey{Key: os.Getenv("OPENAI_API_KEY_2"), Organization: "org-2"},
+//	)
+//	if err != nil {
+//		return err
+//	}
+//
+//	c := openai.NewClient("", openai.WithKeyPool(pool))
+type KeyPool struct {
+	selection KeySelection
+
+	// Cooldown is how long a rate limited key isn't used for, if the API
+	// doesn't say how long to wait.
+	//
+	// Optional. Defaults to 30s.
+	Cooldown time.Duration
+
+	// mu guards keys and next, the index of the key to consider first.
+	mu   sync.Mutex
+	keys []*poolKey
+	next int
+}
+
+// poolKey is the state of a key in a KeyPool.
+type poolKey struct {
+	PoolKey
+
+	// coolUntil is when the key can be used again, and throttled when it was
+	// last rate limited.
+	coolUntil time.Time
+	throttled time.Time
+}
+
+// NewKeyPool returns a KeyPool of the given keys, selected as given.
+func NewKeyPool(selection KeySelection, keys ...PoolKey) (*KeyPool, error) {
+	switch selection {
+	case KeySelectionRoundRobin, KeySelectionLeastRecentlyThrottled:
+	default:
+		return nil, errors.New("invalid key selection: " + string(selection))
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("key pool has no keys")
+	}
+
+	p := &KeyPool{selection: selection}
+	for _, k := range keys {
+		if k.Key == "" {
+			return nil, errors.New("key pool has an empty key")
+		}
+		p.keys = append(p.keys, &poolKey{PoolKey: k})
+	}
+	return p, nil
+}
+
+// WithKeyPool is a ClientOption that authenticates requests with the keys of
+// the given pool, instead of the client's API key or TokenProvider. The
+// client's organization, if any, is sent with keys without their own, and its
+// admin key, if any, is still used for organization management requests.
+func WithKeyPool(pool *KeyPool) ClientOption {
+	return func(client *Client) {
+		client.keyPool = pool
+		client.Middleware = append(client.Middleware, pool.middleware(client))
+	}
+}
+
+// Available returns the number of keys that aren't cooling down.
+func (p *KeyPool) Available() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	var n int
+	for _, k := range p.keys {
+		if !now.Before(k.coolUntil) {
+			n++
+		}
+	}
+	return n
+}
+
+// middleware returns the middleware of the client that sends each request
+// with a key from the pool, sending it again with the next available key if
+// it's rate limited. Requests the client authenticates with its admin key are
+// sent as they are.
+func (p *KeyPool) middleware(c *Client) Middleware {
+	return func(next RoundTripperFunc) RoundTripperFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			if c.usesAdminKey(c.apiPath(r.URL)) {
+				return next(r)
+			}
+
+			body := r.Body
+
+			for tries := 1; ; tries++ {
+				key := p.take()
+
+				// The key is set on a copy of the request, so the client's
+				// organization is kept for keys without one.
+				req := r.Clone(r.Context())
+				req.Body = body
+
+				req.Header.Set("Authorization", "Bearer "+key.Key)
+				if key.Organization != "" {
+					req.Header.Set("OpenAI-Organization", key.Organization)
+				}
+
+				resp, err := next(req)
+				if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+					return resp, err
+				}
+
+				p.throttle(key, resp.Header)
+
+				if tries >= len(p.keys) || p.Available() == 0 || (r.Body != nil && r.GetBody == nil) {
+					return resp, nil
+				}
+
+				// Drain and close the body so the connection can be reused.
+				io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+				resp.Body.Close()
+
+				if r.GetBody != nil {
+					body, err = r.GetBody()
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+}
+
+// take returns the key to use for the next request.
+func (p *KeyPool) take() *poolKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	var best *poolKey
+	bestIndex := 0
+
+	for n := range p.keys {
+		i := (p.next + n) % len(p.keys)
+		k := p.keys[i]
+
+		switch {
+		case best == nil:
+		case now.Before(best.coolUntil):
+			// Prefer any key that's available, or the one available soonest.
+			if !k.coolUntil.Before(best.coolUntil) {
+				continue
+			}
+		case now.Before(k.coolUntil):
+			continue
+		case p.selection == KeySelectionLeastRecentlyThrottled:
+			if !k.throttled.Before(best.throttled) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		best, bestIndex = k, i
+	}
+
+	p.next = (bestIndex + 1) % len(p.keys)
+	return best
+}
+
+// throttle cools the key down after it was rate limited, for as long as the
+// response asks, or the pool's Cooldown.
+func (p *KeyPool) throttle(key *poolKey, h http.Header) {
+	d, ok := retryAfter(h)
+	if !ok {
+		d = p.Cooldown
+		if d <= 0 {
+			d = 30 * time.Second
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key.throttled = time.Now()
+	key.coolUntil = key.throttled.Add(d)
+}
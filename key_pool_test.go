@@ -0,0 +1,71 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestKeyPool(t *testing.T) {
+	var used []string
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		used = append(used, key+"/"+r.Header.Get("OpenAI-Organization"))
+
+		if key == "key-2" {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"message":"Rate limit reached","code":"rate_limit_exceeded"}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	pool, err := openai.NewKeyPool(openai.KeySelectionRoundRobin,
+		openai.PoolKey{Key: "key-1", Organization: "org-1"},
+		openai.PoolKey{Key: "key-2", Organization: "org-2"},
+		openai.PoolKey{Key: "key-3"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := openai.NewClient("",
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+		openai.WithOrganization("org-0"),
+		openai.WithAdminKey("admin-key"),
+		openai.WithKeyPool(pool),
+	)
+
+	for range 4 {
+		if _, err := c.ListModels(testCtx(t)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Organization management requests use the admin key.
+	if _, err := c.ListProjects(testCtx(t), &openai.ListProjectsRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The rate limited key is sent again with the next key, and skipped
+	// while it cools down. Keys without an organization use the client's.
+	want := []string{"key-1/org-1", "key-2/org-2", "key-3/org-0", "key-1/org-1", "key-3/org-0", "admin-key/org-0"}
+
+	if fmt.Sprint(used) != fmt.Sprint(want) {
+		t.Fatalf("unexpected keys used:\n got: %v\nwant: %v", used, want)
+	}
+
+	if n := pool.Available(); n != 2 {
+		t.Fatalf("expected 2 available keys, got %d", n)
+	}
+
+	if _, err := openai.NewKeyPool(openai.KeySelectionRoundRobin); err == nil {
+		t.Fatal("expected an error for a pool without keys")
+	}
+}
@@ -0,0 +1,242 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// maxLoggedBody is the maximum number of bytes of a body read for logging.
+const maxLoggedBody = 1 << 20
+
+// redacted replaces redacted values in logs.
+const redacted = "[REDACTED]"
+
+// contentKeys are the JSON keys of request fields holding message contents,
+// which are redacted from logged request bodies by default.
+var contentKeys = map[string]bool{
+	"content":      true,
+	"text":         true,
+	"input":        true,
+	"prompt":       true,
+	"instructions": true,
+	"arguments":    true,
+	"output":       true,
+}
+
+// loggedHeaders are the request headers known not to hold credentials, which
+// are logged as they are. All other headers are redacted.
+var loggedHeaders = map[string]bool{
+	"Accept":              true,
+	"Accept-Encoding":     true,
+	"Content-Type":        true,
+	"Content-Length":      true,
+	"User-Agent":          true,
+	"Idempotency-Key":     true,
+	"Openai-Beta":         true,
+	"Openai-Version":      true,
+	"Openai-Organization": true,
+	"Openai-Project":      true,
+}
+
+// LoggerOption configures the logging enabled by WithLogger.
+type LoggerOption func(*loggerConfig)
+
+type loggerConfig struct {
+	logContents bool
+}
+
+// LogMessageContents is a LoggerOption that includes message contents, such
+// as prompts and tool arguments, in logged request bodies instead of redacting them.
+func LogMessageContents() LoggerOption {
+	return func(c *loggerConfig) {
+		c.logContents = true
+	}
+}
+
+// WithLogger is a ClientOption that logs every API request to the given logger,
+// with its method, path, status code, latency, request ID and token usage.
+// Failed requests are logged at the warning level or above.
+//
+// At the debug level, request headers and JSON bodies are logged too. The
+// headers that may hold credentials, such as Authorization, are always
+// redacted, as are message contents unless LogMessageContents is given.
+//
+// # Example
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithLogger(slog.Default()))
+func WithLogger(logger *slog.Logger, opts ...LoggerOption) ClientOption {
+	var cfg loggerConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return WithMiddleware(func(next RoundTripperFunc) RoundTripperFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			ctx := r.Context()
+
+			if logger.Enabled(ctx, slog.LevelDebug) {
+				logger.LogAttrs(ctx, slog.LevelDebug, "openai request",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Any("headers", redactHeaders(r.Header)),
+					slog.Any("body", loggedRequestBody(r, cfg.logContents)),
+				)
+			}
+
+			start := time.Now()
+
+			resp, err := next(r)
+			if err != nil {
+				logger.LogAttrs(ctx, slog.LevelError, "openai request failed",
+					slog.String("method", r.Method),
+					slog.String("path", r.URL.Path),
+					slog.Duration("latency", time.Since(start)),
+					slog.Any("error", err),
+				)
+				return nil, err
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", resp.StatusCode),
+				slog.Duration("latency", time.Since(start)),
+				slog.String("request_id", resp.Header.Get("X-Request-Id")),
+			}
+
+			level := slog.LevelInfo
+			if resp.StatusCode >= 400 {
+				level = slog.LevelWarn
+			}
+
+			// Usage is only known once a JSON body is read, so log when it's closed.
+			if mediaType(resp.Header.Get("Content-Type")) == "application/json" {
+				resp.Body = &loggedBody{ReadCloser: resp.Body, log: func(body []byte) {
+					if usage := responseUsage(body); usage != nil {
+						attrs = append(attrs, slog.Any("usage", usage))
+					}
+					logger.LogAttrs(ctx, level, "openai request", attrs...)
+				}}
+				return resp, nil
+			}
+
+			logger.LogAttrs(ctx, level, "openai request", attrs...)
+			return resp, nil
+		}
+	})
+}
+
+// loggedBody buffers a response body as it is read, up to maxLoggedBody
+// bytes, and calls log with it once when closed.
+type loggedBody struct {
+	io.ReadCloser
+
+	buf  bytes.Buffer
+	log  func(body []byte)
+	once sync.Once
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if room := maxLoggedBody - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	b.once.Do(func() { b.log(b.buf.Bytes()) })
+	return b.ReadCloser.Close()
+}
+
+// mediaType returns the media type of a Content-Type header, without parameters.
+func mediaType(contentType string) string {
+	t, _, _ := mime.ParseMediaType(contentType)
+	return t
+}
+
+// responseUsage returns the token counts of the usage object of a response
+// body, or nil if it has none.
+func responseUsage(body []byte) map[string]int {
+	var payload struct {
+		Usage map[string]any `json:"usage"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Usage == nil {
+		return nil
+	}
+
+	usage := map[string]int{}
+	for k, v := range payload.Usage {
+		if n, ok := v.(float64); ok {
+			usage[k] = int(n)
+		}
+	}
+	return usage
+}
+
+// redactHeaders returns a copy of the headers with the values of all but the
+// loggedHeaders redacted, as any other header, such as Authorization or a
+// proxy's Api-Key, may hold credentials.
+func redactHeaders(h http.Header) http.Header {
+	h = h.Clone()
+	for k := range h {
+		if !loggedHeaders[http.CanonicalHeaderKey(k)] {
+			h[k] = []string{redacted}
+		}
+	}
+	return h
+}
+
+// loggedRequestBody returns the JSON body of the request for logging, with
+// message contents redacted unless logContents is set. It returns nil for
+// requests without a replayable JSON body.
+func loggedRequestBody(r *http.Request, logContents bool) any {
+	if r.GetBody == nil || mediaType(r.Header.Get("Content-Type")) != "application/json" {
+		return nil
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	var v any
+	if err := json.NewDecoder(io.LimitReader(body, maxLoggedBody)).Decode(&v); err != nil {
+		return nil
+	}
+
+	if !logContents {
+		v = redactContents(v, false)
+	}
+	return v
+}
+
+// redactContents replaces the strings within message content fields of the
+// decoded JSON value, keeping its structure. content reports whether v is
+// within a content field, in which case everything nested in it is redacted,
+// whatever its key.
+func redactContents(v any, content bool) any {
+	switch v := v.(type) {
+	case string:
+		if content {
+			return redacted
+		}
+	case map[string]any:
+		for k, child := range v {
+			v[k] = redactContents(child, content || contentKeys[k])
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactContents(child, content)
+		}
+	}
+	return v
+}
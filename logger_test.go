@@ -0,0 +1,89 @@
+package openai_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestWithLogger(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req_123")
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"Hi!"}}],"usage":{"prompt_tokens":9,"completion_tokens":2,"total_tokens":11}}`)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		opts     []openai.LoggerOption
+		contents bool
+	}{
+		{name: "redacted"},
+		{name: "contents", opts: []openai.LoggerOption{openai.LogMessageContents()}, contents: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+			c := openai.NewClient("sk-secret",
+				openai.WithHTTPClient(testClient(t, h).HTTPClient),
+				openai.WithLogger(logger, tt.opts...),
+				openai.WithHeaders(http.Header{"Api-Key": {"gateway-secret"}}),
+			)
+
+			_, err := c.CreateChat(testCtx(t), &openai.CreateChatRequest{
+				Model: openai.ModelGPT4,
+				Messages: []openai.ChatMessage{
+					openai.User("my secret prompt"),
+					openai.UserParts(openai.ImagePart("https://example.com/my-secret-image.png", openai.ImageDetailAuto)),
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out := buf.String()
+
+			if strings.Contains(out, "sk-secret") || strings.Contains(out, "gateway-secret") {
+				t.Fatalf("expected the API keys to be redacted:\n%s", out)
+			}
+
+			if !strings.Contains(out, `"Content-Type":["application/json"]`) {
+				t.Fatalf("expected the content type to be logged:\n%s", out)
+			}
+
+			// Everything within message contents is redacted, such as image URLs.
+			for _, content := range []string{"my secret prompt", "my-secret-image"} {
+				if got := strings.Contains(out, content); got != tt.contents {
+					t.Fatalf("expected %q logged: %v, got %v:\n%s", content, tt.contents, got, out)
+				}
+			}
+
+			lines := strings.Split(strings.TrimSpace(out), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("expected 2 log records, got %d:\n%s", len(lines), out)
+			}
+
+			var record struct {
+				Level     string         `json:"level"`
+				Path      string         `json:"path"`
+				Status    int            `json:"status"`
+				RequestID string         `json:"request_id"`
+				Usage     map[string]int `json:"usage"`
+			}
+			if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+				t.Fatal(err)
+			}
+
+			if record.Level != "INFO" || record.Path != "/v1/chat/completions" || record.Status != 200 || record.RequestID != "req_123" || record.Usage["total_tokens"] != 11 {
+				t.Fatalf("unexpected log record: %s", lines[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package openai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/picatz/openai/embeddings"
+)
+
+// MemoryStore stores chat messages by the embeddings of their text, for a
+// LongTermMemory to recall the messages most relevant to a new one.
+type MemoryStore interface {
+	// Put stores the message with the embedding of its text.
+	Put(ctx context.Context, message ChatMessage, embedding []float32) error
+
+	// Query returns the k stored messages most similar to the embedding, most
+	// similar first.
+	Query(ctx context.Context, embedding []float32, k int) ([]ChatMessage, error)
+}
+
+// VectorMemoryStore is a MemoryStore kept in memory by an embeddings.VectorIndex.
+//
+// Messages are stored by their role and text, so storing the same message
+// again replaces it.
+type VectorMemoryStore struct {
+	index *embeddings.VectorIndex
+}
+
+// NewVectorMemoryStore returns an empty VectorMemoryStore.
+func NewVectorMemoryStore() *VectorMemoryStore {
+	return &VectorMemoryStore{index: embeddings.NewVectorIndex()}
+}
+
+// Put implements the MemoryStore interface.
+func (s *VectorMemoryStore) Put(ctx context.Context, message ChatMessage, embedding []float32) error {
+	b, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return s.index.Add(memoryID(message), embedding, map[string]string{"message": string(b)})
+}
+
+// Query implements the MemoryStore interface.
+func (s *VectorMemoryStore) Query(ctx context.Context, embedding []float32, k int) ([]ChatMessage, error) {
+	results, err := s.index.Query(embedding, k, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]ChatMessage, len(results))
+	for i, r := range results {
+		if err := json.Unmarshal([]byte(r.Metadata["message"]), &messages[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode message %s: %w", r.ID, err)
+		}
+	}
+	return messages, nil
+}
+
+// memoryID returns the ID of a message in a MemoryStore, from its role and
+// text, so the same message is only stored once.
+func memoryID(message ChatMessage) string {
+	id := sha256.Sum256([]byte(message.Role + "\x00" + message.text()))
+	return hex.EncodeToString(id[:])
+}
+
+// recallPrefix starts the text of the system message holding the messages
+// recalled by a LongTermMemory.
+const recallPrefix = "Relevant messages from earlier in the conversation:\n\n"
+
+// LongTermMemory stores the messages of a ChatSession in a MemoryStore, and
+// recalls those most relevant to each new user message, as found by the
+// similarity of the embeddings of their text. Recalled messages are added to
+// the request as a system message, after the leading system messages, but
+// not to the session's history.
+//
+// It complements a ChatMemory, such as a WindowMemory, which drops older
+// messages from the history.
+//
+// # Example
+//
+//	session.Memory = &openai.WindowMemory{MaxMessages: 20}
+//	session.Recall = openai.NewLongTermMemory(client, openai.ModelTextEmbedding3Small, nil)
+type LongTermMemory struct {
+	client *Client
+	model  string
+	store  MemoryStore
+
+	// K is the maximum number of messages recalled for each request.
+	//
+	// Optional. Defaults to 3.
+	K int
+
+	// mu guards the last text queried and its embedding, reused when the
+	// message is stored.
+	mu            sync.Mutex
+	lastText      string
+	lastEmbedding []float32
+}
+
+// NewLongTermMemory returns a LongTermMemory that embeds messages with the given
+// client and embedding model, such as ModelTextEmbedding3Small, and keeps them
+// in the given store. If the store is nil, a new VectorMemoryStore is used.
+func NewLongTermMemory(c *Client, embeddingModel string, store MemoryStore) *LongTermMemory {
+	if store == nil {
+		store = NewVectorMemoryStore()
+	}
+
+	return &LongTermMemory{client: c, model: embeddingModel, store: store}
+}
+
+// Store returns the MemoryStore the messages are kept in.
+func (m *LongTermMemory) Store() MemoryStore {
+	return m.store
+}
+
+// inject returns the messages with those recalled for the last user message
+// added, leaving out any already among them.
+func (m *LongTermMemory) inject(ctx context.Context, messages []ChatMessage) ([]ChatMessage, error) {
+	var query string
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == ChatRoleUser {
+			query = messages[i].text()
+			break
+		}
+	}
+
+	if query == "" {
+		return messages, nil
+	}
+
+	embedding, err := m.embed(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	k := m.K
+	if k <= 0 {
+		k = 3
+	}
+
+	// Query enough messages to fill k after leaving out those in context.
+	results, err := m.store.Query(ctx, embedding, k+len(messages))
+	if err != nil {
+		return nil, fmt.Errorf("failed to recall messages: %w", err)
+	}
+
+	var b strings.Builder
+	for _, r := range results {
+		text := r.text()
+		if text == "" || slices.ContainsFunc(messages, func(m ChatMessage) bool { return m.Role == r.Role && m.text() == text }) {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s: %s\n", r.Role, text)
+
+		if k--; k == 0 {
+			break
+		}
+	}
+
+	if b.Len() == 0 {
+		return messages, nil
+	}
+
+	i := slices.IndexFunc(messages, func(m ChatMessage) bool { return m.Role != ChatRoleSystem })
+	if i < 0 {
+		i = len(messages)
+	}
+
+	return slices.Concat(messages[:i], []ChatMessage{System(recallPrefix + b.String())}, messages[i:]), nil
+}
+
+// remember stores the messages with text.
+func (m *LongTermMemory) remember(ctx context.Context, messages ...ChatMessage) error {
+	for _, msg := range messages {
+		text := msg.text()
+		if text == "" {
+			continue
+		}
+
+		embedding, err := m.embed(ctx, text)
+		if err != nil {
+			return err
+		}
+
+		if err := m.store.Put(ctx, msg, embedding); err != nil {
+			return fmt.Errorf("failed to store message: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// embed returns the embedding of the text, reusing that of the last text
+// embedded if it's the same.
+func (m *LongTermMemory) embed(ctx context.Context, text string) ([]float32, error) {
+	m.mu.Lock()
+	if text == m.lastText && m.lastEmbedding != nil {
+		embedding := m.lastEmbedding
+		m.mu.Unlock()
+		return embedding, nil
+	}
+	m.mu.Unlock()
+
+	resp, err := m.client.CreateEmbedding(ctx, &CreateEmbeddingRequest{Model: m.model, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed text: %w", err)
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, errors.New("failed to embed text: no embedding returned")
+	}
+
+	embedding := resp.Data[0].Float32()
+
+	m.mu.Lock()
+	m.lastText, m.lastEmbedding = text, embedding
+	m.mu.Unlock()
+
+	return embedding, nil
+}
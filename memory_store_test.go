@@ -0,0 +1,98 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestLongTermMemory(t *testing.T) {
+	var last openai.CreateChatRequest
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/embeddings") {
+			var req openai.CreateEmbeddingRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatal(err)
+			}
+
+			v := "[0, 1]"
+			if strings.Contains(strings.ToLower(req.Input), "color") {
+				v = "[1, 0]"
+			}
+			fmt.Fprintf(w, `{"data":[{"index":0,"embedding":%s}]}`, v)
+			return
+		}
+
+		last = openai.CreateChatRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&last); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprint(w, `{"choices": [{"message": {"role": "assistant", "content": "Noted."}, "index": 0}]}`)
+	})
+
+	session := openai.NewChatSession(c, openai.ModelGPT4o, openai.System("Be brief."))
+	session.Memory = &openai.WindowMemory{MaxMessages: 2}
+	session.Recall = openai.NewLongTermMemory(c, openai.ModelTextEmbedding3Small, nil)
+	session.Recall.K = 1
+
+	for _, text := range []string{"My favorite color is blue.", "It's sunny today.", "What's my favorite color?"} {
+		if _, err := session.Send(testCtx(t), text); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for _, m := range last.Messages {
+		got = append(got, m.Role+": "+m.Content)
+	}
+
+	want := []string{
+		"system: Be brief.",
+		"system: Relevant messages from earlier in the conversation:\n\nuser: My favorite color is blue.\n",
+		"assistant: Noted.",
+		"user: What's my favorite color?",
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected messages:\n%s", strings.Join(got, "\n"))
+	}
+
+	for _, m := range session.Messages() {
+		if strings.HasPrefix(m.Content, "Relevant messages") {
+			t.Fatalf("expected recalled messages to be left out of the history, got %+v", session.Messages())
+		}
+	}
+}
+
+func TestVectorMemoryStore(t *testing.T) {
+	ctx := testCtx(t)
+	store := openai.NewVectorMemoryStore()
+
+	for _, put := range []struct {
+		text      string
+		embedding []float32
+	}{
+		{"My favorite color is blue.", []float32{1, 0}},
+		{"It's sunny today.", []float32{0, 1}},
+		{"It's sunny today.", []float32{0.1, 1}},
+	} {
+		if err := store.Put(ctx, openai.User(put.text), put.embedding); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Storing the same message again replaces it.
+	messages, err := store.Query(ctx, []float32{0, 1}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(messages) != 2 || messages[0].Content != "It's sunny today." {
+		t.Fatalf("unexpected messages: %+v", messages)
+	}
+}
@@ -0,0 +1,177 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Types of MessageAnnotation.
+const (
+	// MessageAnnotationFileCitation cites a file found by the file_search tool.
+	MessageAnnotationFileCitation = "file_citation"
+
+	// MessageAnnotationFilePath links to a file created by the
+	// code_interpreter tool.
+	MessageAnnotationFilePath = "file_path"
+)
+
+// MessageAnnotation is an annotation of the text content of a thread message,
+// marking where it cites or links to a file.
+//
+// https://platform.openai.com/docs/api-reference/messages/object#messages/object-content
+type MessageAnnotation struct {
+	// Type is the type of the annotation, MessageAnnotationFileCitation or
+	// MessageAnnotationFilePath.
+	Type string `json:"type"`
+
+	// Text is the text of the annotation in the message, such as the marker
+	// "【4:0†source】" or a "sandbox:/mnt/data/file.csv" link.
+	Text string `json:"text"`
+
+	// StartIndex and EndIndex are the character offsets of the annotation in
+	// the message text.
+	StartIndex int `json:"start_index"`
+	EndIndex   int `json:"end_index"`
+
+	// FileCitation is the file cited by a "file_citation" annotation.
+	FileCitation *MessageFileCitation `json:"file_citation,omitempty"`
+
+	// FilePath is the file linked to by a "file_path" annotation.
+	FilePath *MessageFilePath `json:"file_path,omitempty"`
+}
+
+// MessageFileCitation is a file cited by a message.
+type MessageFileCitation struct {
+	FileID string `json:"file_id"`
+
+	// Quote is the quote from the file, if any.
+	Quote string `json:"quote,omitempty"`
+}
+
+// MessageFilePath is a file linked to by a message.
+type MessageFilePath struct {
+	FileID string `json:"file_id"`
+}
+
+// FileID returns the ID of the file the annotation refers to.
+func (a *MessageAnnotation) FileID() string {
+	switch {
+	case a.FileCitation != nil:
+		return a.FileCitation.FileID
+	case a.FilePath != nil:
+		return a.FilePath.FileID
+	}
+	return ""
+}
+
+// Annotations returns the annotations of the text content, or nil if it has
+// none, or isn't text.
+func (t ThreadMessageContent) Annotations() []MessageAnnotation {
+	b, err := json.Marshal(t["text"])
+	if err != nil {
+		return nil
+	}
+
+	var text struct {
+		Annotations []MessageAnnotation `json:"annotations"`
+	}
+	if err := json.Unmarshal(b, &text); err != nil {
+		return nil
+	}
+
+	return text.Annotations
+}
+
+// Citation is a file referred to by the annotations of a message.
+type Citation struct {
+	// Number is the number the file is cited by in the text, starting at 1.
+	Number int
+
+	// Type is the type of the annotations citing the file.
+	Type string
+
+	// FileID is the ID of the file.
+	FileID string
+
+	// FileName is the name of the file, set by Client.ResolveCitations.
+	FileName string
+
+	// Quote is the quote from the file, if any.
+	Quote string
+}
+
+// Citations returns the text content with its annotations replaced by numbered
+// citations, such as "[1]", along with the files cited, in order of their
+// numbers. Annotations referring to the same file share a number.
+//
+// # Example
+//
+//	text, citations := msg.Content[0].Citations()
+//
+//	if err := client.ResolveCitations(ctx, citations); err != nil {
+//		return err
+//	}
+//
+//	fmt.Println(text)
+//	for _, c := range citations {
+//		fmt.Printf("[%d] %s\n", c.Number, c.FileName)
+//	}
+func (t ThreadMessageContent) Citations() (string, []Citation) {
+	text := t.Text()
+
+	annotations := t.Annotations()
+	slices.SortStableFunc(annotations, func(a, b MessageAnnotation) int {
+		return a.StartIndex - b.StartIndex
+	})
+
+	var (
+		b         strings.Builder
+		citations []Citation
+		pos       int
+	)
+
+	for _, a := range annotations {
+		id := a.FileID()
+		if a.Text == "" || id == "" {
+			continue
+		}
+
+		i := strings.Index(text[pos:], a.Text)
+		if i < 0 {
+			continue
+		}
+
+		n := slices.IndexFunc(citations, func(c Citation) bool { return c.FileID == id })
+		if n < 0 {
+			n = len(citations)
+			citations = append(citations, Citation{Number: n + 1, Type: a.Type, FileID: id})
+		}
+
+		if a.FileCitation != nil && citations[n].Quote == "" {
+			citations[n].Quote = a.FileCitation.Quote
+		}
+
+		b.WriteString(text[pos : pos+i])
+		fmt.Fprintf(&b, "[%d]", n+1)
+		pos += i + len(a.Text)
+	}
+
+	b.WriteString(text[pos:])
+	return b.String(), citations
+}
+
+// ResolveCitations sets the FileName of each citation, retrieving the info of
+// the files cited.
+func (c *Client) ResolveCitations(ctx context.Context, citations []Citation) error {
+	for i := range citations {
+		info, err := c.GetFileInfo(ctx, &GetFileInfoRequest{ID: citations[i].FileID})
+		if err != nil {
+			return fmt.Errorf("failed to resolve citation %d: %w", citations[i].Number, err)
+		}
+		citations[i].FileName = info.Filename
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package openai
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// RoundTripperFunc is an http.RoundTripper implemented by a function, used to
+// send a request within a Middleware.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements the http.RoundTripper interface.
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// Middleware wraps the sending of API requests, such as to add headers, record
+// metrics, cache responses, or inject faults. It returns a function that sends
+// a request, typically by calling next.
+//
+// Middleware is applied to each attempt of a request, after the client's
+// authentication headers are set, so retried requests pass through it again.
+type Middleware func(next RoundTripperFunc) RoundTripperFunc
+
+// WithMiddleware is a ClientOption that adds middleware applied to every API
+// request. The first middleware given is the outermost, seeing each request
+// first and each response last.
+//
+// # Example
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithMiddleware(
+//		func(next openai.RoundTripperFunc) openai.RoundTripperFunc {
+//			return func(r *http.Request) (*http.Response, error) {
+//				start := time.Now()
+//				resp, err := next(r)
+//				log.Printf("%s %s took %s", r.Method, r.URL.Path, time.Since(start))
+//				return resp, err
+//			}
+//		},
+//	))
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(client *Client) {
+		client.Middleware = append(client.Middleware, middleware...)
+	}
+}
+
+// roundTrip sends a single attempt of the request with the client's HTTP
+// client, through its middleware, and records the response's metadata.
+// Gzip-encoded responses are decoded before the middleware sees them.
+func (c *Client) roundTrip(r *http.Request) (*http.Response, error) {
+	next := RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		resp, err := c.HTTPClient.Do(r)
+		if err != nil {
+			return nil, err
+		}
+		return decodeGzip(resp)
+	})
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		next = c.Middleware[i](next)
+	}
+
+	resp, err := next(r)
+	if err == nil {
+		recordResponseMetadata(r, resp)
+	}
+	return resp, err
+}
+
+// decodeGzip replaces the body of a gzip-encoded response with its decoded
+// contents. Closing the new body closes the original one too.
+func decodeGzip(resp *http.Response) (*http.Response, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp, nil
+	}
+
+	var body io.ReadCloser
+	zr, err := gzip.NewReader(resp.Body)
+	switch {
+	case err == io.EOF:
+		// An empty body has nothing to decode.
+		body = resp.Body
+	case err != nil:
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to decode gzip response: %w", err)
+	default:
+		body = &gzipBody{Reader: zr, body: resp.Body}
+	}
+
+	resp.Body = body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// gzipBody is a gzip-decoded response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
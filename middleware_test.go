@@ -0,0 +1,70 @@
+package openai_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	var order []string
+
+	tag := func(name string) openai.Middleware {
+		return func(next openai.RoundTripperFunc) openai.RoundTripperFunc {
+			return func(r *http.Request) (*http.Response, error) {
+				order = append(order, name+" before")
+				r.Header.Set("X-"+name, "1")
+				resp, err := next(r)
+				order = append(order, name+" after")
+				return resp, err
+			}
+		}
+	}
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-outer") != "1" || r.Header.Get("X-inner") != "1" {
+			t.Errorf("expected middleware headers, got %v", r.Header)
+		}
+		if r.Header.Get("Authorization") != "Bearer test" {
+			t.Errorf("expected authorization header to be set before middleware")
+		}
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	c := openai.NewClient("test",
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+		openai.WithMiddleware(tag("outer"), tag("inner")),
+	)
+
+	if _, err := c.ListModels(testCtx(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "outer before,inner before,inner after,outer after"
+	if got := strings.Join(order, ","); got != want {
+		t.Fatalf("unexpected middleware order: %s", got)
+	}
+}
+
+func TestWithMiddleware_FaultInjection(t *testing.T) {
+	injected := errors.New("injected")
+
+	c := openai.NewClient("test",
+		openai.WithHTTPClient(testClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("expected the request to be short-circuited")
+		}).HTTPClient),
+		openai.WithMiddleware(func(next openai.RoundTripperFunc) openai.RoundTripperFunc {
+			return func(r *http.Request) (*http.Response, error) {
+				return nil, injected
+			}
+		}),
+	)
+
+	if _, err := c.ListModels(testCtx(t)); !errors.Is(err, injected) {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+}
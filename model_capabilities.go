@@ -0,0 +1,109 @@
+package openai
+
+import "strings"
+
+// ChatModalityImage is image input, for models with vision. Images can't be
+// generated by chat models, so it is only used in ModelCapabilities.
+const ChatModalityImage ChatModality = "image"
+
+// ModelCapabilities describes what a model supports.
+//
+// https://platform.openai.com/docs/models
+type ModelCapabilities struct {
+	// ContextWindow is the maximum number of tokens, shared by the input and
+	// the output, the model can handle.
+	ContextWindow int
+
+	// MaxOutputTokens is the maximum number of tokens the model can generate
+	// in a response, including reasoning tokens, or 0 for models that don't
+	// generate text, such as embedding models.
+	MaxOutputTokens int
+
+	// InputModalities are the types of input the model accepts.
+	InputModalities []ChatModality
+
+	// OutputModalities are the types of output the model generates.
+	OutputModalities []ChatModality
+
+	// Tools reports whether the model supports function calling.
+	Tools bool
+
+	// Reasoning reports whether the model is a reasoning model; see IsReasoningModel.
+	Reasoning bool
+}
+
+// SupportsInput reports whether the model accepts the given type of input.
+func (c ModelCapabilities) SupportsInput(modality ChatModality) bool {
+	for _, m := range c.InputModalities {
+		if m == modality {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	textOnly     = []ChatModality{ChatModalityText}
+	textAndImage = []ChatModality{ChatModalityText, ChatModalityImage}
+	textAndAudio = []ChatModality{ChatModalityText, ChatModalityAudio}
+	audioOnly    = []ChatModality{ChatModalityAudio}
+)
+
+// modelCapabilities maps model name prefixes to their capabilities, checked
+// in order, so more specific prefixes come first. Capabilities are listed in
+// field order: context window, max output tokens, input and output
+// modalities, tools, and reasoning.
+var modelCapabilities = []struct {
+	prefix string
+	caps   ModelCapabilities
+}{
+	{"gpt-5-chat", ModelCapabilities{128000, 16384, textAndImage, textOnly, true, false}},
+	{"gpt-5", ModelCapabilities{400000, 128000, textAndImage, textOnly, true, true}},
+	{"gpt-4.1", ModelCapabilities{1047576, 32768, textAndImage, textOnly, true, false}},
+	{"gpt-4.5", ModelCapabilities{128000, 16384, textAndImage, textOnly, true, false}},
+	{"gpt-4o-audio", ModelCapabilities{128000, 16384, textAndAudio, textAndAudio, true, false}},
+	{"gpt-4o-mini-audio", ModelCapabilities{128000, 16384, textAndAudio, textAndAudio, true, false}},
+	{"gpt-4o-transcribe", ModelCapabilities{16000, 2000, textAndAudio, textOnly, false, false}},
+	{"gpt-4o-mini-transcribe", ModelCapabilities{16000, 2000, textAndAudio, textOnly, false, false}},
+	{"gpt-4o-mini-tts", ModelCapabilities{2000, 0, textOnly, audioOnly, false, false}},
+	{"gpt-4o", ModelCapabilities{128000, 16384, textAndImage, textOnly, true, false}},
+	{"chatgpt-4o", ModelCapabilities{128000, 16384, textAndImage, textOnly, false, false}},
+	{"o1-mini", ModelCapabilities{128000, 65536, textOnly, textOnly, false, true}},
+	{"o1-preview", ModelCapabilities{128000, 32768, textOnly, textOnly, false, true}},
+	{"o1", ModelCapabilities{200000, 100000, textAndImage, textOnly, true, true}},
+	{"o3-mini", ModelCapabilities{200000, 100000, textOnly, textOnly, true, true}},
+	{"o3", ModelCapabilities{200000, 100000, textAndImage, textOnly, true, true}},
+	{"o4-mini", ModelCapabilities{200000, 100000, textAndImage, textOnly, true, true}},
+	{"gpt-4-turbo", ModelCapabilities{128000, 4096, textAndImage, textOnly, true, false}},
+	{"gpt-4-1106", ModelCapabilities{128000, 4096, textOnly, textOnly, true, false}},
+	{"gpt-4-0125", ModelCapabilities{128000, 4096, textOnly, textOnly, true, false}},
+	{"gpt-4-vision", ModelCapabilities{128000, 4096, textAndImage, textOnly, false, false}},
+	{"gpt-4-32k", ModelCapabilities{32768, 4096, textOnly, textOnly, false, false}},
+	{"gpt-4", ModelCapabilities{8192, 8192, textOnly, textOnly, true, false}},
+	{"gpt-3.5-turbo-instruct", ModelCapabilities{4096, 4096, textOnly, textOnly, false, false}},
+	{"gpt-3.5-turbo-0301", ModelCapabilities{4096, 4096, textOnly, textOnly, false, false}},
+	{"gpt-3.5-turbo-0613", ModelCapabilities{4096, 4096, textOnly, textOnly, true, false}},
+	{"gpt-3.5-turbo", ModelCapabilities{16385, 4096, textOnly, textOnly, true, false}},
+	{"text-embedding-3", ModelCapabilities{8191, 0, textOnly, nil, false, false}},
+	{"text-embedding-ada-002", ModelCapabilities{8191, 0, textOnly, nil, false, false}},
+}
+
+// Capabilities returns the capabilities of the given model, or false if they
+// aren't known. Fine-tuned models have the capabilities of their base model.
+//
+// # Example
+//
+//	if caps, ok := openai.Capabilities(model); ok && !caps.SupportsInput(openai.ChatModalityImage) {
+//		return fmt.Errorf("model %s doesn't support images", model)
+//	}
+func Capabilities(model string) (ModelCapabilities, bool) {
+	base := strings.TrimPrefix(model, "ft:")
+
+	for _, m := range modelCapabilities {
+		if strings.HasPrefix(base, m.prefix) {
+			return m.caps, true
+		}
+	}
+
+	return ModelCapabilities{}, false
+}
@@ -0,0 +1,81 @@
+package openai
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// modelCache is a cached model catalog.
+type modelCache struct {
+	mu        sync.Mutex
+	models    *Models
+	fetchedAt time.Time
+}
+
+// WithModelCache is a ClientOption that caches the model catalog returned by
+// ListModels for the given duration, after which it is fetched again when
+// needed, so frequent model lookups, such as with SupportsModel, don't each
+// make a request.
+func WithModelCache(ttl time.Duration) ClientOption {
+	return func(client *Client) {
+		client.ModelCacheTTL = ttl
+	}
+}
+
+// RefreshModels fetches the model catalog, replacing the cached one if the
+// client caches it, such as after fine-tuning a new model.
+func (c *Client) RefreshModels(ctx context.Context) (*Models, error) {
+	return c.cachedModels(ctx, true)
+}
+
+// SupportsModel reports whether the given model, such as "gpt-4o" or a
+// fine-tuned model, is available to the client's organization, according to
+// the model catalog.
+//
+// # Example
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithModelCache(time.Hour))
+//
+//	ok, err := c.SupportsModel(ctx, openai.ModelGPT4o)
+//	if err != nil {
+//		return err
+//	}
+//	if !ok {
+//		return fmt.Errorf("model %s isn't available", openai.ModelGPT4o)
+//	}
+func (c *Client) SupportsModel(ctx context.Context, id string) (bool, error) {
+	models, err := c.ListModels(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(models.Data, func(m ModelInfo) bool { return m.ID == id }), nil
+}
+
+// cachedModels returns the cached model catalog while it is fresh, or fetches
+// and caches it otherwise, or if refresh is set. Callers get their own copy of
+// the list, so they can't modify the cached one.
+func (c *Client) cachedModels(ctx context.Context, refresh bool) (*Models, error) {
+	c.models.mu.Lock()
+	defer c.models.mu.Unlock()
+
+	if refresh || c.models.models == nil || time.Since(c.models.fetchedAt) >= c.ModelCacheTTL {
+		models, err := c.listModels(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.ModelCacheTTL <= 0 {
+			return models, nil
+		}
+
+		c.models.models = models
+		c.models.fetchedAt = time.Now()
+	}
+
+	models := *c.models.models
+	models.Data = slices.Clone(models.Data)
+	return &models, nil
+}
@@ -187,20 +187,51 @@ const (
 	ModelGPT4VisionPreview Model = "gpt-4-vision-preview"
 	ModelGPT40125Preview   Model = "gpt-4-0125-preview"
 	ModelGPT4TurboPreview  Model = "gpt-4-turbo-preview"
+	ModelGPT4Turbo         Model = "gpt-4-turbo"
+
+	ModelGPT4o                 Model = "gpt-4o"
+	ModelGPT4oMini             Model = "gpt-4o-mini"
+	ModelChatGPT4oLatest       Model = "chatgpt-4o-latest"
+	ModelGPT4oAudioPreview     Model = "gpt-4o-audio-preview"
+	ModelGPT4oMiniAudioPreview Model = "gpt-4o-mini-audio-preview"
+
+	ModelGPT41     Model = "gpt-4.1"
+	ModelGPT41Mini Model = "gpt-4.1-mini"
+	ModelGPT41Nano Model = "gpt-4.1-nano"
+
+	ModelGPT5           Model = "gpt-5"
+	ModelGPT5Mini       Model = "gpt-5-mini"
+	ModelGPT5Nano       Model = "gpt-5-nano"
+	ModelGPT5ChatLatest Model = "gpt-5-chat-latest"
+
+	ModelO1     Model = "o1"
+	ModelO1Mini Model = "o1-mini"
+	ModelO1Pro  Model = "o1-pro"
+	ModelO3     Model = "o3"
+	ModelO3Mini Model = "o3-mini"
+	ModelO3Pro  Model = "o3-pro"
+	ModelO4Mini Model = "o4-mini"
 
 	ModelWhisper1 Model = "whisper-1"
 
+	ModelGPT4oTranscribe     Model = "gpt-4o-transcribe"
+	ModelGPT4oMiniTranscribe Model = "gpt-4o-mini-transcribe"
+
 	ModelTTS1       Model = "tts-1"
 	ModelTTS11106   Model = "tts-1-1106"
 	ModelTTS1HD     Model = "tts-1-hd"
 	ModelTTS1HD1106 Model = "tts-1-hd-1106"
 
+	ModelGPT4oMiniTTS Model = "gpt-4o-mini-tts"
+
 	ModelTextModeration007    Model = "text-moderation-007"
 	ModelTextModerationLatest Model = "text-moderation-latest"
 	ModelTextModerationStable Model = "text-moderation-stable"
 
+	ModelOmniModerationLatest Model = "omni-moderation-latest"
+
 	ModelDallE2 Model = "dall-e-2"
 	ModelDallE3 Model = "dall-e-3"
 
-	// TODO: add more "known" models.
+	ModelGPTImage1 Model = "gpt-image-1"
 )
@@ -0,0 +1,145 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestGetModel(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/models/gpt-4" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{"id": "gpt-4", "object": "model", "created": 1687882411, "owned_by": "openai"}`)
+	})
+
+	model, err := c.GetModel(testCtx(t), openai.ModelGPT4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if model.ID != "gpt-4" || model.OwnedBy != "openai" || model.Created != 1687882411 {
+		t.Fatalf("unexpected model: %#+v", model)
+	}
+}
+
+func TestDeleteModel(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/v1/models/ft:gpt-4o-mini:acemeco:suffix:abc123" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{"id": "ft:gpt-4o-mini:acemeco:suffix:abc123", "object": "model", "deleted": true}`)
+	})
+
+	resp, err := c.DeleteModel(testCtx(t), "ft:gpt-4o-mini:acemeco:suffix:abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !resp.Deleted {
+		t.Fatalf("expected model to be deleted: %#+v", resp)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	caps, ok := openai.Capabilities(openai.ModelGPT4oMini)
+	if !ok || caps.ContextWindow != 128000 || caps.MaxOutputTokens != 16384 || !caps.Tools || caps.Reasoning {
+		t.Fatalf("unexpected gpt-4o-mini capabilities: %+v", caps)
+	}
+
+	if !caps.SupportsInput(openai.ChatModalityImage) || caps.SupportsInput(openai.ChatModalityAudio) {
+		t.Fatalf("unexpected gpt-4o-mini input modalities: %v", caps.InputModalities)
+	}
+
+	caps, ok = openai.Capabilities(openai.ModelGPT4oAudioPreview)
+	if !ok || !caps.SupportsInput(openai.ChatModalityAudio) {
+		t.Fatalf("unexpected gpt-4o-audio-preview capabilities: %+v", caps)
+	}
+
+	caps, ok = openai.Capabilities("ft:" + openai.ModelO3Mini + ":org::id")
+	if !ok || !caps.Reasoning || caps.SupportsInput(openai.ChatModalityImage) {
+		t.Fatalf("unexpected fine-tuned o3-mini capabilities: %+v", caps)
+	}
+
+	caps, ok = openai.Capabilities(openai.ModelTextEmbedding3Small)
+	if !ok || caps.MaxOutputTokens != 0 {
+		t.Fatalf("unexpected text-embedding-3-small capabilities: %+v", caps)
+	}
+
+	// Speech and transcription models aren't chat models, despite their names.
+	caps, ok = openai.Capabilities(openai.ModelGPT4oMiniTTS)
+	if !ok || caps.ContextWindow != 2000 || caps.Tools || caps.SupportsInput(openai.ChatModalityImage) {
+		t.Fatalf("unexpected gpt-4o-mini-tts capabilities: %+v", caps)
+	}
+
+	caps, ok = openai.Capabilities(openai.ModelGPT4oTranscribe)
+	if !ok || caps.ContextWindow != 16000 || caps.Tools || !caps.SupportsInput(openai.ChatModalityAudio) {
+		t.Fatalf("unexpected gpt-4o-transcribe capabilities: %+v", caps)
+	}
+
+	if _, ok := openai.Capabilities(openai.ModelDavinci); ok {
+		t.Fatal("expected unknown capabilities for davinci")
+	}
+}
+
+func TestWithModelCache(t *testing.T) {
+	var requests int
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/models" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"gpt-4o","object":"model"},{"id":"ft:gpt-4o-mini:org::abc","object":"model"}]}`)
+	}
+
+	c := openai.NewClient("test",
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+		openai.WithModelCache(time.Hour),
+	)
+
+	ctx := testCtx(t)
+
+	for _, tt := range []struct {
+		id   string
+		want bool
+	}{
+		{openai.ModelGPT4o, true},
+		{"ft:gpt-4o-mini:org::abc", true},
+		{openai.ModelGPT4, false},
+	} {
+		got, err := c.SupportsModel(ctx, tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("SupportsModel(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+
+	models, err := c.ListModels(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Modifying the returned list doesn't affect the cache.
+	models.Data[0].ID = "modified"
+
+	if ok, _ := c.SupportsModel(ctx, openai.ModelGPT4o); !ok || requests != 1 {
+		t.Fatalf("expected the cached catalog to be used, got %d requests", requests)
+	}
+
+	if _, err := c.RefreshModels(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected the catalog to be refreshed, got %d requests", requests)
+	}
+}
@@ -0,0 +1,184 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ModerationInputPart is a text or image input to moderate with an
+// omni-moderation model.
+//
+// https://platform.openai.com/docs/api-reference/moderations/create#moderations-create-input
+type ModerationInputPart struct {
+	// Type is the type of the part, either "text" or "image_url".
+	//
+	// Required.
+	Type string `json:"type"`
+
+	// Text is the text of a "text" part.
+	Text string `json:"text,omitempty"`
+
+	// ImageURL is the image of an "image_url" part.
+	ImageURL *ModerationImageURL `json:"image_url,omitempty"`
+}
+
+// ModerationImageURL is an image to moderate.
+type ModerationImageURL struct {
+	// URL is either the URL of the image, or the base64 encoded image data as
+	// a data URL.
+	//
+	// Required.
+	URL string `json:"url"`
+}
+
+// ModerationText returns a text input part.
+func ModerationText(text string) ModerationInputPart {
+	return ModerationInputPart{Type: "text", Text: text}
+}
+
+// ModerationImage returns an image input part for the given URL or data URL.
+func ModerationImage(url string) ModerationInputPart {
+	return ModerationInputPart{Type: "image_url", ImageURL: &ModerationImageURL{URL: url}}
+}
+
+// MarshalJSON marshals the request, sending Inputs or InputParts as the input
+// if either is set.
+func (r CreateModerationRequest) MarshalJSON() ([]byte, error) {
+	type request CreateModerationRequest
+
+	var input any = r.Input
+
+	switch {
+	case len(r.Inputs) > 0 && len(r.InputParts) > 0:
+		return nil, errors.New("only one of Inputs and InputParts can be set")
+	case len(r.Inputs) > 0:
+		input = r.Inputs
+	case len(r.InputParts) > 0:
+		input = r.InputParts
+	}
+
+	return json.Marshal(struct {
+		request
+		Input any `json:"input"`
+	}{
+		request: request(r),
+		Input:   input,
+	})
+}
+
+// Flagged returns the names of the flagged categories, such as "harassment"
+// or "self-harm/intent", in alphabetical order.
+func (c ModerationCategories) Flagged() []string {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+
+	var categories map[string]bool
+	if err := json.Unmarshal(b, &categories); err != nil {
+		return nil
+	}
+
+	var names []string
+	for name, flagged := range categories {
+		if flagged {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ModerationError is returned by CreateChat when auto moderation is enabled
+// with WithAutoModeration, and user messages of the request were flagged.
+type ModerationError struct {
+	// Messages are the indexes of the flagged messages in the request.
+	Messages []int
+
+	// Results are the moderation results of the flagged messages, in the
+	// same order as Messages.
+	Results []ModerationResult
+}
+
+// Error implements the error interface.
+func (e *ModerationError) Error() string {
+	flagged := make([]string, len(e.Messages))
+	for i, index := range e.Messages {
+		flagged[i] = fmt.Sprintf("message %d (%s)", index, strings.Join(e.Results[i].Categories.Flagged(), ", "))
+	}
+	return "chat request flagged by moderation: " + strings.Join(flagged, ", ")
+}
+
+// WithAutoModeration is a ClientOption that runs the text of the user messages
+// of every chat request through CreateModeration before sending it. If any is
+// flagged, a *ModerationError is returned instead, and the request never
+// reaches the model.
+//
+// Images and audio in messages aren't moderated.
+//
+// # Example
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithAutoModeration())
+//
+//	_, err := c.CreateChat(ctx, req)
+//
+//	var modErr *openai.ModerationError
+//	if errors.As(err, &modErr) {
+//		// Tell the user their message can't be answered.
+//	}
+func WithAutoModeration() ClientOption {
+	return func(c *Client) {
+		c.AutoModeration = true
+	}
+}
+
+// moderateChat runs the text of the user messages of the request through
+// CreateModeration, returning a *ModerationError if any are flagged.
+func (c *Client) moderateChat(ctx context.Context, req *CreateChatRequest) error {
+	var (
+		inputs  []string
+		indexes []int
+	)
+
+	for i, m := range req.Messages {
+		if m.Role != ChatRoleUser {
+			continue
+		}
+
+		if text := m.text(); text != "" {
+			inputs = append(inputs, text)
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	resp, err := c.CreateModeration(ctx, &CreateModerationRequest{Inputs: inputs})
+	if err != nil {
+		return fmt.Errorf("failed to moderate chat request: %w", err)
+	}
+
+	if len(resp.Results) != len(inputs) {
+		return fmt.Errorf("failed to moderate chat request: expected %d results, got %d", len(inputs), len(resp.Results))
+	}
+
+	var modErr ModerationError
+	for i, result := range resp.Results {
+		if result.Flagged {
+			modErr.Messages = append(modErr.Messages, indexes[i])
+			modErr.Results = append(modErr.Results, result)
+		}
+	}
+
+	if len(modErr.Messages) > 0 {
+		return &modErr
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestCreateModeration_Omni(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		want := `{"model":"omni-moderation-latest","input":[{"type":"text","text":"look at this"},{"type":"image_url","image_url":{"url":"https://example.com/a.png"}}]}`
+		if string(body) != want {
+			t.Errorf("expected body %s, got %s", want, body)
+		}
+
+		fmt.Fprint(w, `{
+			"id": "modr-abc",
+			"model": "omni-moderation-latest",
+			"results": [{
+				"flagged": true,
+				"categories": {"illicit": true, "self-harm/intent": false, "violence": true},
+				"category_scores": {"illicit": 0.9, "harassment": 0.01, "violence": 0.8},
+				"category_applied_input_types": {"illicit": ["text"], "violence": ["text", "image"]}
+			}]
+		}`)
+	})
+
+	resp, err := c.CreateModeration(testCtx(t), &openai.CreateModerationRequest{
+		Model: openai.ModelOmniModerationLatest,
+		InputParts: []openai.ModerationInputPart{
+			openai.ModerationText("look at this"),
+			openai.ModerationImage("https://example.com/a.png"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := resp.Results[0]
+
+	if !result.Flagged || !result.Categories.Illicit || result.CategoryScores.Illicit != 0.9 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if types := result.CategoryAppliedInputTypes["violence"]; len(types) != 2 || types[1] != "image" {
+		t.Fatalf("unexpected applied input types: %v", result.CategoryAppliedInputTypes)
+	}
+}
+
+func TestCreateModeration_Batch(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		results := make([]map[string]any, len(body.Input))
+		for i, input := range body.Input {
+			results[i] = map[string]any{"flagged": input == "bad"}
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"id": "modr-abc", "results": results})
+	})
+
+	ctx := testCtx(t)
+
+	resp, err := c.CreateModeration(ctx, &openai.CreateModerationRequest{
+		Inputs: []string{"good", "bad", "fine", "bad"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Results) != 4 || !resp.Flagged() {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+
+	if got := resp.FlaggedIndexes(); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Fatalf("unexpected flagged indexes: %v", got)
+	}
+
+	_, err = c.CreateModeration(ctx, &openai.CreateModerationRequest{
+		Inputs:     []string{"a"},
+		InputParts: []openai.ModerationInputPart{openai.ModerationText("b")},
+	})
+	if err == nil {
+		t.Fatal("expected error when setting both Inputs and InputParts")
+	}
+}
+
+func TestWithAutoModeration(t *testing.T) {
+	var chats int
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/moderations":
+			var body struct {
+				Input []string `json:"input"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(body.Input) != 2 || body.Input[0] != "Hi" || body.Input[1] != "something bad\nreally" {
+				t.Errorf("unexpected moderation input: %q", body.Input)
+			}
+
+			flagged := body.Input[1] == "something bad\nreally"
+			fmt.Fprintf(w, `{"results":[{"flagged":false},{"flagged":%t,"categories":{"violence":%t,"harassment":%t}}]}`, flagged, flagged, flagged)
+		case "/v1/chat/completions":
+			chats++
+			fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"Hello!"}}]}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}
+
+	c := openai.NewClient("test", openai.WithAutoModeration(), openai.WithHTTPClient(testClient(t, h).HTTPClient))
+
+	_, err := c.CreateChat(testCtx(t), &openai.CreateChatRequest{
+		Model: openai.ModelGPT4o,
+		Messages: []openai.ChatMessage{
+			openai.System("Be nice."),
+			openai.User("Hi"),
+			openai.AssistantMsg("Hello!"),
+			openai.UserParts(openai.TextPart("something bad"), openai.TextPart("really")),
+		},
+	})
+
+	var modErr *openai.ModerationError
+	if !errors.As(err, &modErr) {
+		t.Fatalf("expected moderation error, got %v", err)
+	}
+
+	if len(modErr.Messages) != 1 || modErr.Messages[0] != 3 {
+		t.Errorf("unexpected flagged messages: %v", modErr.Messages)
+	}
+
+	if want := "chat request flagged by moderation: message 3 (harassment, violence)"; err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err)
+	}
+
+	if chats != 0 {
+		t.Errorf("flagged request was sent to the model")
+	}
+}
@@ -0,0 +1,160 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/http"
+)
+
+// multipartForm is a multipart form request body, whose files are streamed to
+// the API rather than buffered in memory.
+type multipartForm struct {
+	files  []multipartFile
+	fields []multipartField
+}
+
+// multipartFile is a file part of a multipartForm.
+type multipartFile struct {
+	field, name string
+	r           io.Reader
+}
+
+// multipartField is a value part of a multipartForm. A field can be given
+// more than once, such as for arrays.
+type multipartField struct {
+	name, value string
+}
+
+// addFile adds a file part with the given field name and file name.
+func (f *multipartForm) addFile(field, name string, r io.Reader) {
+	f.files = append(f.files, multipartFile{field: field, name: name, r: r})
+}
+
+// addField adds a value part, unless the value is empty.
+func (f *multipartForm) addField(name, value string) {
+	if value == "" {
+		return
+	}
+	f.fields = append(f.fields, multipartField{name: name, value: value})
+}
+
+// write writes the form to w, closing it when done. Without withFiles, the
+// contents of file parts are left out, to measure the rest of the form.
+func (f *multipartForm) write(w *multipart.Writer, withFiles bool) error {
+	for _, file := range f.files {
+		fw, err := w.CreateFormFile(file.field, file.name)
+		if err != nil {
+			return err
+		}
+
+		if !withFiles {
+			continue
+		}
+
+		if _, err := io.Copy(fw, file.r); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range f.fields {
+		if err := w.WriteField(field.name, field.value); err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+// contentLength returns the length of the form encoded with the given
+// boundary, or -1 if the size of any of its files isn't known.
+func (f *multipartForm) contentLength(boundary string) int64 {
+	var n int64
+	for _, file := range f.files {
+		size := readerSize(file.r)
+		if size < 0 {
+			return -1
+		}
+		n += size
+	}
+
+	var cw countingWriter
+	w := multipart.NewWriter(&cw)
+	if err := w.SetBoundary(boundary); err != nil {
+		return -1
+	}
+	if err := f.write(w, false); err != nil {
+		return -1
+	}
+
+	return n + cw.n
+}
+
+// readerSize returns the number of bytes left to read from r, if it's an
+// in-memory reader or a regular file, or -1 otherwise.
+func readerSize(r io.Reader) int64 {
+	switch r := r.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case interface {
+		io.Seeker
+		Stat() (fs.FileInfo, error)
+	}:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+
+		return max(info.Size()-offset, 0)
+	}
+	return -1
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// sizedReader is a request body of a known length, which doRequest sends as
+// its Content-Length.
+type sizedReader struct {
+	io.ReadCloser
+	size int64
+}
+
+// doMultipart sends the form as a POST request against the given API path,
+// streaming it through a pipe. Its Content-Length is sent when the sizes of
+// all of its files are known. As the body can't be replayed, the request
+// isn't retried.
+func (c *Client) doMultipart(ctx context.Context, path string, form *multipartForm) (*http.Response, error) {
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+
+	var body io.Reader = pr
+	if size := form.contentLength(w.Boundary()); size >= 0 {
+		body = &sizedReader{ReadCloser: pr, size: size}
+	}
+
+	go func() {
+		pw.CloseWithError(form.write(w, true))
+	}()
+
+	resp, err := c.doRequest(ctx, http.MethodPost, path, w.FormDataContentType(), body)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,130 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// ErrNoMorePages is returned by Page.NextPage when there are no more pages.
+var ErrNoMorePages = errors.New("no more pages")
+
+// Page is a single page of a cursor-paginated list response.
+//
+// Use NextPage to fetch the following page, All to iterate over every item
+// across all remaining pages, or Pages to iterate over the pages themselves.
+// ListAll iterates over a whole list, starting with its first page.
+//
+// https://platform.openai.com/docs/api-reference/pagination
+type Page[T any] struct {
+	Object  string `json:"object"`
+	Data    []T    `json:"data"`
+	FirstID string `json:"first_id"`
+	LastID  string `json:"last_id"`
+	HasMore bool   `json:"has_more"`
+
+	// next fetches the page after the given cursor, using the same request
+	// parameters that fetched this page. It is nil for lists that can't be
+	// paginated.
+	next func(ctx context.Context, after string) (*Page[T], error)
+}
+
+// NextPage fetches the page after this one, returning ErrNoMorePages if this is
+// the last page.
+func (p *Page[T]) NextPage(ctx context.Context) (*Page[T], error) {
+	if p.next == nil || !p.HasMore || p.LastID == "" {
+		return nil, ErrNoMorePages
+	}
+
+	return p.next(ctx, p.LastID)
+}
+
+// All returns an iterator over the items in this page and every page after it,
+// fetching pages as needed. Iteration stops after the first error.
+//
+// # Example
+//
+//	page, _ := c.ListAssistants(ctx, &openai.ListAssistantsRequest{})
+//
+//	for assistant, err := range page.All(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(assistant.ID)
+//	}
+func (p *Page[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range p.Pages(ctx) {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Pages returns an iterator over this page and every page after it, fetching
+// pages as needed. Iteration stops after the first error.
+func (p *Page[T]) Pages(ctx context.Context) iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		for page := p; ; {
+			if !yield(page, nil) {
+				return
+			}
+
+			next, err := page.NextPage(ctx)
+			if errors.Is(err, ErrNoMorePages) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			page = next
+		}
+	}
+}
+
+// ListAll returns an iterator over every item of a paginated list, calling
+// list with req to fetch the first page when iteration starts, and following
+// the cursors of the pages after it until there are no more. Iteration stops
+// after the first error.
+//
+// # Example
+//
+//	for run, err := range openai.ListAll(ctx, &openai.ListRunsRequest{ThreadID: thread.ID}, c.ListRuns) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(run.ID, run.Status)
+//	}
+func ListAll[R, T any](ctx context.Context, req *R, list func(context.Context, *R) (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page, err := list(ctx, req)
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+
+		page.All(ctx)(yield)
+	}
+}
+
+// paginate sets the function used to fetch the page after p, by calling list
+// with a copy of req whose cursor is set by setAfter.
+func paginate[T, R any](p *Page[T], req *R, setAfter func(*R, string), list func(context.Context, *R) (*Page[T], error)) {
+	p.next = func(ctx context.Context, after string) (*Page[T], error) {
+		r := *req
+		setAfter(&r, after)
+		return list(ctx, &r)
+	}
+}
@@ -0,0 +1,192 @@
+package openai_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestPage_All(t *testing.T) {
+	var afters []string
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("expected limit to be kept across pages, got %q", got)
+		}
+
+		switch after {
+		case "":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"asst_1"},{"id":"asst_2"}],"first_id":"asst_1","last_id":"asst_2","has_more":true}`)
+		case "asst_2":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"asst_3"}],"first_id":"asst_3","last_id":"asst_3","has_more":false}`)
+		default:
+			t.Errorf("unexpected cursor: %q", after)
+		}
+	}
+
+	c := testClient(t, h)
+
+	ctx := testCtx(t)
+
+	page, err := c.ListAssistants(ctx, &openai.ListAssistantsRequest{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for assistant, err := range page.All(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, assistant.ID)
+	}
+
+	if fmt.Sprint(ids) != "[asst_1 asst_2 asst_3]" {
+		t.Fatalf("unexpected ids: %v", ids)
+	}
+
+	if fmt.Sprint(afters) != "[ asst_2]" {
+		t.Fatalf("unexpected cursors: %q", afters)
+	}
+
+	next, err := page.NextPage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := next.NextPage(ctx); !errors.Is(err, openai.ErrNoMorePages) {
+		t.Fatalf("expected ErrNoMorePages, got %v", err)
+	}
+}
+
+func TestPage_AllError(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("after") != "" {
+			http.Error(w, `{"error":{"message":"boom"}}`, http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"run_1"}],"last_id":"run_1","has_more":true}`)
+	}
+
+	c := testClient(t, h)
+
+	ctx := testCtx(t)
+
+	page, err := c.ListRuns(ctx, &openai.ListRunsRequest{ThreadID: "thread_1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		runs int
+		errs []error
+	)
+	for _, err := range page.All(ctx) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		runs++
+	}
+
+	if runs != 1 || len(errs) != 1 {
+		t.Fatalf("expected 1 run and 1 error, got %d runs and %v", runs, errs)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	var requests int
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Query().Get("after") {
+		case "":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"msg_1"},{"id":"msg_2"}],"last_id":"msg_2","has_more":true}`)
+		case "msg_2":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"msg_3"}],"last_id":"msg_3","has_more":false}`)
+		}
+	}
+
+	c := testClient(t, h)
+
+	ctx := testCtx(t)
+
+	var ids []string
+	for msg, err := range openai.ListAll(ctx, &openai.ListMessagesRequest{ThreadID: "thread_1"}, c.ListMessages) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, msg.ID)
+
+		// Stopping early doesn't fetch more pages.
+		if len(ids) == 2 {
+			break
+		}
+	}
+
+	if fmt.Sprint(ids) != "[msg_1 msg_2]" || requests != 1 {
+		t.Fatalf("unexpected ids %v after %d requests", ids, requests)
+	}
+
+	page, err := c.ListMessages(ctx, &openai.ListMessagesRequest{ThreadID: "thread_1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sizes []int
+	for page, err := range page.Pages(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, len(page.Data))
+	}
+
+	if fmt.Sprint(sizes) != "[2 1]" {
+		t.Fatalf("unexpected page sizes: %v", sizes)
+	}
+}
+
+func TestListFineTunes_NextPage(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		if r.URL.Path != "/v1/fine-tunes" || q.Get("limit") != "1" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+
+		switch q.Get("after") {
+		case "":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"ft-a"}],"last_id":"ft-a","has_more":true}`)
+		case "ft-a":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"ft-b"}],"last_id":"ft-b","has_more":false}`)
+		default:
+			t.Errorf("unexpected cursor: %s", q.Get("after"))
+		}
+	})
+
+	ctx := testCtx(t)
+
+	page, err := c.ListFineTunes(ctx, &openai.ListFineTunesRequest{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := page.NextPage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(next.Data) != 1 || next.Data[0].ID != "ft-b" {
+		t.Fatalf("unexpected next page: %+v", next.Data)
+	}
+
+	if _, err := next.NextPage(ctx); !errors.Is(err, openai.ErrNoMorePages) {
+		t.Fatalf("expected ErrNoMorePages, got %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+// errIncompleteJSON is returned by UnmarshalPartialJSON when data doesn't
+// begin with enough JSON to decode anything.
+var errIncompleteJSON = errors.New("no complete JSON value")
+
+// UnmarshalPartialJSON decodes data, which may be a JSON document cut off at
+// any point, such as structured output still being streamed, into v on a
+// best-effort basis.
+//
+// The document is completed by closing its open strings, arrays and objects.
+// A string being streamed is decoded as far as it was received, while object
+// keys without a value, and numbers and literals that may be incomplete, are
+// left out. Decoding a complete document is the same as json.Unmarshal.
+//
+// # Example
+//
+//	var v struct {
+//		Title string   `json:"title"`
+//		Tags  []string `json:"tags"`
+//	}
+//
+//	_ = openai.UnmarshalPartialJSON([]byte(`{"title":"Gophers","tags":["go","mas`), &v)
+//	// v.Title == "Gophers", v.Tags == []string{"go", "mas"}
+func UnmarshalPartialJSON(data []byte, v any) error {
+	completed, ok := completeJSON(data)
+	if !ok {
+		return errIncompleteJSON
+	}
+	return json.Unmarshal(completed, v)
+}
+
+// completeJSON returns the longest decodable prefix of the truncated JSON
+// document, with its open strings, arrays and objects closed. It reports false
+// if no value has been started, or data isn't the prefix of valid JSON.
+func completeJSON(data []byte) ([]byte, bool) {
+	var (
+		// stack holds the open arrays and objects, as '[' or '{'.
+		stack []byte
+
+		// keyNext holds whether the next string of each open object is a key.
+		keyNext []bool
+
+		// safe is the length of the longest prefix that can be completed by
+		// closing the containers that were open after it, safeStack.
+		safe      int
+		safeStack []byte
+	)
+
+	markSafe := func(end int) {
+		safe = end
+		safeStack = slices.Clone(stack)
+	}
+
+	inObject := func() bool {
+		return len(stack) > 0 && stack[len(stack)-1] == '{'
+	}
+
+	for i := 0; i < len(data); {
+		switch c := data[i]; c {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '{', '[':
+			stack = append(stack, c)
+			keyNext = append(keyNext, c == '{')
+			i++
+			markSafe(i)
+		case '}', ']':
+			if len(stack) == 0 || stack[len(stack)-1] != c-2 { // '{'+2 == '}', '['+2 == ']'
+				return nil, false
+			}
+			stack = stack[:len(stack)-1]
+			keyNext = keyNext[:len(keyNext)-1]
+			i++
+			markSafe(i)
+		case ',':
+			if inObject() {
+				keyNext[len(keyNext)-1] = true
+			}
+			i++
+		case ':':
+			if !inObject() {
+				return nil, false
+			}
+			keyNext[len(keyNext)-1] = false
+			i++
+		case '"':
+			isKey := inObject() && keyNext[len(keyNext)-1]
+
+			end, escape := stringEnd(data, i+1)
+			if end < 0 {
+				if isKey {
+					return closeJSON(data[:safe], safeStack), safe > 0
+				}
+
+				// Keep as much of the value as was received, without an
+				// incomplete escape sequence.
+				if escape >= 0 {
+					data = data[:escape]
+				}
+				return closeJSON(append(slices.Clip(data), '"'), stack), true
+			}
+
+			i = end + 1
+			if !isKey {
+				markSafe(i)
+			}
+		default:
+			end := i
+			for end < len(data) && !isJSONDelimiter(data[end]) {
+				end++
+			}
+
+			// Numbers at the end may be cut off, so are only kept if they
+			// are the whole document.
+			tok := data[i:end]
+			if end == len(data) && len(stack) > 0 && !isJSONLiteral(tok) {
+				return closeJSON(data[:safe], safeStack), safe > 0
+			}
+
+			if !json.Valid(tok) {
+				if end == len(data) {
+					return closeJSON(data[:safe], safeStack), safe > 0
+				}
+				return nil, false
+			}
+
+			i = end
+			markSafe(i)
+		}
+	}
+
+	return closeJSON(data[:safe], safeStack), safe > 0
+}
+
+// stringEnd returns the index of the quote closing the string starting at
+// start, or -1 if data ends first, along with the index of the backslash of an
+// incomplete escape sequence at the end of data, or -1 if there is none.
+func stringEnd(data []byte, start int) (end, escape int) {
+	for i := start; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			return i, -1
+		case '\\':
+			n := 2
+			if i+1 < len(data) && data[i+1] == 'u' {
+				n = 6
+			}
+			if i+n > len(data) {
+				return -1, i
+			}
+			i += n - 1
+		}
+	}
+	return -1, -1
+}
+
+// isJSONDelimiter reports whether c ends a number or literal.
+func isJSONDelimiter(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', ',', ':', '[', ']', '{', '}', '"':
+		return true
+	}
+	return false
+}
+
+// closeJSON appends the closing brackets of the open containers in stack to
+// data.
+func closeJSON(data []byte, stack []byte) []byte {
+	out := slices.Clip(data)
+	for i := len(stack) - 1; i >= 0; i-- {
+		out = append(out, stack[i]+2)
+	}
+	return out
+}
+
+// isJSONLiteral reports whether tok is true, false or null.
+func isJSONLiteral(tok []byte) bool {
+	switch string(tok) {
+	case "true", "false", "null":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestUnmarshalPartialJSON(t *testing.T) {
+	for _, tc := range []struct {
+		data, want string
+	}{
+		{`{`, `{}`},
+		{`{"ti`, `{}`},
+		{`{"title"`, `{}`},
+		{`{"title":`, `{}`},
+		{`{"title":"Gop`, `{"title":"Gop"}`},
+		{`{"title":"Gophers",`, `{"title":"Gophers"}`},
+		{`{"title":"a\`, `{"title":"a"}`},
+		{`{"title":"a\u00`, `{"title":"a"}`},
+		{`{"title":"aé`, `{"title":"aé"}`},
+		{`{"count":12`, `{}`},
+		{`{"count":12,"ok":tr`, `{"count":12}`},
+		{`{"count":12,"ok":true`, `{"count":12,"ok":true}`},
+		{`{"tags":["go","mas`, `{"tags":["go","mas"]}`},
+		{`{"tags":["go",`, `{"tags":["go"]}`},
+		{`{"items":[{"a":1},{"b":[1,2`, `{"items":[{"a":1},{"b":[1]}]}`},
+		{`{"a":null}`, `{"a":null}`},
+		{`[1, 2, 3]`, `[1,2,3]`},
+		{`42`, `42`},
+		{`"hel`, `"hel"`},
+	} {
+		var v any
+		if err := openai.UnmarshalPartialJSON([]byte(tc.data), &v); err != nil {
+			t.Errorf("%s: %v", tc.data, err)
+			continue
+		}
+
+		got, _ := json.Marshal(v)
+		if string(got) != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.data, tc.want, got)
+		}
+	}
+
+	for _, data := range []string{``, `  `, `}`, `{"a":1]`, `{"a" 1:`} {
+		var v any
+		if err := openai.UnmarshalPartialJSON([]byte(data), &v); err == nil {
+			t.Errorf("%q: expected error, got %v", data, v)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package openai
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PGVectorStore is a MemoryStore that keeps messages in a Postgres database
+// with the pgvector extension, in a "chat_memory" table, and queries them by
+// cosine distance. The database driver, such as github.com/jackc/pgx/v5/stdlib
+// or github.com/lib/pq, must be imported by the program.
+//
+// Messages are stored under a namespace, such as a user ID, so stores for
+// different conversations can share the table without recalling each other's
+// messages. Within a namespace, messages are stored by their role and text, so
+// storing the same message again replaces it.
+//
+// # Example
+//
+//	db, _ := sql.Open("pgx", os.Getenv("DATABASE_URL"))
+//
+//	store, err := openai.NewPGVectorStore(ctx, db, userID, 1536)
+//	if err != nil {
+//		return err
+//	}
+//
+//	session.Recall = openai.NewLongTermMemory(client, openai.ModelTextEmbedding3Small, store)
+type PGVectorStore struct {
+	db        *sql.DB
+	namespace string
+}
+
+// NewPGVectorStore returns a PGVectorStore for the messages in the given
+// namespace of the database, creating the pgvector extension, and its table
+// and index, if they don't exist yet. The number of dimensions must match the
+// embedding model, such as 1536 for ModelTextEmbedding3Small.
+func NewPGVectorStore(ctx context.Context, db *sql.DB, namespace string, dims int) (*PGVectorStore, error) {
+	if namespace == "" {
+		return nil, errors.New("namespace is empty")
+	}
+
+	if dims <= 0 {
+		return nil, fmt.Errorf("invalid number of dimensions: %d", dims)
+	}
+
+	stmts := []string{
+		`CREATE EXTENSION IF NOT EXISTS vector`,
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS chat_memory (
+			namespace TEXT NOT NULL,
+			id TEXT NOT NULL,
+			message JSONB NOT NULL,
+			embedding vector(%d) NOT NULL,
+			PRIMARY KEY (namespace, id)
+		)`, dims),
+		`CREATE INDEX IF NOT EXISTS chat_memory_embedding_idx ON chat_memory USING hnsw (embedding vector_cosine_ops)`,
+	}
+
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("failed to create chat_memory table: %w", err)
+		}
+	}
+
+	return &PGVectorStore{db: db, namespace: namespace}, nil
+}
+
+// Put implements the MemoryStore interface.
+func (s *PGVectorStore) Put(ctx context.Context, message ChatMessage, embedding []float32) error {
+	b, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO chat_memory (namespace, id, message, embedding) VALUES ($1, $2, $3, $4::vector)
+		ON CONFLICT (namespace, id) DO UPDATE SET message = excluded.message, embedding = excluded.embedding`,
+		s.namespace, memoryID(message), string(b), pgvector(embedding),
+	)
+	return err
+}
+
+// Query implements the MemoryStore interface.
+func (s *PGVectorStore) Query(ctx context.Context, embedding []float32, k int) ([]ChatMessage, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT message FROM chat_memory WHERE namespace = $1 ORDER BY embedding <=> $2::vector LIMIT $3`,
+		s.namespace, pgvector(embedding), k,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []ChatMessage
+	for rows.Next() {
+		var b string
+		if err := rows.Scan(&b); err != nil {
+			return nil, err
+		}
+
+		var m ChatMessage
+		if err := json.Unmarshal([]byte(b), &m); err != nil {
+			return nil, fmt.Errorf("failed to decode message: %w", err)
+		}
+		messages = append(messages, m)
+	}
+
+	return messages, rows.Err()
+}
+
+// pgvector returns the text representation of the embedding as a pgvector,
+// such as "[0.1,0.2,0.3]".
+func pgvector(embedding []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
@@ -0,0 +1,178 @@
+package openai_test
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+// fakePG is a database/sql connector for a fake Postgres database, which
+// understands just the statements of a PGVectorStore, keeping the rows of the
+// chat_memory table in memory.
+type fakePG struct {
+	mu    sync.Mutex
+	stmts []string
+	rows  map[[2]string]fakePGRow
+}
+
+type fakePGRow struct {
+	message   string
+	embedding []float64
+}
+
+func (db *fakePG) Connect(context.Context) (driver.Conn, error) { return fakePGConn{db}, nil }
+func (db *fakePG) Driver() driver.Driver                        { return nil }
+
+type fakePGConn struct{ db *fakePG }
+
+func (c fakePGConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c fakePGConn) Close() error                        { return nil }
+func (c fakePGConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c fakePGConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.db.stmts = append(c.db.stmts, query)
+
+	if strings.HasPrefix(query, "INSERT INTO chat_memory") {
+		key := [2]string{args[0].Value.(string), args[1].Value.(string)}
+		c.db.rows[key] = fakePGRow{message: args[2].Value.(string), embedding: parsePGVector(args[3].Value.(string))}
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c fakePGConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	if !strings.HasPrefix(query, "SELECT message FROM chat_memory WHERE namespace = $1") {
+		return nil, errors.New("unexpected query: " + query)
+	}
+
+	namespace, embedding, limit := args[0].Value.(string), parsePGVector(args[1].Value.(string)), args[2].Value.(int64)
+
+	var rows []fakePGRow
+	for key, row := range c.db.rows {
+		if key[0] == namespace {
+			rows = append(rows, row)
+		}
+	}
+
+	slices.SortFunc(rows, func(a, b fakePGRow) int {
+		return cmp.Compare(cosineDistance(a.embedding, embedding), cosineDistance(b.embedding, embedding))
+	})
+
+	var messages []string
+	for _, row := range rows[:min(len(rows), int(limit))] {
+		messages = append(messages, row.message)
+	}
+	return &fakePGRows{messages: messages}, nil
+}
+
+type fakePGRows struct{ messages []string }
+
+func (r *fakePGRows) Columns() []string { return []string{"message"} }
+func (r *fakePGRows) Close() error      { return nil }
+
+func (r *fakePGRows) Next(dest []driver.Value) error {
+	if len(r.messages) == 0 {
+		return io.EOF
+	}
+	dest[0], r.messages = r.messages[0], r.messages[1:]
+	return nil
+}
+
+func parsePGVector(s string) []float64 {
+	var v []float64
+	for _, f := range strings.Split(strings.Trim(s, "[]"), ",") {
+		n, _ := strconv.ParseFloat(f, 64)
+		v = append(v, n)
+	}
+	return v
+}
+
+func cosineDistance(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	return 1 - dot/math.Sqrt(na*nb)
+}
+
+func TestPGVectorStore(t *testing.T) {
+	ctx := testCtx(t)
+
+	fake := &fakePG{rows: map[[2]string]fakePGRow{}}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	if _, err := openai.NewPGVectorStore(ctx, db, "", 2); err == nil {
+		t.Fatal("expected error for an empty namespace")
+	}
+
+	alice, err := openai.NewPGVectorStore(ctx, db, "alice", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fake.stmts) != 3 || !strings.Contains(fake.stmts[1], "vector(2)") || !strings.Contains(fake.stmts[1], "PRIMARY KEY (namespace, id)") {
+		t.Fatalf("unexpected statements: %q", fake.stmts)
+	}
+
+	bob, err := openai.NewPGVectorStore(ctx, db, "bob", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, put := range []struct {
+		store     *openai.PGVectorStore
+		text      string
+		embedding []float32
+	}{
+		{alice, "My favorite color is blue.", []float32{1, 0}},
+		{alice, "It's sunny today.", []float32{0, 1}},
+		{alice, "It's sunny today.", []float32{0.1, 1}},
+		{bob, "My favorite color is red.", []float32{1, 0}},
+	} {
+		if err := put.store.Put(ctx, openai.User(put.text), put.embedding); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Storing the same message again replaces it.
+	if len(fake.rows) != 3 {
+		t.Fatalf("expected 3 stored messages, got %d", len(fake.rows))
+	}
+
+	messages, err := alice.Query(ctx, []float32{0.9, 0.1}, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, m := range messages {
+		got = append(got, m.Content)
+	}
+
+	if strings.Join(got, "|") != "My favorite color is blue.|It's sunny today." {
+		t.Fatalf("unexpected messages recalled for alice: %q", got)
+	}
+
+	if messages, err := bob.Query(ctx, []float32{0, 1}, 1); err != nil || len(messages) != 1 || messages[0].Content != "My favorite color is red." {
+		t.Fatalf("unexpected messages recalled for bob: %+v, %v", messages, err)
+	}
+}
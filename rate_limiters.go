@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"net/http"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -78,3 +79,36 @@ func NewRateLimiters() *RateLimiters {
 
 	return rl
 }
+
+// WithRateLimit is a ClientOption that limits the client to rps requests per
+// second, allowing bursts of up to burst requests, so bulk jobs like embedding
+// or moderating many inputs stay within the organization's limits instead of
+// being retried after 429 responses.
+//
+// Requests wait for the limiter, or fail with the context's error if it is
+// done first. Each retry attempt counts as a request.
+//
+// # Example
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithRateLimit(50, 10))
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return WithRateLimiter(rate.NewLimiter(rate.Limit(rps), burst))
+}
+
+// WithRateLimiter is a ClientOption that limits the client's requests with the
+// given limiter, which can be shared between clients, such as those using the
+// same organization. See WithRateLimit.
+//
+// # Example
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithRateLimiter(openai.RateLimits.Chat.Requests))
+func WithRateLimiter(limiter *rate.Limiter) ClientOption {
+	return WithMiddleware(func(next RoundTripperFunc) RoundTripperFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			if err := limiter.Wait(r.Context()); err != nil {
+				return nil, err
+			}
+			return next(r)
+		}
+	})
+}
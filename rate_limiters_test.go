@@ -1,6 +1,9 @@
 package openai_test
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -45,3 +48,37 @@ func TestNewChatRequestRateLimiter(t *testing.T) {
 		}
 	}
 }
+
+func TestWithRateLimit(t *testing.T) {
+	var requests int
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	c := openai.NewClient("test",
+		openai.WithRateLimit(1, 2),
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+	)
+
+	for range 2 {
+		_, err := c.ListModels(testCtx(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The burst is used up, so the next request has to wait longer than the deadline.
+	ctx, cancel := context.WithTimeout(testCtx(t), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := c.ListModels(ctx)
+	if err == nil {
+		t.Fatal("expected rate limited request to fail with its context")
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected 2 requests to be sent, got %d", requests)
+	}
+}
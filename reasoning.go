@@ -0,0 +1,53 @@
+package openai
+
+import "strings"
+
+// reasoningModelPrefixes are the name prefixes of reasoning models, for models
+// without known capabilities, such as new snapshots.
+var reasoningModelPrefixes = []string{"o1", "o3", "o4", "gpt-5"}
+
+// IsReasoningModel reports whether the given model is a reasoning model, such
+// as o1, o3 or gpt-5, which thinks before it answers and doesn't support
+// sampling parameters like temperature.
+//
+// https://platform.openai.com/docs/guides/reasoning
+func IsReasoningModel(model string) bool {
+	if caps, ok := Capabilities(model); ok {
+		return caps.Reasoning
+	}
+
+	model = strings.TrimPrefix(model, "ft:")
+	for _, prefix := range reasoningModelPrefixes {
+		if strings.HasPrefix(model, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forModel returns the request adapted to its model. For reasoning models,
+// MaxTokens is sent as MaxCompletionTokens, and the sampling parameters they
+// reject are left out. Other requests are returned as-is.
+func (r *CreateChatRequest) forModel() *CreateChatRequest {
+	if !IsReasoningModel(r.Model) {
+		return r
+	}
+
+	req := *r
+
+	if req.MaxCompletionTokens == 0 {
+		req.MaxCompletionTokens = req.MaxTokens
+	}
+	req.MaxTokens = 0
+
+	req.Temperature = 0
+	req.TopP = 0
+	req.PresencePenalty = 0
+	req.FrequencyPenalty = 0
+	req.LogitBias = nil
+	req.Logprobs = false
+	req.TopLogprobs = 0
+
+	return &req
+}
@@ -0,0 +1,115 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestIsReasoningModel(t *testing.T) {
+	for model, want := range map[string]bool{
+		"o1":                   true,
+		"o1-mini":              true,
+		"o3-mini-2025-01-31":   true,
+		"o4-mini":              true,
+		"gpt-5":                true,
+		"gpt-5-chat-latest":    false,
+		"ft:o4-mini:org::id":   true,
+		"o4-2026-01-01":        true,
+		"gpt-4o":               false,
+		"gpt-4o-mini-tts":      false,
+		openai.ModelGPT35Turbo: false,
+	} {
+		if got := openai.IsReasoningModel(model); got != want {
+			t.Errorf("IsReasoningModel(%q) = %v, want %v", model, got, want)
+		}
+	}
+}
+
+func TestCreateChat_ReasoningModel(t *testing.T) {
+	var body map[string]any
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"Hi!"}}]}`)
+	}
+
+	c := testClient(t, h)
+
+	ctx := testCtx(t)
+
+	req := &openai.CreateChatRequest{
+		Model:            "o3-mini",
+		Messages:         []openai.ChatMessage{openai.User("Hello!")},
+		MaxTokens:        256,
+		Temperature:      0.2,
+		PresencePenalty:  0.5,
+		FrequencyPenalty: 0.5,
+	}
+
+	_, err := c.CreateChat(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"max_tokens", "temperature", "presence_penalty", "frequency_penalty"} {
+		if _, ok := body[key]; ok {
+			t.Errorf("expected %s to be left out for reasoning model", key)
+		}
+	}
+
+	if body["max_completion_tokens"] != float64(256) {
+		t.Errorf("expected max_tokens to be sent as max_completion_tokens, got %v", body["max_completion_tokens"])
+	}
+
+	if req.MaxTokens != 256 || req.Temperature != 0.2 {
+		t.Error("expected request not to be modified")
+	}
+
+	req.Model = openai.ModelGPT4
+
+	_, err = c.CreateChat(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body["max_tokens"] != float64(256) || body["temperature"] != 0.2 {
+		t.Errorf("expected parameters to be sent as-is for other models, got %v", body)
+	}
+}
+
+func TestCreateChat_ReasoningEffort(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ReasoningEffort string `json:"reasoning_effort"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.ReasoningEffort != "low" {
+			t.Errorf("unexpected reasoning effort: %q", body.ReasoningEffort)
+		}
+		fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"4"}}],"usage":{"prompt_tokens":10,"completion_tokens":140,"total_tokens":150,"completion_tokens_details":{"reasoning_tokens":128}}}`)
+	}
+
+	c := testClient(t, h)
+
+	resp, err := c.CreateChat(testCtx(t), &openai.CreateChatRequest{
+		Model:           "o3-mini",
+		Messages:        []openai.ChatMessage{openai.User("What is 2+2?")},
+		ReasoningEffort: openai.ReasoningEffortLow,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := resp.Usage.ReasoningTokens(); got != 128 {
+		t.Fatalf("unexpected reasoning tokens: %d", got)
+	}
+}
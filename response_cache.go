@@ -0,0 +1,228 @@
+package openai
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCache is an in-memory cache of API responses, keyed by a hash of the
+// request, for requests whose response is expected to be the same each time:
+// embeddings, and chat completions whose body sets a seed and a temperature of
+// 0, and that aren't streamed. Other requests, and responses other than 200
+// OK, aren't cached.
+//
+// CreateChatRequest leaves out a Temperature of 0, so chats it sends, which the
+// API samples with its default temperature of 1, aren't cached.
+//
+// The least recently used responses are evicted once the cache is full. A
+// ResponseCache is safe for concurrent use, and can be shared by clients.
+//
+// # Example
+//
+//	cache := openai.NewResponseCache(time.Hour, 1000)
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithResponseCache(cache))
+type ResponseCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	// mu guards entries and lru, whose front is the most recently used entry.
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*list.Element
+	lru     *list.List
+}
+
+// cachedResponse is a response stored in a ResponseCache.
+type cachedResponse struct {
+	key     [sha256.Size]byte
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// NewResponseCache returns an empty ResponseCache keeping responses for the given
+// time to live, and at most maxEntries of them. A ttl or maxEntries of zero
+// means no limit.
+func NewResponseCache(ttl time.Duration, maxEntries int) *ResponseCache {
+	return &ResponseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[[sha256.Size]byte]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+// WithResponseCache is a ClientOption that adds middleware serving responses
+// from the given cache, and storing them in it.
+func WithResponseCache(cache *ResponseCache) ClientOption {
+	return WithMiddleware(cache.Middleware())
+}
+
+// Len returns the number of responses in the cache, including expired ones not
+// yet evicted.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Clear removes all responses from the cache.
+func (c *ResponseCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.lru.Init()
+}
+
+// Middleware returns a Middleware serving responses from the cache, and
+// storing them in it.
+func (c *ResponseCache) Middleware() Middleware {
+	return func(next RoundTripperFunc) RoundTripperFunc {
+		return func(r *http.Request) (*http.Response, error) {
+			key, ok := responseCacheKey(r)
+			if !ok {
+				return next(r)
+			}
+
+			if resp, ok := c.get(key, r); ok {
+				return resp, nil
+			}
+
+			resp, err := next(r)
+			if err != nil || resp.StatusCode != http.StatusOK {
+				return resp, err
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+
+			c.put(key, resp.Header, body)
+			return resp, nil
+		}
+	}
+}
+
+// get returns the cached response for the key, if it hasn't expired.
+func (c *ResponseCache) get(key [sha256.Size]byte, r *http.Request) (*http.Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	cached := e.Value.(*cachedResponse)
+	if c.ttl > 0 && time.Now().After(cached.expires) {
+		c.lru.Remove(e)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(e)
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.body)),
+		ContentLength: int64(len(cached.body)),
+		Request:       r,
+	}, true
+}
+
+// put stores the response for the key, evicting the least recently used
+// responses if the cache is full.
+func (c *ResponseCache) put(key [sha256.Size]byte, header http.Header, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := &cachedResponse{key: key, header: header.Clone(), body: body, expires: time.Now().Add(c.ttl)}
+
+	if e, ok := c.entries[key]; ok {
+		e.Value = cached
+		c.lru.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(cached)
+
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.entries, e.Value.(*cachedResponse).key)
+	}
+}
+
+// responseCacheKey returns the cache key of the request, reporting false if its
+// response shouldn't be cached. The key covers the request's credentials, so
+// clients sharing a cache don't see each other's responses.
+func responseCacheKey(r *http.Request) ([sha256.Size]byte, bool) {
+	if r.Method != http.MethodPost || r.GetBody == nil {
+		return [sha256.Size]byte{}, false
+	}
+
+	chat := strings.HasSuffix(r.URL.Path, "/chat/completions")
+	if !chat && !strings.HasSuffix(r.URL.Path, "/embeddings") {
+		return [sha256.Size]byte{}, false
+	}
+
+	body, err := r.GetBody()
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+
+	if chat {
+		var req struct {
+			Stream      bool     `json:"stream"`
+			Seed        *int     `json:"seed"`
+			Temperature *float64 `json:"temperature"`
+		}
+		if err := json.Unmarshal(b, &req); err != nil {
+			return [sha256.Size]byte{}, false
+		}
+
+		// Without a temperature, the API samples with its default of 1.
+		if req.Stream || req.Seed == nil || req.Temperature == nil || *req.Temperature != 0 {
+			return [sha256.Size]byte{}, false
+		}
+	}
+
+	h := sha256.New()
+	for _, s := range []string{
+		r.URL.String(),
+		r.Header.Get("Authorization"),
+		r.Header.Get("OpenAI-Organization"),
+		r.Header.Get("OpenAI-Project"),
+	} {
+		io.WriteString(h, s)
+		h.Write([]byte{0})
+	}
+	h.Write(b)
+
+	var key [sha256.Size]byte
+	h.Sum(key[:0])
+	return key, true
+}
@@ -0,0 +1,120 @@
+package openai_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestResponseCache(t *testing.T) {
+	var calls int
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/v1/embeddings" {
+			fmt.Fprint(w, `{"data":[{"index":0,"embedding":[1, 0]}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"choices": [{"message": {"role": "assistant", "content": "reply %d"}, "index": 0}]}`, calls)
+	}
+
+	cache := openai.NewResponseCache(50*time.Millisecond, 2)
+
+	c := openai.NewClient("test",
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+		openai.WithResponseCache(cache),
+	)
+
+	embed := func(input string) {
+		t.Helper()
+		if _, err := c.CreateEmbedding(testCtx(t), &openai.CreateEmbeddingRequest{Model: openai.ModelTextEmbedding3Small, Input: input}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	chat := func(req *openai.CreateChatRequest) string {
+		t.Helper()
+		resp, err := c.CreateChat(testCtx(t), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Choices[0].Message.Content
+	}
+
+	embed("hello")
+	embed("hello")
+
+	if calls != 1 {
+		t.Fatalf("expected the second embedding to be cached, got %d calls", calls)
+	}
+
+	seed := 42
+	req := &openai.CreateChatRequest{
+		Model:    openai.ModelGPT4o,
+		Messages: []openai.ChatMessage{openai.User("Hi")},
+		Seed:     &seed,
+	}
+
+	// Without a temperature, the API samples with its default of 1.
+	chat(req)
+	chat(req)
+
+	req.Temperature = 0.7
+	chat(req)
+	chat(req)
+
+	if calls != 5 {
+		t.Fatalf("expected chats without a temperature of 0 not to be cached, got %d calls", calls)
+	}
+
+	// Seeded chats with an explicit temperature of 0 are cached.
+	var sent int
+	send := cache.Middleware()(func(r *http.Request) (*http.Response, error) {
+		sent++
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(fmt.Sprintf("reply %d", sent)))}, nil
+	})
+
+	deterministic := func() string {
+		t.Helper()
+		r, err := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/chat/completions", strings.NewReader(`{"model":"gpt-4o","seed":42,"temperature":0}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := send(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		return string(b)
+	}
+
+	if first, second := deterministic(), deterministic(); first != second || sent != 1 {
+		t.Fatalf("expected the deterministic chat to be cached, got %q and %q with %d calls", first, second, sent)
+	}
+
+	// The least recently used embedding is evicted.
+	embed("world")
+
+	if cache.Len() != 2 {
+		t.Fatalf("expected the cache to be bounded to 2 entries, got %d", cache.Len())
+	}
+
+	embed("hello")
+
+	if calls != 7 {
+		t.Fatalf("expected the evicted embedding to be requested again, got %d calls", calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	embed("hello")
+
+	if calls != 8 {
+		t.Fatalf("expected the expired embedding to be requested again, got %d calls", calls)
+	}
+}
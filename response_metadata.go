@@ -0,0 +1,120 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMetadata is the metadata of an API response, sent in its headers,
+// such as its request ID to share with support, and the organization's rate
+// limits to adapt client-side pacing to.
+//
+// https://platform.openai.com/docs/api-reference/debugging-requests
+type ResponseMetadata struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// RequestID is the unique ID of the request, from the x-request-id header.
+	RequestID string
+
+	// ProcessingTime is the time the API took to process the request, from the
+	// openai-processing-ms header.
+	ProcessingTime time.Duration
+
+	// RateLimit is the organization's rate limit state after the request.
+	RateLimit RateLimit
+
+	// Header is the full set of response headers.
+	Header http.Header
+}
+
+// RateLimit is the rate limit state sent in the x-ratelimit-* headers of a
+// response. Fields are zero if their header wasn't sent.
+//
+// https://platform.openai.com/docs/guides/rate-limits#rate-limits-in-headers
+type RateLimit struct {
+	// LimitRequests is the maximum number of requests allowed before the limit is exhausted.
+	LimitRequests int
+
+	// LimitTokens is the maximum number of tokens allowed before the limit is exhausted.
+	LimitTokens int
+
+	// RemainingRequests is the number of requests left before the limit is exhausted.
+	RemainingRequests int
+
+	// RemainingTokens is the number of tokens left before the limit is exhausted.
+	RemainingTokens int
+
+	// ResetRequests is the time until the request limit resets to its initial state.
+	ResetRequests time.Duration
+
+	// ResetTokens is the time until the token limit resets to its initial state.
+	ResetTokens time.Duration
+}
+
+// ParseResponseMetadata returns the metadata in the given response headers.
+func ParseResponseMetadata(statusCode int, h http.Header) *ResponseMetadata {
+	md := &ResponseMetadata{
+		StatusCode: statusCode,
+		RequestID:  h.Get("X-Request-Id"),
+		Header:     h,
+		RateLimit: RateLimit{
+			LimitRequests:     headerInt(h, "X-Ratelimit-Limit-Requests"),
+			LimitTokens:       headerInt(h, "X-Ratelimit-Limit-Tokens"),
+			RemainingRequests: headerInt(h, "X-Ratelimit-Remaining-Requests"),
+			RemainingTokens:   headerInt(h, "X-Ratelimit-Remaining-Tokens"),
+			ResetRequests:     headerDuration(h, "X-Ratelimit-Reset-Requests"),
+			ResetTokens:       headerDuration(h, "X-Ratelimit-Reset-Tokens"),
+		},
+	}
+
+	if ms := headerInt(h, "Openai-Processing-Ms"); ms > 0 {
+		md.ProcessingTime = time.Duration(ms) * time.Millisecond
+	}
+
+	return md
+}
+
+type responseMetadataContextKey struct{}
+
+// ContextWithResponseMetadata returns a copy of ctx that records the metadata
+// of the response to each request made with it into md. If a request is retried,
+// md holds the metadata of its last attempt.
+//
+// # Example
+//
+//	var md openai.ResponseMetadata
+//
+//	resp, err := client.CreateChat(openai.ContextWithResponseMetadata(ctx, &md), req)
+//	if err != nil {
+//		return fmt.Errorf("request %s failed: %w", md.RequestID, err)
+//	}
+//
+//	fmt.Println(md.RateLimit.RemainingTokens)
+func ContextWithResponseMetadata(ctx context.Context, md *ResponseMetadata) context.Context {
+	return context.WithValue(ctx, responseMetadataContextKey{}, md)
+}
+
+// recordResponseMetadata records the metadata of resp in the ResponseMetadata
+// of the request's context, if any.
+func recordResponseMetadata(r *http.Request, resp *http.Response) {
+	if md, ok := r.Context().Value(responseMetadataContextKey{}).(*ResponseMetadata); ok && md != nil {
+		*md = *ParseResponseMetadata(resp.StatusCode, resp.Header)
+	}
+}
+
+// headerInt returns the integer value of the header, or 0 if it isn't set or
+// isn't an integer.
+func headerInt(h http.Header, key string) int {
+	n, _ := strconv.Atoi(h.Get(key))
+	return n
+}
+
+// headerDuration returns the duration value of the header, such as "6m0s" or
+// "20ms", or 0 if it isn't set or isn't a duration.
+func headerDuration(h http.Header, key string) time.Duration {
+	d, _ := time.ParseDuration(h.Get(key))
+	return d
+}
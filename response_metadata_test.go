@@ -0,0 +1,46 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestContextWithResponseMetadata(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_abc")
+		w.Header().Set("Openai-Processing-Ms", "150")
+		w.Header().Set("X-Ratelimit-Limit-Requests", "60")
+		w.Header().Set("X-Ratelimit-Limit-Tokens", "150000")
+		w.Header().Set("X-Ratelimit-Remaining-Requests", "59")
+		w.Header().Set("X-Ratelimit-Remaining-Tokens", "149984")
+		w.Header().Set("X-Ratelimit-Reset-Requests", "1s")
+		w.Header().Set("X-Ratelimit-Reset-Tokens", "6m0s")
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	c := testClient(t, h)
+
+	var md openai.ResponseMetadata
+
+	_, err := c.ListModels(openai.ContextWithResponseMetadata(testCtx(t), &md))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := openai.RateLimit{
+		LimitRequests:     60,
+		LimitTokens:       150000,
+		RemainingRequests: 59,
+		RemainingTokens:   149984,
+		ResetRequests:     time.Second,
+		ResetTokens:       6 * time.Minute,
+	}
+
+	if md.StatusCode != http.StatusOK || md.RequestID != "req_abc" || md.ProcessingTime != 150*time.Millisecond || md.RateLimit != want {
+		t.Fatalf("unexpected metadata: %+v", md)
+	}
+}
@@ -0,0 +1,693 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/picatz/openai/sse"
+)
+
+// ResponseToolType is the type of a built-in or custom tool available to the
+// model in a Responses API request.
+//
+// https://platform.openai.com/docs/api-reference/responses/create#responses-create-tools
+type ResponseToolType string
+
+const (
+	// ResponseToolTypeFunction is a custom function defined by the caller.
+	ResponseToolTypeFunction ResponseToolType = "function"
+
+	// ResponseToolTypeImageGeneration is the built-in image generation tool, which allows
+	// the model to produce images inline within a conversation.
+	//
+	// https://platform.openai.com/docs/guides/tools-image-generation
+	ResponseToolTypeImageGeneration ResponseToolType = "image_generation"
+)
+
+// ResponseTool is a tool the model may call while generating a response.
+//
+// Only the fields relevant to the tool's Type should be set.
+//
+// https://platform.openai.com/docs/api-reference/responses/create#responses-create-tools
+type ResponseTool struct {
+	// Type is the type of the tool, e.g. "function" or "image_generation".
+	//
+	// Required.
+	Type ResponseToolType `json:"type"`
+
+	// Name is the name of the function, only used for "function" tools.
+	Name string `json:"name,omitempty"`
+
+	// Description is a description of the function, only used for "function" tools.
+	Description string `json:"description,omitempty"`
+
+	// Parameters are the arguments to the function, only used for "function" tools.
+	Parameters *JSONSchema `json:"parameters,omitempty"`
+
+	// Model is the image generation model to use, only used for "image_generation" tools.
+	//
+	// Optional. Defaults to "gpt-image-1".
+	Model string `json:"model,omitempty"`
+
+	// Size of the generated image, e.g. "1024x1024", "1024x1536", "1536x1024", or "auto".
+	//
+	// Optional. Only used for "image_generation" tools.
+	Size ImageSize `json:"size,omitempty"`
+
+	// Quality of the generated image, one of "low", "medium", "high", or "auto".
+	//
+	// Optional. Only used for "image_generation" tools.
+	Quality ImageQuality `json:"quality,omitempty"`
+
+	// Background type for the generated image, one of "transparent", "opaque", or "auto".
+	//
+	// Optional. Only used for "image_generation" tools.
+	Background ImageBackground `json:"background,omitempty"`
+
+	// OutputFormat of the generated image, one of "png", "webp", or "jpeg".
+	//
+	// Optional. Only used for "image_generation" tools.
+	OutputFormat ImageOutputFormat `json:"output_format,omitempty"`
+
+	// OutputCompression level for the generated image, from 0 to 100.
+	//
+	// Optional. Only used for "image_generation" tools with the "webp" or "jpeg" output format.
+	OutputCompression *int `json:"output_compression,omitempty"`
+
+	// Moderation level for the generated image, either "auto" or "low".
+	//
+	// Optional. Only used for "image_generation" tools.
+	Moderation ImageModeration `json:"moderation,omitempty"`
+
+	// PartialImages is the number of partial images to stream while the final image
+	// is being generated, from 0 (the default) to 3.
+	//
+	// Optional. Only used for "image_generation" tools when streaming.
+	PartialImages int `json:"partial_images,omitempty"`
+}
+
+// ResponseInputItemType is the type of an item in a response's input.
+//
+// https://platform.openai.com/docs/api-reference/responses/create#responses-create-input
+type ResponseInputItemType string
+
+const (
+	// ResponseInputItemTypeMessage is a message from the user, system, developer,
+	// or a previous assistant turn.
+	ResponseInputItemTypeMessage ResponseInputItemType = "message"
+
+	// ResponseInputItemTypeFunctionCall is a function call previously made by the
+	// model, included to replay a conversation without a previous response ID.
+	ResponseInputItemTypeFunctionCall ResponseInputItemType = "function_call"
+
+	// ResponseInputItemTypeFunctionCallOutput is the result of a function call,
+	// referenced by its CallID.
+	ResponseInputItemTypeFunctionCallOutput ResponseInputItemType = "function_call_output"
+)
+
+// ResponseInputContentType is the type of a content part of an input message.
+type ResponseInputContentType string
+
+const (
+	ResponseInputContentTypeText  ResponseInputContentType = "input_text"
+	ResponseInputContentTypeImage ResponseInputContentType = "input_image"
+	ResponseInputContentTypeFile  ResponseInputContentType = "input_file"
+)
+
+// ResponseInputContent is a content part of an input message, either text,
+// an image, or a file.
+//
+// https://platform.openai.com/docs/api-reference/responses/create#responses-create-input
+type ResponseInputContent struct {
+	// Type is the type of the content, e.g. "input_text" or "input_image".
+	//
+	// Required.
+	Type ResponseInputContentType `json:"type"`
+
+	// Text is the text input, for "input_text" content, or the text of a
+	// previous assistant message for "output_text" content.
+	Text string `json:"text,omitempty"`
+
+	// ImageURL is either the URL of the image, or the base64 encoded image data
+	// as a data URL, for "input_image" content.
+	ImageURL string `json:"image_url,omitempty"`
+
+	// Detail is the level of detail used to process an "input_image".
+	//
+	// Optional. Defaults to "auto".
+	Detail ImageDetail `json:"detail,omitempty"`
+
+	// FileID is the ID of an uploaded file, for "input_image" or "input_file" content.
+	FileID string `json:"file_id,omitempty"`
+
+	// Filename and FileData are the name and base64 encoded content of a file
+	// sent inline, for "input_file" content.
+	Filename string `json:"filename,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+}
+
+// ResponseInputItem is an item of the input to the model, such as a message,
+// or the output of a function call.
+//
+// Only the fields relevant to the item's Type should be set.
+//
+// https://platform.openai.com/docs/api-reference/responses/create#responses-create-input
+type ResponseInputItem struct {
+	// Type is the type of the item, e.g. "message" or "function_call_output".
+	//
+	// Required.
+	Type ResponseInputItemType `json:"type"`
+
+	// ID is the ID of the item, set for items returned by ListResponseInputItems.
+	ID string `json:"id,omitempty"`
+
+	// Status is the status of the item, set for items returned by ListResponseInputItems.
+	Status string `json:"status,omitempty"`
+
+	// Set for "message" items.
+	Role    Role                   `json:"role,omitempty"`
+	Content []ResponseInputContent `json:"content,omitempty"`
+
+	// Set for "function_call" and "function_call_output" items.
+	CallID string `json:"call_id,omitempty"`
+
+	// Set for "function_call" items.
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+
+	// Set for "function_call_output" items.
+	Output string `json:"output,omitempty"`
+}
+
+// ResponseInputMessage returns a "message" input item with the given role
+// and text content.
+func ResponseInputMessage(role Role, text string) ResponseInputItem {
+	return ResponseInputItem{
+		Type:    ResponseInputItemTypeMessage,
+		Role:    role,
+		Content: []ResponseInputContent{{Type: ResponseInputContentTypeText, Text: text}},
+	}
+}
+
+// ResponseFunctionCallOutput returns a "function_call_output" input item with
+// the result of the function call with the given ID.
+func ResponseFunctionCallOutput(callID, output string) ResponseInputItem {
+	return ResponseInputItem{
+		Type:   ResponseInputItemTypeFunctionCallOutput,
+		CallID: callID,
+		Output: output,
+	}
+}
+
+// https://platform.openai.com/docs/api-reference/responses/create
+type CreateResponseRequest struct {
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-model
+	//
+	// Required.
+	Model string `json:"model"`
+
+	// Text, image, or file inputs to the model. This is either a plain string,
+	// or a []ResponseInputItem.
+	//
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-input
+	//
+	// Required.
+	Input any `json:"input"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-instructions
+	//
+	// Optional.
+	Instructions string `json:"instructions,omitempty"`
+
+	// The unique ID of the previous response to the model, used to create
+	// multi-turn conversations without resending the whole context.
+	//
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-previous_response_id
+	//
+	// Optional.
+	PreviousResponseID string `json:"previous_response_id,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-tools
+	//
+	// Optional.
+	Tools []ResponseTool `json:"tools,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-tool_choice
+	//
+	// Optional.
+	ToolChoice any `json:"tool_choice,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-max_output_tokens
+	//
+	// Optional.
+	MaxOutputTokens int `json:"max_output_tokens,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-temperature
+	//
+	// Optional.
+	Temperature float64 `json:"temperature,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-store
+	//
+	// Optional. Defaults to true.
+	Store *bool `json:"store,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-metadata
+	//
+	// Optional.
+	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-user
+	//
+	// Optional.
+	User string `json:"user,omitempty"`
+
+	// Enable streaming mode, which will return a stream of server-sent events
+	// instead of a single response object.
+	//
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-stream
+	//
+	// Optional.
+	Stream bool `json:"stream,omitempty"`
+}
+
+// ResponseOutputItemType is the type of an item in a response's output.
+type ResponseOutputItemType string
+
+const (
+	ResponseOutputItemTypeMessage             ResponseOutputItemType = "message"
+	ResponseOutputItemTypeFunctionCall        ResponseOutputItemType = "function_call"
+	ResponseOutputItemTypeImageGenerationCall ResponseOutputItemType = "image_generation_call"
+	ResponseOutputItemTypeReasoning           ResponseOutputItemType = "reasoning"
+)
+
+// ResponseOutputContent is a content part of an output message.
+type ResponseOutputContent struct {
+	// Type is the type of the content, e.g. "output_text" or "refusal".
+	Type string `json:"type"`
+
+	// Text is the text output from the model, for "output_text" content.
+	Text string `json:"text,omitempty"`
+
+	// Refusal is the refusal explanation from the model, for "refusal" content.
+	Refusal string `json:"refusal,omitempty"`
+}
+
+// ResponseOutputItem is an item generated by the model, such as a message,
+// a function call, or an image generation call.
+//
+// https://platform.openai.com/docs/api-reference/responses/object#responses/object-output
+type ResponseOutputItem struct {
+	Type   ResponseOutputItemType `json:"type"`
+	ID     string                 `json:"id"`
+	Status string                 `json:"status,omitempty"`
+
+	// Set for "message" items.
+	Role    string                  `json:"role,omitempty"`
+	Content []ResponseOutputContent `json:"content,omitempty"`
+
+	// Set for "function_call" items.
+	CallID    string `json:"call_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+
+	// Set for "image_generation_call" items. Result is the base64-encoded image.
+	Result        string `json:"result,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+	Size          string `json:"size,omitempty"`
+	Quality       string `json:"quality,omitempty"`
+	Background    string `json:"background,omitempty"`
+	OutputFormat  string `json:"output_format,omitempty"`
+
+	// Set for "reasoning" items.
+	Summary []ResponseReasoningSummary `json:"summary,omitempty"`
+}
+
+// ResponseReasoningSummary is a summary of the model's reasoning, for
+// "reasoning" output items.
+type ResponseReasoningSummary struct {
+	// Type is the type of the summary, currently only "summary_text".
+	Type string `json:"type"`
+
+	// Text is the summary of the reasoning.
+	Text string `json:"text"`
+}
+
+// ImageBytes decodes the base64-encoded image result of an "image_generation_call" item.
+func (i *ResponseOutputItem) ImageBytes() ([]byte, error) {
+	if i.Type != ResponseOutputItemTypeImageGenerationCall {
+		return nil, fmt.Errorf("output item %q is a %q, not an image generation call", i.ID, i.Type)
+	}
+
+	if i.Result == "" {
+		return nil, fmt.Errorf("image generation call %q has no result", i.ID)
+	}
+
+	return base64.StdEncoding.DecodeString(i.Result)
+}
+
+// https://platform.openai.com/docs/api-reference/responses/object#responses/object-usage
+type ResponseUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+// Response is a model response returned by the Responses API.
+//
+// https://platform.openai.com/docs/api-reference/responses/object
+type Response struct {
+	ID                 string               `json:"id"`
+	Object             string               `json:"object"`
+	CreatedAt          int                  `json:"created_at"`
+	Status             string               `json:"status"`
+	Model              string               `json:"model"`
+	Instructions       string               `json:"instructions,omitempty"`
+	PreviousResponseID string               `json:"previous_response_id,omitempty"`
+	Output             []ResponseOutputItem `json:"output"`
+	Usage              *ResponseUsage       `json:"usage,omitempty"`
+	Error              map[string]any       `json:"error,omitempty"`
+	Metadata           map[string]any       `json:"metadata,omitempty"`
+
+	// https://platform.openai.com/docs/api-reference/responses/create#responses-create-stream
+	Stream io.ReadCloser `json:"-"`
+
+	// maxEventSize is the client's MaxStreamEventSize.
+	maxEventSize int
+}
+
+// OutputText returns the concatenated text of all "output_text" content parts
+// in the response's output messages.
+func (r *Response) OutputText() string {
+	var b strings.Builder
+	for _, item := range r.Output {
+		if item.Type != ResponseOutputItemTypeMessage {
+			continue
+		}
+		for _, content := range item.Content {
+			if content.Type == "output_text" {
+				b.WriteString(content.Text)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Images decodes all images generated by "image_generation_call" items in the
+// response's output, in order.
+func (r *Response) Images() ([][]byte, error) {
+	var images [][]byte
+	for i := range r.Output {
+		if r.Output[i].Type != ResponseOutputItemTypeImageGenerationCall {
+			continue
+		}
+
+		b, err := r.Output[i].ImageBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, b)
+	}
+	return images, nil
+}
+
+// FunctionCalls returns the "function_call" items in the response's output, in order.
+func (r *Response) FunctionCalls() []ResponseOutputItem {
+	var calls []ResponseOutputItem
+	for _, item := range r.Output {
+		if item.Type == ResponseOutputItemTypeFunctionCall {
+			calls = append(calls, item)
+		}
+	}
+	return calls
+}
+
+// ResponseStreamEventType is the type of a server-sent event streamed by the Responses API.
+//
+// https://platform.openai.com/docs/api-reference/responses-streaming
+type ResponseStreamEventType string
+
+const (
+	ResponseStreamEventCreated                         ResponseStreamEventType = "response.created"
+	ResponseStreamEventInProgress                      ResponseStreamEventType = "response.in_progress"
+	ResponseStreamEventCompleted                       ResponseStreamEventType = "response.completed"
+	ResponseStreamEventFailed                          ResponseStreamEventType = "response.failed"
+	ResponseStreamEventOutputItemAdded                 ResponseStreamEventType = "response.output_item.added"
+	ResponseStreamEventOutputItemDone                  ResponseStreamEventType = "response.output_item.done"
+	ResponseStreamEventOutputTextDelta                 ResponseStreamEventType = "response.output_text.delta"
+	ResponseStreamEventOutputTextDone                  ResponseStreamEventType = "response.output_text.done"
+	ResponseStreamEventImageGenerationCallInProgress   ResponseStreamEventType = "response.image_generation_call.in_progress"
+	ResponseStreamEventImageGenerationCallGenerating   ResponseStreamEventType = "response.image_generation_call.generating"
+	ResponseStreamEventImageGenerationCallPartialImage ResponseStreamEventType = "response.image_generation_call.partial_image"
+	ResponseStreamEventImageGenerationCallCompleted    ResponseStreamEventType = "response.image_generation_call.completed"
+	ResponseStreamEventError                           ResponseStreamEventType = "error"
+)
+
+// ResponseStreamEvent is a single server-sent event streamed by the Responses API.
+//
+// Only the fields relevant to the event's Type are set.
+//
+// https://platform.openai.com/docs/api-reference/responses-streaming
+type ResponseStreamEvent struct {
+	Type           ResponseStreamEventType `json:"type"`
+	SequenceNumber int                     `json:"sequence_number"`
+
+	// Set for "response.*" lifecycle events.
+	Response *Response `json:"response,omitempty"`
+
+	// Set for "response.output_item.*" events.
+	Item *ResponseOutputItem `json:"item,omitempty"`
+
+	ItemID       string `json:"item_id,omitempty"`
+	OutputIndex  int    `json:"output_index"`
+	ContentIndex int    `json:"content_index"`
+
+	// Set for "response.output_text.delta" events.
+	Delta string `json:"delta,omitempty"`
+
+	// Set for "response.image_generation_call.partial_image" events.
+	PartialImageIndex int    `json:"partial_image_index"`
+	PartialImageB64   string `json:"partial_image_b64,omitempty"`
+
+	// Set for "error" events.
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// PartialImage decodes the base64-encoded partial image of a
+// "response.image_generation_call.partial_image" event.
+func (e *ResponseStreamEvent) PartialImage() ([]byte, error) {
+	if e.Type != ResponseStreamEventImageGenerationCallPartialImage {
+		return nil, fmt.Errorf("event is a %q, not a partial image", e.Type)
+	}
+
+	return base64.StdEncoding.DecodeString(e.PartialImageB64)
+}
+
+// ReadStream reads the stream, applying the callback to each event.
+//
+// Events are sent via server-sent events (SSE). An "error" event, a malformed
+// event, or the context being cancelled stops the stream, and is returned as a
+// *StreamError with the output text received so far.
+func (r *Response) ReadStream(ctx context.Context, cb func(*ResponseStreamEvent) error) error {
+	if r.Stream == nil {
+		return fmt.Errorf("no stream")
+	}
+
+	// Close the stream when we're done.
+	defer r.Stream.Close()
+
+	s := sse.NewReader(r.Stream)
+	s.MaxEventSize = r.maxEventSize
+
+	// The output text received so far.
+	var partial strings.Builder
+
+	for ctx.Err() == nil {
+		e, err := s.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Prefer the context error if the read failed due to cancellation.
+			if ctx.Err() != nil {
+				break
+			}
+			return &StreamError{Err: err, Partial: partial.String()}
+		}
+
+		// The "event" field is repeated in the type of the JSON payload.
+		data := bytes.TrimSpace(e.Data)
+
+		if bytes.Equal(data, []byte("[DONE]")) {
+			break
+		}
+
+		if err := streamError(data); err != nil {
+			return &StreamError{Err: err, Partial: partial.String()}
+		}
+
+		var event ResponseStreamEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return &StreamError{Err: fmt.Errorf("malformed event: %w", err), Partial: partial.String()}
+		}
+
+		switch event.Type {
+		case ResponseStreamEventError:
+			return &StreamError{Err: &APIError{Code: event.Code, Message: event.Message}, Partial: partial.String()}
+		case ResponseStreamEventOutputTextDelta:
+			partial.WriteString(event.Delta)
+		}
+
+		if err := cb(&event); err != nil {
+			return err
+		}
+	}
+
+	// Check for context errors, keeping the content received so far.
+	if ctx.Err() != nil {
+		return &StreamError{Err: ctx.Err(), Partial: partial.String()}
+	}
+
+	return nil
+}
+
+// CreateResponse creates a model response using the Responses API.
+//
+// # Example
+//
+//	resp, _ := client.CreateResponse(ctx, &openai.CreateResponseRequest{
+//		Model: "gpt-4o",
+//		Input: "Draw a gopher wearing an OpenAI t-shirt.",
+//		Tools: []openai.ResponseTool{
+//			{Type: openai.ResponseToolTypeImageGeneration},
+//		},
+//	})
+//
+//	images, _ := resp.Images()
+//
+// https://platform.openai.com/docs/api-reference/responses/create
+func (c *Client) CreateResponse(ctx context.Context, req *CreateResponseRequest) (*Response, error) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/responses", "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	var res Response
+	if !req.Stream {
+		defer resp.Body.Close()
+		if err := json.NewDecoder(c.limitBody(resp.Body)).Decode(&res); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	} else {
+		res.Stream = resp.Body
+		res.maxEventSize = c.MaxStreamEventSize
+	}
+
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/responses/get
+type GetResponseRequest struct {
+	// Required.
+	ID string `json:"-"`
+}
+
+// GetResponse retrieves a model response with the given ID. Only responses
+// created with Store enabled (the default) can be retrieved.
+//
+// https://platform.openai.com/docs/api-reference/responses/get
+func (c *Client) GetResponse(ctx context.Context, req *GetResponseRequest) (*Response, error) {
+	var res Response
+	err := c.do(ctx, http.MethodGet, "/responses/"+req.ID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/responses/delete
+type DeleteResponseRequest struct {
+	// Required.
+	ID string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/responses/delete
+type DeleteResponseResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// DeleteResponse deletes a model response with the given ID.
+//
+// https://platform.openai.com/docs/api-reference/responses/delete
+func (c *Client) DeleteResponse(ctx context.Context, req *DeleteResponseRequest) (*DeleteResponseResponse, error) {
+	var res DeleteResponseResponse
+	err := c.do(ctx, http.MethodDelete, "/responses/"+req.ID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/responses/input-items
+type ListResponseInputItemsRequest struct {
+	// ResponseID is the ID of the response to list the input items of.
+	//
+	// Required.
+	ResponseID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/responses/input-items#responses-input-items-limit
+	//
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/responses/input-items#responses-input-items-order
+	//
+	// Optional. Defaults to "desc".
+	Order SortOrder `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/responses/input-items#responses-input-items-after
+	//
+	// Optional.
+	After string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/responses/input-items#responses-input-items-before
+	//
+	// Optional.
+	Before string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/responses/input-items
+type ListResponseInputItemsResponse = Page[ResponseInputItem]
+
+// ListResponseInputItems lists the input items used to generate a model response.
+//
+// https://platform.openai.com/docs/api-reference/responses/input-items
+func (c *Client) ListResponseInputItems(ctx context.Context, req *ListResponseInputItemsRequest) (*ListResponseInputItemsResponse, error) {
+	if req.Order != "" && !req.Order.IsValid() {
+		return nil, fmt.Errorf("invalid order: %q", req.Order)
+	}
+
+	var res ListResponseInputItemsResponse
+	path := "/responses/" + req.ResponseID + "/input_items" + listQuery(req.Limit, req.Order, req.After, req.Before)
+	err := c.do(ctx, http.MethodGet, path, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListResponseInputItemsRequest, after string) { r.After = after }, c.ListResponseInputItems)
+	return &res, nil
+}
@@ -0,0 +1,243 @@
+package openai_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestCreateResponse_ImageGeneration(t *testing.T) {
+	png := []byte("\x89PNG fake image")
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/responses" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var req openai.CreateResponseRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(req.Tools) != 1 || req.Tools[0].Type != openai.ResponseToolTypeImageGeneration {
+			t.Errorf("expected image_generation tool, got %#+v", req.Tools)
+		}
+
+		fmt.Fprintf(w, `{
+			"id": "resp_123",
+			"object": "response",
+			"status": "completed",
+			"output": [
+				{"type": "image_generation_call", "id": "ig_123", "status": "completed", "result": %q},
+				{"type": "message", "id": "msg_123", "role": "assistant", "content": [{"type": "output_text", "text": "Here is your gopher."}]}
+			]
+		}`, base64.StdEncoding.EncodeToString(png))
+	})
+
+	resp, err := c.CreateResponse(testCtx(t), &openai.CreateResponseRequest{
+		Model: "gpt-4o",
+		Input: "Draw a gopher.",
+		Tools: []openai.ResponseTool{
+			{Type: openai.ResponseToolTypeImageGeneration, Quality: "low"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	images, err := resp.Images()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(images) != 1 || !bytes.Equal(images[0], png) {
+		t.Fatalf("unexpected images: %q", images)
+	}
+
+	if resp.OutputText() != "Here is your gopher." {
+		t.Fatalf("unexpected output text: %q", resp.OutputText())
+	}
+}
+
+func TestCreateResponse_ImageGenerationPartialImages(t *testing.T) {
+	partial := []byte("partial")
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: response.created\ndata: {\"type\":\"response.created\",\"response\":{\"id\":\"resp_123\"}}\n\n")
+		fmt.Fprintf(w, "event: response.image_generation_call.partial_image\ndata: {\"type\":\"response.image_generation_call.partial_image\",\"item_id\":\"ig_123\",\"partial_image_index\":0,\"partial_image_b64\":%q}\n\n", base64.StdEncoding.EncodeToString(partial))
+		fmt.Fprintf(w, "event: response.completed\ndata: {\"type\":\"response.completed\",\"response\":{\"id\":\"resp_123\",\"status\":\"completed\"}}\n\n")
+	})
+
+	ctx := testCtx(t)
+
+	resp, err := c.CreateResponse(ctx, &openai.CreateResponseRequest{
+		Model:  "gpt-4o",
+		Input:  "Draw a gopher.",
+		Tools:  []openai.ResponseTool{{Type: openai.ResponseToolTypeImageGeneration, PartialImages: 1}},
+		Stream: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		partials  [][]byte
+		completed bool
+	)
+
+	err = resp.ReadStream(ctx, func(e *openai.ResponseStreamEvent) error {
+		switch e.Type {
+		case openai.ResponseStreamEventImageGenerationCallPartialImage:
+			b, err := e.PartialImage()
+			if err != nil {
+				return err
+			}
+			partials = append(partials, b)
+		case openai.ResponseStreamEventCompleted:
+			completed = true
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(partials) != 1 || !bytes.Equal(partials[0], partial) {
+		t.Fatalf("unexpected partial images: %q", partials)
+	}
+
+	if !completed {
+		t.Fatal("expected response.completed event")
+	}
+}
+
+func TestCreateResponse_InputItems(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []openai.ResponseInputItem `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(req.Input) != 2 {
+			t.Fatalf("expected 2 input items, got %#+v", req.Input)
+		}
+
+		if msg := req.Input[0]; msg.Type != "message" || msg.Role != "user" || msg.Content[0].Type != "input_text" || msg.Content[0].Text != "What's the weather?" {
+			t.Errorf("unexpected message item: %#+v", msg)
+		}
+
+		if out := req.Input[1]; out.Type != "function_call_output" || out.CallID != "call_123" || out.Output != `{"temp":20}` {
+			t.Errorf("unexpected function call output item: %#+v", out)
+		}
+
+		fmt.Fprint(w, `{
+			"id": "resp_123",
+			"object": "response",
+			"status": "completed",
+			"output": [
+				{"type": "reasoning", "id": "rs_123", "summary": [{"type": "summary_text", "text": "Checking the weather."}]},
+				{"type": "function_call", "id": "fc_123", "call_id": "call_456", "name": "get_forecast", "arguments": "{}"}
+			]
+		}`)
+	})
+
+	resp, err := c.CreateResponse(testCtx(t), &openai.CreateResponseRequest{
+		Model: "gpt-4o",
+		Input: []openai.ResponseInputItem{
+			openai.ResponseInputMessage(openai.RoleUser, "What's the weather?"),
+			openai.ResponseFunctionCallOutput("call_123", `{"temp":20}`),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := resp.Output[0].Summary; len(s) != 1 || s[0].Text != "Checking the weather." {
+		t.Errorf("unexpected reasoning summary: %#+v", s)
+	}
+
+	calls := resp.FunctionCalls()
+	if len(calls) != 1 || calls[0].CallID != "call_456" || calls[0].Name != "get_forecast" {
+		t.Fatalf("unexpected function calls: %#+v", calls)
+	}
+}
+
+func TestGetAndDeleteResponse(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/responses/resp_123" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"id": "resp_123", "object": "response", "status": "completed", "output": []}`)
+		case http.MethodDelete:
+			fmt.Fprint(w, `{"id": "resp_123", "object": "response.deleted", "deleted": true}`)
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	})
+
+	resp, err := c.GetResponse(testCtx(t), &openai.GetResponseRequest{ID: "resp_123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.ID != "resp_123" || resp.Status != "completed" {
+		t.Fatalf("unexpected response: %#+v", resp)
+	}
+
+	del, err := c.DeleteResponse(testCtx(t), &openai.DeleteResponseRequest{ID: "resp_123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !del.Deleted {
+		t.Fatalf("expected response to be deleted: %#+v", del)
+	}
+}
+
+func TestListResponseInputItems(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/responses/resp_123/input_items" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		switch after := r.URL.Query().Get("after"); after {
+		case "":
+			if r.URL.Query().Get("limit") != "1" {
+				t.Errorf("unexpected limit: %q", r.URL.Query().Get("limit"))
+			}
+			fmt.Fprint(w, `{"object": "list", "data": [{"type": "message", "id": "msg_1", "role": "user", "content": [{"type": "input_text", "text": "hi"}]}], "first_id": "msg_1", "last_id": "msg_1", "has_more": true}`)
+		case "msg_1":
+			fmt.Fprint(w, `{"object": "list", "data": [{"type": "function_call_output", "id": "fco_1", "call_id": "call_1", "output": "ok"}], "first_id": "fco_1", "last_id": "fco_1", "has_more": false}`)
+		default:
+			t.Errorf("unexpected after: %q", after)
+		}
+	})
+
+	page, err := c.ListResponseInputItems(testCtx(t), &openai.ListResponseInputItemsRequest{ResponseID: "resp_123", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for item, err := range page.All(testCtx(t)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, item.ID)
+	}
+
+	if len(ids) != 2 || ids[0] != "msg_1" || ids[1] != "fco_1" {
+		t.Fatalf("unexpected input items: %v", ids)
+	}
+}
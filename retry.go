@@ -0,0 +1,136 @@
+package openai
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures the backoff between retried requests.
+type RetryPolicy struct {
+	// BaseDelay is the delay before the first retry, doubled for each retry
+	// after it. Defaults to 500ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between retries, including delays requested by
+	// the API with the Retry-After header. Defaults to 30s.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the default RetryPolicy, whose values are also used in
+// place of any zero fields of a policy.
+var DefaultRetryPolicy = RetryPolicy{
+	BaseDelay: 500 * time.Millisecond,
+	MaxDelay:  30 * time.Second,
+}
+
+// WithRetry is a ClientOption that retries requests failing with a 429 or 5xx
+// status code, up to maxAttempts attempts in total. The Retry-After header is
+// honored when present, otherwise the policy's jittered exponential backoff is
+// used.
+//
+// Requests whose body can't be replayed, such as file uploads, aren't retried.
+//
+// # Example
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithRetry(3, openai.DefaultRetryPolicy))
+func WithRetry(maxAttempts int, policy RetryPolicy) ClientOption {
+	return func(client *Client) {
+		client.MaxAttempts = maxAttempts
+		client.RetryPolicy = policy
+	}
+}
+
+// shouldRetry reports whether a response with the given status code is worth retrying.
+func shouldRetry(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// delay returns how long to wait before the given retry (starting at 1), using
+// the response's Retry-After headers if present.
+func (p RetryPolicy) delay(retry int, h http.Header) time.Duration {
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+
+	if d, ok := retryAfter(h); ok {
+		return min(d, p.MaxDelay)
+	}
+
+	d := p.BaseDelay << (retry - 1)
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	// Use "equal jitter", waiting between half and all of the delay, so
+	// concurrent clients don't retry in lockstep.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the delay requested by the retry-after-ms or Retry-After
+// headers, the latter as either a number of seconds or an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	if v := h.Get("Retry-After-Ms"); v != "" {
+		if ms, err := strconv.ParseFloat(v, 64); err == nil && ms >= 0 {
+			return time.Duration(ms * float64(time.Millisecond)), true
+		}
+	}
+
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
+
+// send sends the request with the client's HTTP client and middleware,
+// retrying it according to the client's retry configuration.
+func (c *Client) send(r *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.roundTrip(r)
+		if err != nil || attempt >= c.MaxAttempts || !shouldRetry(resp.StatusCode) {
+			return resp, err
+		}
+
+		if r.Body != nil && r.GetBody == nil {
+			return resp, nil
+		}
+
+		delay := c.RetryPolicy.delay(attempt, resp.Header)
+
+		// Drain and close the body so the connection can be reused.
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+
+		t := time.NewTimer(delay)
+		select {
+		case <-r.Context().Done():
+			t.Stop()
+			return nil, r.Context().Err()
+		case <-t.C:
+		}
+
+		next := r.Clone(r.Context())
+		if r.GetBody != nil {
+			next.Body, err = r.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+		r = next
+	}
+}
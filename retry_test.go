@@ -0,0 +1,99 @@
+package openai_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestWithRetry(t *testing.T) {
+	var (
+		attempts int
+		bodies   []string
+	)
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, `{"error":{"message":"slow down","type":"requests"}}`, http.StatusTooManyRequests)
+			return
+		}
+
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"Hi!"}}]}`)
+	}
+
+	c := openai.NewClient("test",
+		openai.WithRetry(3, openai.RetryPolicy{BaseDelay: time.Millisecond}),
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+	)
+
+	ctx := testCtx(t)
+
+	resp, err := c.CreateChat(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT35Turbo,
+		Messages: []openai.ChatMessage{openai.User("Hi")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Choices[0].Message.Content != "Hi!" {
+		t.Fatalf("unexpected content: %q", resp.Choices[0].Message.Content)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+
+	for i, body := range bodies {
+		if !strings.Contains(body, `"Hi"`) {
+			t.Errorf("attempt %d: request body was not replayed: %q", i+1, body)
+		}
+	}
+}
+
+func TestWithRetry_Exhausted(t *testing.T) {
+	var attempts int
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}
+
+	ctx := testCtx(t)
+
+	_, err := testClient(t, h).ListModels(ctx)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if attempts != 1 {
+		t.Fatalf("expected no retries by default, got %d attempts", attempts)
+	}
+
+	attempts = 0
+
+	c := openai.NewClient("test",
+		openai.WithRetry(2, openai.RetryPolicy{BaseDelay: time.Millisecond}),
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+	)
+
+	_, err = c.ListModels(ctx)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
@@ -0,0 +1,147 @@
+package openai
+
+import (
+	"net/url"
+	"strings"
+)
+
+// RunStepIncludeFileSearchContent includes the content of the results of
+// file_search calls in run steps, when given to the Include of
+// GetRunStepRequest or ListRunStepsRequest.
+const RunStepIncludeFileSearchContent = "step_details.tool_calls[*].file_search.results[*].content"
+
+// RunStepDetails are the details of a run step: either the message it created,
+// or the tool calls it made.
+//
+// https://platform.openai.com/docs/api-reference/run-steps/step-object#run-steps/step-object-step_details
+type RunStepDetails struct {
+	// Type is the type of the step, which determines which of the other fields
+	// is set.
+	Type RunStepType `json:"type"`
+
+	// MessageCreation is the message created by a RunStepTypeMessageCreation
+	// step.
+	MessageCreation *RunStepMessageCreation `json:"message_creation,omitempty"`
+
+	// ToolCalls are the tool calls made by a RunStepTypeToolCalls step.
+	ToolCalls []RunStepToolCall `json:"tool_calls,omitempty"`
+}
+
+// RunStepMessageCreation is the message created by a run step.
+type RunStepMessageCreation struct {
+	MessageID string `json:"message_id"`
+}
+
+// RunStepToolCall is a tool call made by a run step. Only the field matching
+// its Type is set.
+//
+// # Example
+//
+//	for _, call := range step.StepDetails.ToolCalls {
+//		if ci := call.CodeInterpreter; ci != nil {
+//			fmt.Println(ci.Input)
+//			for _, out := range ci.Outputs {
+//				if out.Type == "logs" {
+//					fmt.Println(out.Logs)
+//				}
+//			}
+//		}
+//	}
+type RunStepToolCall struct {
+	// ID is the ID of the tool call.
+	ID string `json:"id"`
+
+	// Type is the type of the tool called, "code_interpreter", "file_search"
+	// or "function".
+	Type string `json:"type"`
+
+	// CodeInterpreter is the code run by a "code_interpreter" call, and its
+	// outputs.
+	CodeInterpreter *CodeInterpreterCall `json:"code_interpreter,omitempty"`
+
+	// FileSearch is the results of a "file_search" call.
+	FileSearch *FileSearchCall `json:"file_search,omitempty"`
+
+	// Function is the function called by a "function" call, and its output.
+	Function *RunStepFunctionCall `json:"function,omitempty"`
+}
+
+// CodeInterpreterCall is the code run by the code_interpreter tool, and its
+// outputs.
+type CodeInterpreterCall struct {
+	// Input is the code that was run.
+	Input string `json:"input"`
+
+	// Outputs are the outputs of the code, such as its logs and the images it
+	// created.
+	Outputs []CodeInterpreterOutput `json:"outputs"`
+}
+
+// CodeInterpreterOutput is an output of code run by the code_interpreter tool.
+type CodeInterpreterOutput struct {
+	// Type is the type of the output, "logs" or "image".
+	Type string `json:"type"`
+
+	// Logs is the text output of a "logs" output.
+	Logs string `json:"logs,omitempty"`
+
+	// Image is the image file of an "image" output, which can be downloaded
+	// with GetFileContent.
+	Image *CodeInterpreterImage `json:"image,omitempty"`
+}
+
+// CodeInterpreterImage is an image created by the code_interpreter tool.
+type CodeInterpreterImage struct {
+	FileID string `json:"file_id"`
+}
+
+// FileSearchCall is the results of a call to the file_search tool.
+type FileSearchCall struct {
+	// Results are the files found. Their content is only included if
+	// requested with RunStepIncludeFileSearchContent.
+	Results []FileSearchResult `json:"results,omitempty"`
+}
+
+// FileSearchResult is a file found by the file_search tool.
+type FileSearchResult struct {
+	FileID   string  `json:"file_id"`
+	FileName string  `json:"file_name"`
+	Score    float64 `json:"score"`
+
+	// Content is the content of the result that was found.
+	Content []FileSearchResultContent `json:"content,omitempty"`
+}
+
+// FileSearchResultContent is the content of a file_search result.
+type FileSearchResultContent struct {
+	// Type is the type of the content, currently only "text".
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// RunStepFunctionCall is a function called by a run step.
+type RunStepFunctionCall struct {
+	Name string `json:"name"`
+
+	// Arguments are the arguments of the call, as a JSON object.
+	Arguments string `json:"arguments"`
+
+	// Output is the output submitted with SubmitToolOutputs, or nil if it
+	// hasn't been yet.
+	Output *string `json:"output"`
+}
+
+// withInclude returns the API path with the given include[] query parameters
+// added.
+func withInclude(path string, include []string) string {
+	if len(include) == 0 {
+		return path
+	}
+
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	return path + sep + url.Values{"include[]": include}.Encode()
+}
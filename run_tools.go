@@ -0,0 +1,152 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrMaxToolIterations is returned by RunTools when the model is still calling
+// tools after the maximum number of iterations.
+var ErrMaxToolIterations = errors.New("maximum tool call iterations reached")
+
+// RunToolsOptions configures RunTools.
+type RunToolsOptions struct {
+	// MaxIterations is the maximum number of chat requests to send.
+	//
+	// Optional. Defaults to 10.
+	MaxIterations int
+
+	// ReportErrors sends the errors of failed tool calls back to the model as
+	// their result, so it can recover, instead of stopping with the error.
+	//
+	// Optional. Defaults to false.
+	ReportErrors bool
+
+	// OnToolCall is called with each tool call and its result message before
+	// the next request is sent, such as for logging.
+	//
+	// Optional.
+	OnToolCall func(call ToolCall, result ChatMessage)
+}
+
+// RunToolsResult is the result of RunTools.
+type RunToolsResult struct {
+	// Response is the final chat response, whose first choice is the model's answer.
+	Response *CreateChatResponse
+
+	// Messages is the conversation, starting with the request's messages and
+	// including every tool call, tool result and the final answer.
+	Messages []ChatMessage
+
+	// Iterations is the number of chat requests sent.
+	Iterations int
+}
+
+// RunTools sends the chat request, handles the tool calls in the model's reply
+// with the given registry, sends their results back, and repeats until the
+// model replies without calling tools.
+//
+// If req has no Tools or Functions, the registry's tools are sent with it.
+// Legacy function calls are handled too, and answered with function messages.
+// The given request isn't modified.
+//
+// # Example
+//
+//	res, err := client.RunTools(ctx, &openai.CreateChatRequest{
+//		Model:    openai.ModelGPT4,
+//		Messages: []openai.ChatMessage{openai.User("What's the weather in Paris?")},
+//	}, tools, nil)
+//	if err != nil {
+//		return err
+//	}
+//
+//	answer, _ := res.Response.FirstChoice()
+//	fmt.Println(answer.Content)
+func (c *Client) RunTools(ctx context.Context, req *CreateChatRequest, registry *ToolRegistry, opts *RunToolsOptions) (*RunToolsResult, error) {
+	if opts == nil {
+		opts = &RunToolsOptions{}
+	}
+
+	maxIterations := opts.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = 10
+	}
+
+	r := *req
+	r.Stream = false
+	r.Messages = slices.Clone(req.Messages)
+
+	if len(r.Tools) == 0 && len(r.Functions) == 0 {
+		r.Tools = registry.Tools()
+	}
+
+	res := &RunToolsResult{}
+
+	for res.Iterations < maxIterations {
+		resp, err := c.CreateChat(ctx, &r)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Iterations++
+		res.Response = resp
+
+		msg, err := resp.FirstChoice()
+		if err != nil {
+			return nil, err
+		}
+
+		r.Messages = append(r.Messages, *msg)
+
+		if len(msg.ToolCalls) == 0 && msg.FunctionCall == nil {
+			res.Messages = r.Messages
+			return res, nil
+		}
+
+		for _, call := range msg.ToolCalls {
+			result, err := runTool(ctx, registry, call, opts)
+			if err != nil {
+				return nil, err
+			}
+			r.Messages = append(r.Messages, result)
+		}
+
+		if msg.FunctionCall != nil {
+			call := ToolCall{Type: ToolTypeFunction, Function: msg.FunctionCall}
+
+			result, err := runTool(ctx, registry, call, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			r.Messages = append(r.Messages, ChatMessage{
+				Role:    RoleFunction,
+				Name:    msg.FunctionCall.Name,
+				Content: result.Content,
+			})
+		}
+	}
+
+	res.Messages = r.Messages
+	return res, ErrMaxToolIterations
+}
+
+// runTool handles a single tool call, reporting its error as its result if
+// configured to.
+func runTool(ctx context.Context, registry *ToolRegistry, call ToolCall, opts *RunToolsOptions) (ChatMessage, error) {
+	result, err := registry.Call(ctx, call)
+	if err != nil {
+		if !opts.ReportErrors || ctx.Err() != nil {
+			return ChatMessage{}, err
+		}
+		result = ToolResult(call.ID, fmt.Sprintf("error: %v", err))
+	}
+
+	if opts.OnToolCall != nil {
+		opts.OnToolCall(call, result)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestRunTools(t *testing.T) {
+	var requests int
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var req openai.CreateChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(req.Tools) != 1 || req.Tools[0].Function.Name != "get_weather" {
+			t.Errorf("expected registry tools to be sent, got %#+v", req.Tools)
+		}
+
+		last := req.Messages[len(req.Messages)-1]
+
+		switch requests {
+		case 1:
+			fmt.Fprint(w, `{"choices":[{"index":0,"finish_reason":"tool_calls","message":{"role":"assistant","content":null,"tool_calls":[
+				{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\":\"Paris\"}"}},
+				{"id":"call_2","type":"function","function":{"name":"get_weather","arguments":"{\"location\":\"Atlantis\"}"}}
+			]}}]}`)
+		case 2:
+			if last.Role != openai.ChatRoleTool || last.ToolCallID != "call_2" || !strings.HasPrefix(last.Content, "error: ") {
+				t.Errorf("expected reported tool error, got %#+v", last)
+			}
+			fmt.Fprint(w, `{"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"It's sunny in Paris."}}]}`)
+		default:
+			t.Errorf("unexpected request %d", requests)
+		}
+	})
+
+	tools := openai.NewToolRegistry()
+
+	err := openai.RegisterTool(tools, "get_weather", "Get the current weather.", func(ctx context.Context, args weatherArgs) (string, error) {
+		if args.Location == "Atlantis" {
+			return "", errors.New("unknown location")
+		}
+		return "Sunny in " + args.Location, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var calls []string
+
+	req := &openai.CreateChatRequest{
+		Model:    openai.ModelGPT4,
+		Messages: []openai.ChatMessage{openai.User("What's the weather in Paris and Atlantis?")},
+	}
+
+	res, err := c.RunTools(testCtx(t), req, tools, &openai.RunToolsOptions{
+		ReportErrors: true,
+		OnToolCall: func(call openai.ToolCall, result openai.ChatMessage) {
+			calls = append(calls, call.ID+": "+result.Content)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	answer, err := res.Response.FirstChoice()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if answer.Content != "It's sunny in Paris." || res.Iterations != 2 {
+		t.Fatalf("unexpected answer after %d iterations: %q", res.Iterations, answer.Content)
+	}
+
+	// The user message, the tool calls, two results and the answer.
+	if len(res.Messages) != 5 || len(req.Messages) != 1 || req.Tools != nil {
+		t.Fatalf("unexpected messages: %d, request modified: %v", len(res.Messages), len(req.Messages) != 1)
+	}
+
+	if len(calls) != 2 || calls[0] != "call_1: Sunny in Paris" {
+		t.Fatalf("unexpected tool calls: %q", calls)
+	}
+}
+
+func TestRunTools_MaxIterations(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"choices":[{"index":0,"finish_reason":"tool_calls","message":{"role":"assistant","content":null,"tool_calls":[
+			{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"location\":\"Paris\"}"}}
+		]}}]}`)
+	})
+
+	tools := openai.NewToolRegistry()
+
+	err := openai.RegisterTool(tools, "get_weather", "", func(ctx context.Context, args weatherArgs) (string, error) {
+		return "Sunny", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.RunTools(testCtx(t), &openai.CreateChatRequest{
+		Model:    openai.ModelGPT4,
+		Messages: []openai.ChatMessage{openai.User("Weather?")},
+	}, tools, &openai.RunToolsOptions{MaxIterations: 3})
+
+	if !errors.Is(err, openai.ErrMaxToolIterations) {
+		t.Fatalf("expected ErrMaxToolIterations, got %v", err)
+	}
+
+	if res.Iterations != 3 {
+		t.Fatalf("expected 3 iterations, got %d", res.Iterations)
+	}
+}
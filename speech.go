@@ -0,0 +1,131 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/picatz/openai/sse"
+)
+
+// SpeechStreamEventType is the type of an event streamed by CreateSpeech with
+// the "sse" stream format.
+type SpeechStreamEventType string
+
+const (
+	SpeechStreamEventAudioDelta SpeechStreamEventType = "speech.audio.delta"
+	SpeechStreamEventAudioDone  SpeechStreamEventType = "speech.audio.done"
+)
+
+// SpeechStreamEvent is an event streamed by CreateSpeech with the "sse" stream format.
+//
+// https://platform.openai.com/docs/api-reference/audio/speech-audio-delta-event
+type SpeechStreamEvent struct {
+	Type SpeechStreamEventType `json:"type"`
+
+	// Audio is a base64 encoded chunk of audio, for "speech.audio.delta" events.
+	Audio string `json:"audio,omitempty"`
+}
+
+// CreateSpeechStream generates speech for the request, writing the audio to w
+// as it is received, so it can start playing before synthesis finishes. It
+// returns the number of bytes of audio written.
+//
+// If onProgress isn't nil, it is called with the total number of bytes written
+// after each chunk of audio. Both stream formats are supported: with the "sse"
+// format, the audio of each event is decoded and written.
+//
+// # Example
+//
+//	player := newPlayer() // An io.Writer that plays PCM audio.
+//
+//	_, err := client.CreateSpeechStream(ctx, &openai.CreateSpeechRequest{
+//		Model:          openai.ModelTTS1,
+//		Input:          "Hello, world!",
+//		Voice:          openai.SpeechVoiceAlloy,
+//		ResponseFormat: openai.SpeechFormatPCM,
+//	}, player, nil)
+func (c *Client) CreateSpeechStream(ctx context.Context, req *CreateSpeechRequest, w io.Writer, onProgress func(written int64)) (int64, error) {
+	body, err := c.CreateSpeech(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	pw := &progressWriter{w: w, onProgress: onProgress}
+
+	if req.StreamFormat == SpeechStreamFormatSSE {
+		err = c.copySpeechEvents(pw, body)
+	} else {
+		_, err = io.Copy(pw, body)
+	}
+
+	if ctx.Err() != nil {
+		return pw.n, ctx.Err()
+	}
+
+	return pw.n, err
+}
+
+// copySpeechEvents writes the audio of the speech events read from r to w.
+func (c *Client) copySpeechEvents(w io.Writer, r io.Reader) error {
+	s := sse.NewReader(r)
+	s.MaxEventSize = c.MaxStreamEventSize
+
+	for {
+		e, err := s.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		data := bytes.TrimSpace(e.Data)
+
+		if err := streamError(data); err != nil {
+			return err
+		}
+
+		var event SpeechStreamEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("malformed speech event: %w", err)
+		}
+
+		switch event.Type {
+		case SpeechStreamEventAudioDelta:
+			audio, err := base64.StdEncoding.DecodeString(event.Audio)
+			if err != nil {
+				return fmt.Errorf("malformed speech audio: %w", err)
+			}
+
+			if _, err := w.Write(audio); err != nil {
+				return err
+			}
+		case SpeechStreamEventAudioDone:
+			return nil
+		}
+	}
+}
+
+// progressWriter counts the bytes written to w, reporting the total to
+// onProgress after each write.
+type progressWriter struct {
+	w          io.Writer
+	n          int64
+	onProgress func(written int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.n += int64(n)
+
+	if n > 0 && p.onProgress != nil {
+		p.onProgress(p.n)
+	}
+
+	return n, err
+}
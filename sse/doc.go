@@ -0,0 +1,5 @@
+// Package sse provides a reader for server-sent events (SSE), the format used
+// by the OpenAI API to stream responses.
+//
+// https://html.spec.whatwg.org/multipage/server-sent-events.html
+package sse
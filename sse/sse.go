@@ -0,0 +1,153 @@
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ErrEventTooLarge is returned by Reader.Next when an event is larger than the
+// reader's MaxEventSize.
+var ErrEventTooLarge = errors.New("stream event too large")
+
+// Event is a single server-sent event.
+//
+// https://html.spec.whatwg.org/multipage/server-sent-events.html#event-stream-interpretation
+type Event struct {
+	// ID is the value of the event's "id" field, if any.
+	ID string
+
+	// Event is the value of the event's "event" field, if any.
+	Event string
+
+	// Data is the value of the event's "data" fields, joined with newlines.
+	Data []byte
+
+	// Retry is the value of the event's "retry" field in milliseconds, or 0
+	// if it was not set or not a valid integer.
+	Retry int
+}
+
+// Reader reads server-sent events from a stream.
+//
+// Unlike a bufio.Scanner, it handles lines of any length, and joins multi-line
+// "data" fields. Comments, which are often used as keepalives, are skipped.
+type Reader struct {
+	r *bufio.Reader
+
+	// MaxEventSize is the maximum size of an event's data in bytes, or 0 for
+	// no limit.
+	MaxEventSize int
+}
+
+// NewReader returns a new Reader that reads events from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		r: bufio.NewReader(r),
+	}
+}
+
+func (s *Reader) errTooLarge() error {
+	return fmt.Errorf("%w: more than %d bytes", ErrEventTooLarge, s.MaxEventSize)
+}
+
+// readLine reads a single line, without the line ending. If limit is greater
+// than 0, lines longer than limit bytes return ErrEventTooLarge.
+func (s *Reader) readLine(limit int) ([]byte, error) {
+	var line []byte
+
+	for {
+		chunk, err := s.r.ReadSlice('\n')
+		line = append(line, chunk...)
+
+		if limit > 0 && len(line) > limit {
+			return nil, s.errTooLarge()
+		}
+
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				return bytes.TrimRight(line, "\r\n"), nil
+			}
+			return nil, err
+		}
+
+		return bytes.TrimRight(line, "\r\n"), nil
+	}
+}
+
+// Next returns the next event in the stream, or io.EOF when the stream ends.
+// Events without any data are skipped, and a final event that isn't followed
+// by a blank line is still returned.
+func (s *Reader) Next() (*Event, error) {
+	var (
+		event   Event
+		hasData bool
+	)
+
+	for {
+		limit := 0
+		if s.MaxEventSize > 0 {
+			// Allow for the field name and line ending in addition to the data.
+			limit = s.MaxEventSize - len(event.Data) + len("data: \r\n")
+		}
+
+		line, err := s.readLine(limit)
+		if err == io.EOF {
+			if hasData {
+				return &event, nil
+			}
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// A blank line dispatches the event.
+		if len(line) == 0 {
+			if hasData {
+				return &event, nil
+			}
+			event = Event{}
+			continue
+		}
+
+		// Lines starting with a colon are comments.
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value, _ := bytes.Cut(line, []byte{':'})
+		value = bytes.TrimPrefix(value, []byte{' '})
+
+		switch string(field) {
+		case "data":
+			if hasData {
+				event.Data = append(event.Data, '\n')
+			}
+			event.Data = append(event.Data, value...)
+			hasData = true
+		case "event":
+			event.Event = string(value)
+		case "id":
+			// IDs containing NULL are ignored, per the specification.
+			if !bytes.ContainsRune(value, 0) {
+				event.ID = string(value)
+			}
+		case "retry":
+			if n, err := strconv.Atoi(string(value)); err == nil && n >= 0 {
+				event.Retry = n
+			}
+		}
+
+		if s.MaxEventSize > 0 && len(event.Data) > s.MaxEventSize {
+			return nil, s.errTooLarge()
+		}
+	}
+}
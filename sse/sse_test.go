@@ -0,0 +1,154 @@
+package sse_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai/sse"
+)
+
+func readAll(t testing.TB, r *sse.Reader) ([]*sse.Event, error) {
+	t.Helper()
+
+	var events []*sse.Event
+	for {
+		event, err := r.Next()
+		if err == io.EOF {
+			return events, nil
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event)
+	}
+}
+
+func TestReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []sse.Event
+	}{
+		{
+			name:  "single event",
+			input: "data: hello\n\n",
+			want:  []sse.Event{{Data: []byte("hello")}},
+		},
+		{
+			name:  "all fields",
+			input: "id: 1\nevent: message\nretry: 1000\ndata: hello\n\n",
+			want:  []sse.Event{{ID: "1", Event: "message", Retry: 1000, Data: []byte("hello")}},
+		},
+		{
+			name:  "multi-line data",
+			input: "data: a\ndata: b\r\ndata:c\n\n",
+			want:  []sse.Event{{Data: []byte("a\nb\nc")}},
+		},
+		{
+			name:  "comments and keepalives",
+			input: ": keepalive\n\n:ping\ndata: hello\n\n: keepalive\n\n",
+			want:  []sse.Event{{Data: []byte("hello")}},
+		},
+		{
+			name:  "events without data are skipped",
+			input: "event: ping\n\ndata: hello\n\n",
+			want:  []sse.Event{{Data: []byte("hello")}},
+		},
+		{
+			name:  "final event without trailing blank line",
+			input: "data: a\n\ndata: b",
+			want:  []sse.Event{{Data: []byte("a")}, {Data: []byte("b")}},
+		},
+		{
+			name:  "invalid retry and unknown fields are ignored",
+			input: "retry: soon\nfoo: bar\ndata: hello\n\n",
+			want:  []sse.Event{{Data: []byte("hello")}},
+		},
+		{
+			name:  "empty",
+			input: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events, err := readAll(t, sse.NewReader(strings.NewReader(test.input)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(events) != len(test.want) {
+				t.Fatalf("got %d events, want %d", len(events), len(test.want))
+			}
+
+			for i, event := range events {
+				want := test.want[i]
+				if event.ID != want.ID || event.Event != want.Event || event.Retry != want.Retry || !bytes.Equal(event.Data, want.Data) {
+					t.Errorf("event %d: got %+v, want %+v", i, *event, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReader_LongLines(t *testing.T) {
+	large := strings.Repeat("a", 1<<20)
+
+	events, err := readAll(t, sse.NewReader(strings.NewReader("data: "+large+"\n\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 || string(events[0].Data) != large {
+		t.Fatalf("unexpected events: %d", len(events))
+	}
+}
+
+func TestReader_MaxEventSize(t *testing.T) {
+	r := sse.NewReader(strings.NewReader("data: small\n\ndata: " + strings.Repeat("a", 100) + "\n\n"))
+	r.MaxEventSize = 64
+
+	event, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(event.Data) != "small" {
+		t.Fatalf("unexpected data: %q", event.Data)
+	}
+
+	_, err = r.Next()
+	if !errors.Is(err, sse.ErrEventTooLarge) {
+		t.Fatalf("expected ErrEventTooLarge, got %v", err)
+	}
+}
+
+func FuzzReader(f *testing.F) {
+	f.Add([]byte("data: hello\n\n"), 0)
+	f.Add([]byte("id: 1\nevent: message\nretry: 10\ndata: a\ndata: b\r\n\r\n"), 0)
+	f.Add([]byte(": keepalive\n\ndata: {\"choices\":[]}\n\ndata: [DONE]\n\n"), 16)
+	f.Add([]byte("data"), 1)
+	f.Add([]byte("\r\n:\n\n\ndata:\n"), 4)
+
+	f.Fuzz(func(t *testing.T, input []byte, maxEventSize int) {
+		r := sse.NewReader(bytes.NewReader(input))
+		r.MaxEventSize = maxEventSize
+
+		events, err := readAll(t, r)
+		if err != nil && !errors.Is(err, sse.ErrEventTooLarge) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, event := range events {
+			if maxEventSize > 0 && len(event.Data) > maxEventSize {
+				t.Fatalf("event data of %d bytes exceeds limit of %d", len(event.Data), maxEventSize)
+			}
+			if event.Retry < 0 {
+				t.Fatalf("negative retry: %d", event.Retry)
+			}
+		}
+	})
+}
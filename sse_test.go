@@ -0,0 +1,59 @@
+package openai_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestReadStream_LargeAndMultilineEvents(t *testing.T) {
+	large := strings.Repeat("a", 256*1024)
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, ": keepalive\n\n")
+		fmt.Fprintf(w, "data: {\"choices\":[{\"delta\":{\"content\":%q},\"index\":0}]}\r\n\r\n", large)
+		// A single event split across multiple data fields.
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":\ndata: {\"content\":\"!\"},\"index\":0}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}
+
+	ctx := testCtx(t)
+
+	resp, err := testClient(t, h).CreateChat(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT35Turbo,
+		Messages: []openai.ChatMessage{openai.User("Hi")},
+		Stream:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := resp.StreamText(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text != large+"!" {
+		t.Fatalf("unexpected text of length %d", len(text))
+	}
+
+	c := openai.NewClient("test", openai.WithMaxStreamEventSize(1024), openai.WithHTTPClient(testClient(t, h).HTTPClient))
+
+	resp, err = c.CreateChat(ctx, &openai.CreateChatRequest{
+		Model:    openai.ModelGPT35Turbo,
+		Messages: []openai.ChatMessage{openai.User("Hi")},
+		Stream:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = resp.StreamText(ctx, nil)
+	if !errors.Is(err, openai.ErrStreamEventTooLarge) {
+		t.Fatalf("expected ErrStreamEventTooLarge, got %v", err)
+	}
+}
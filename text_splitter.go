@@ -0,0 +1,189 @@
+package openai
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/picatz/openai/tokenizer"
+)
+
+// TextSplitter splits large documents into chunks of a maximum number of
+// tokens, such as for embedding them. Chunks end on paragraph boundaries where
+// possible, then on line, sentence and word boundaries, and may overlap so the
+// context around their edges isn't lost.
+//
+// Token counts are estimated unless the model's encoding is loaded, as
+// described by CountTokens.
+//
+// # Example
+//
+//	splitter := &openai.TextSplitter{
+//		Model:         openai.ModelTextEmbedding3Small,
+//		ChunkTokens:   500,
+//		OverlapTokens: 50,
+//	}
+//
+//	chunks, err := splitter.Split(document)
+//	if err != nil {
+//		return err
+//	}
+//
+//	for _, chunk := range chunks {
+//		resp, err := client.CreateEmbedding(ctx, &openai.CreateEmbeddingRequest{
+//			Model: openai.ModelTextEmbedding3Small,
+//			Input: chunk.Text,
+//		})
+//		if err != nil {
+//			return err
+//		}
+//
+//		index.Add(fmt.Sprint(chunk.Start), resp.Data[0].Float32(), map[string]string{"text": chunk.Text})
+//	}
+type TextSplitter struct {
+	// Model is the model the chunks are for, used to count tokens, such as an
+	// embedding model.
+	//
+	// Required.
+	Model string
+
+	// ChunkTokens is the maximum number of tokens in a chunk.
+	//
+	// Optional. Defaults to 800.
+	ChunkTokens int
+
+	// OverlapTokens is the maximum number of tokens at the end of a chunk that
+	// are repeated at the start of the next one. Only whole sentences, or the
+	// smallest boundaries the chunk was split on, are repeated.
+	//
+	// Optional. Defaults to no overlap.
+	OverlapTokens int
+}
+
+// TextChunk is a chunk of a document split by a TextSplitter.
+type TextChunk struct {
+	// Text is the text of the chunk, without leading and trailing whitespace.
+	Text string
+
+	// Start and End are the byte offsets of the text in the document.
+	Start, End int
+
+	// Tokens is the number of tokens in the text.
+	Tokens int
+}
+
+// splitBoundaries match the separators a document is split on, preferred in
+// order: paragraphs, lines, sentences and words. Separators are kept with the
+// text before them.
+var splitBoundaries = []*regexp.Regexp{
+	regexp.MustCompile(`\n[ \t]*\n\s*`),
+	regexp.MustCompile(`\n\s*`),
+	regexp.MustCompile(`[.!?]+["')\]]*\s+`),
+	regexp.MustCompile(`\s+`),
+}
+
+// textSpan is a span of a document, by byte offsets.
+type textSpan struct {
+	start, end int
+}
+
+// Split returns the chunks of the text, in order.
+func (s *TextSplitter) Split(text string) ([]TextChunk, error) {
+	enc, err := tokenizer.ForModel(s.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	size := s.ChunkTokens
+	if size == 0 {
+		size = 800
+	}
+
+	if size < 0 || s.OverlapTokens < 0 || s.OverlapTokens >= size {
+		return nil, errors.New("invalid chunk or overlap size")
+	}
+
+	count := func(start, end int) int {
+		return enc.Count(text[start:end])
+	}
+
+	units := splitUnits(text, 0, len(text), 0, func(sp textSpan) bool {
+		return count(sp.start, sp.end) <= size
+	}, nil)
+
+	var chunks []TextChunk
+
+	for i := 0; i < len(units); {
+		// Add units while they fit, always adding at least one.
+		j := i
+		for j+1 < len(units) && count(units[i].start, units[j+1].end) <= size {
+			j++
+		}
+
+		if chunk, ok := newTextChunk(text, units[i].start, units[j].end); ok {
+			chunk.Tokens = count(chunk.Start, chunk.End)
+			chunks = append(chunks, chunk)
+		}
+
+		if j == len(units)-1 {
+			break
+		}
+
+		// Repeat the last units that fit the overlap, leaving room for the
+		// next unit.
+		next := j + 1
+		for k := j; k > i; k-- {
+			if count(units[k].start, units[j].end) > s.OverlapTokens || count(units[k].start, units[j+1].end) > size {
+				break
+			}
+			next = k
+		}
+		i = next
+	}
+
+	return chunks, nil
+}
+
+// splitUnits appends the spans of text[start:end] that fit to units, splitting
+// on the given boundary level and those after it as needed, down to single
+// characters.
+func splitUnits(text string, start, end, level int, fits func(textSpan) bool, units []textSpan) []textSpan {
+	span := textSpan{start, end}
+	if fits(span) {
+		return append(units, span)
+	}
+
+	if level == len(splitBoundaries) {
+		for i := start; i < end; {
+			_, n := utf8.DecodeRuneInString(text[i:end])
+			units = append(units, textSpan{i, i + n})
+			i += n
+		}
+		return units
+	}
+
+	prev := start
+	for _, m := range splitBoundaries[level].FindAllStringIndex(text[start:end], -1) {
+		if cut := start + m[1]; cut < end {
+			units = splitUnits(text, prev, cut, level+1, fits, units)
+			prev = cut
+		}
+	}
+
+	return splitUnits(text, prev, end, level+1, fits, units)
+}
+
+// newTextChunk returns the chunk of text[start:end] without leading and
+// trailing whitespace, reporting false if it's empty.
+func newTextChunk(text string, start, end int) (TextChunk, bool) {
+	s := text[start:end]
+
+	trimmed := strings.TrimLeft(s, " \t\r\n")
+	start += len(s) - len(trimmed)
+
+	trimmed = strings.TrimRight(trimmed, " \t\r\n")
+	end = start + len(trimmed)
+
+	return TextChunk{Text: trimmed, Start: start, End: end}, trimmed != ""
+}
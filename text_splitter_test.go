@@ -0,0 +1,67 @@
+package openai_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestTextSplitter(t *testing.T) {
+	intro := "Gophers are small burrowing rodents."
+	body := strings.Repeat("They dig long tunnels under fields and gardens. ", 20)
+	outro := "The end."
+
+	text := intro + "\n\n" + body + "\n\n" + outro
+
+	splitter := &openai.TextSplitter{
+		Model:         openai.ModelTextEmbedding3Small,
+		ChunkTokens:   50,
+		OverlapTokens: 10,
+	}
+
+	chunks, err := splitter.Split(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunks) < 3 {
+		t.Fatalf("expected the text to be split into several chunks, got %d", len(chunks))
+	}
+
+	for i, chunk := range chunks {
+		if text[chunk.Start:chunk.End] != chunk.Text {
+			t.Errorf("chunk %d: offsets don't match its text", i)
+		}
+
+		if chunk.Tokens > splitter.ChunkTokens {
+			t.Errorf("chunk %d: %d tokens exceeds the chunk size", i, chunk.Tokens)
+		}
+
+		if !strings.HasSuffix(chunk.Text, ".") {
+			t.Errorf("chunk %d: expected to end on a sentence boundary: %q", i, chunk.Text)
+		}
+
+		if i > 0 && chunk.Start >= chunks[i-1].End {
+			t.Errorf("chunk %d: expected to overlap the previous chunk", i)
+		}
+	}
+
+	if !strings.HasPrefix(chunks[0].Text, intro) || !strings.HasSuffix(chunks[len(chunks)-1].Text, outro) {
+		t.Errorf("expected the chunks to cover the text")
+	}
+
+	// Text without boundaries is split between characters.
+	chunks, err = splitter.Split(strings.Repeat("x", 1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunks) < 2 {
+		t.Fatalf("expected the text to be split, got %d chunks", len(chunks))
+	}
+
+	if _, err := (&openai.TextSplitter{Model: "unknown"}).Split(text); err == nil {
+		t.Fatal("expected an error for a model without a known encoding")
+	}
+}
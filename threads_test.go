@@ -0,0 +1,290 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestRun_RequiredAction(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/threads/thread_abc/runs/run_abc" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{
+			"id": "run_abc",
+			"object": "thread.run",
+			"status": "requires_action",
+			"required_action": {
+				"type": "submit_tool_outputs",
+				"submit_tool_outputs": {
+					"tool_calls": [{
+						"id": "call_abc",
+						"type": "function",
+						"function": {"name": "get_weather", "arguments": "{\"location\":\"Boston\"}"}
+					}]
+				}
+			}
+		}`)
+	})
+
+	run, err := c.GetRun(testCtx(t), &openai.GetRunRequest{ThreadID: "thread_abc", RunID: "run_abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if run.Status != openai.RunStatusRequiresAction || run.RequiredAction.Type != openai.RunRequiredActionSubmitToolOutputs {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+
+	calls := run.ToolCalls()
+	if len(calls) != 1 || calls[0].ID != "call_abc" || calls[0].Function.Name != "get_weather" {
+		t.Fatalf("unexpected tool calls: %+v", calls)
+	}
+
+	if calls[0].Function.Arguments["location"] != "Boston" {
+		t.Fatalf("unexpected arguments: %v", calls[0].Function.Arguments)
+	}
+
+	if (&openai.Run{}).ToolCalls() != nil {
+		t.Fatal("expected no tool calls without a required action")
+	}
+}
+
+func TestDeleteMessage(t *testing.T) {
+	var deleted bool
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/v1/threads/thread_abc/messages/msg_abc" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		deleted = true
+		fmt.Fprint(w, `{"id":"msg_abc","object":"thread.message.deleted","deleted":true}`)
+	})
+
+	err := c.DeleteMessage(testCtx(t), &openai.DeleteMessageRequest{ThreadID: "thread_abc", MessageID: "msg_abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !deleted {
+		t.Fatal("expected message to be deleted")
+	}
+}
+
+func TestListRunSteps_ToolCalls(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/threads/thread_abc/runs/run_abc/steps" || r.URL.Query().Get("include[]") != openai.RunStepIncludeFileSearchContent {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{
+			"object": "list",
+			"data": [{
+				"id": "step_abc",
+				"object": "thread.run.step",
+				"type": "tool_calls",
+				"status": "completed",
+				"step_details": {
+					"type": "tool_calls",
+					"tool_calls": [{
+						"id": "call_ci",
+						"type": "code_interpreter",
+						"code_interpreter": {
+							"input": "print(2 + 2)",
+							"outputs": [{"type": "logs", "logs": "4\n"}, {"type": "image", "image": {"file_id": "file_img"}}]
+						}
+					}, {
+						"id": "call_fs",
+						"type": "file_search",
+						"file_search": {
+							"results": [{"file_id": "file_doc", "file_name": "doc.md", "score": 0.9, "content": [{"type": "text", "text": "Gophers dig."}]}]
+						}
+					}]
+				}
+			}, {
+				"id": "step_def",
+				"object": "thread.run.step",
+				"type": "message_creation",
+				"status": "completed",
+				"step_details": {"type": "message_creation", "message_creation": {"message_id": "msg_abc"}},
+				"usage": {"prompt_tokens": 1000, "completion_tokens": 100, "total_tokens": 1100}
+			}],
+			"has_more": false
+		}`)
+	})
+
+	resp, err := c.ListRunSteps(testCtx(t), &openai.ListRunStepsRequest{
+		ThreadID: "thread_abc",
+		RunID:    "run_abc",
+		Include:  []string{openai.RunStepIncludeFileSearchContent},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := resp.Data[0].StepDetails.ToolCalls
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(calls))
+	}
+
+	ci := calls[0].CodeInterpreter
+	if ci == nil || ci.Input != "print(2 + 2)" || ci.Outputs[0].Logs != "4\n" || ci.Outputs[1].Image.FileID != "file_img" {
+		t.Errorf("unexpected code_interpreter call: %+v", ci)
+	}
+
+	fs := calls[1].FileSearch
+	if fs == nil || fs.Results[0].FileName != "doc.md" || fs.Results[0].Content[0].Text != "Gophers dig." {
+		t.Errorf("unexpected file_search call: %+v", fs)
+	}
+
+	step := resp.Data[1]
+
+	if mc := step.StepDetails.MessageCreation; step.Type != openai.RunStepTypeMessageCreation || mc == nil || mc.MessageID != "msg_abc" {
+		t.Errorf("unexpected message creation: %+v", mc)
+	}
+
+	if step.Usage == nil || step.Usage.TotalTokens != 1100 {
+		t.Fatalf("unexpected usage: %+v", step.Usage)
+	}
+
+	if cost, ok := step.Usage.Cost(openai.ModelGPT4o); !ok || cost <= 0 {
+		t.Errorf("expected the step to be priced, got %v", cost)
+	}
+}
+
+func TestThreadMessageContent_Citations(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v1/files/")
+		fmt.Fprintf(w, `{"id": %q, "object": "file", "filename": "%s.md"}`, id, id)
+	})
+
+	var msg openai.ThreadMessage
+	err := json.Unmarshal([]byte(`{
+		"id": "msg_abc",
+		"role": "assistant",
+		"content": [{
+			"type": "text",
+			"text": {
+				"value": "Gophers dig tunnels【4:0†source】 and eat roots【4:1†source】. They live alone【4:2†source】.",
+				"annotations": [
+					{"type": "file_citation", "text": "【4:1†source】", "start_index": 40, "end_index": 52, "file_citation": {"file_id": "file_b"}},
+					{"type": "file_citation", "text": "【4:0†source】", "start_index": 19, "end_index": 31, "file_citation": {"file_id": "file_a"}},
+					{"type": "file_citation", "text": "【4:2†source】", "start_index": 68, "end_index": 80, "file_citation": {"file_id": "file_a"}}
+				]
+			}
+		}]
+	}`), &msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, citations := msg.Content[0].Citations()
+
+	if want := "Gophers dig tunnels[1] and eat roots[2]. They live alone[1]."; text != want {
+		t.Fatalf("unexpected text:\n got: %q\nwant: %q", text, want)
+	}
+
+	if err := c.ResolveCitations(testCtx(t), citations); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []openai.Citation{
+		{Number: 1, Type: openai.MessageAnnotationFileCitation, FileID: "file_a", FileName: "file_a.md"},
+		{Number: 2, Type: openai.MessageAnnotationFileCitation, FileID: "file_b", FileName: "file_b.md"},
+	}
+
+	if fmt.Sprint(citations) != fmt.Sprint(want) {
+		t.Fatalf("unexpected citations:\n got: %+v\nwant: %+v", citations, want)
+	}
+}
+
+func TestCancelRun(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/threads/thread_abc/runs/run_abc/cancel" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": "cancelling"}`)
+	})
+
+	run, err := c.CancelRun(testCtx(t), &openai.CancelRunRequest{ThreadID: "thread_abc", RunID: "run_abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if run.ID != "run_abc" || run.Status != openai.RunStatusCancelling {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+}
+
+func TestWaitForRun(t *testing.T) {
+	statuses := []string{"queued", "in_progress", "requires_action", "in_progress", "completed"}
+
+	var polls int
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		fmt.Fprintf(w, `{"id": "run_abc", "object": "thread.run", "thread_id": "thread_abc", "status": %q}`, status)
+	})
+
+	var actions int
+
+	run, err := openai.WaitForRun(testCtx(t), c, "thread_abc", "run_abc", &openai.WaitForRunOptions{
+		Interval: time.Millisecond,
+		OnRequiresAction: func(ctx context.Context, run *openai.Run) error {
+			actions++
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if run.Status != openai.RunStatusCompleted || polls != len(statuses) || actions != 1 {
+		t.Fatalf("unexpected run %q after %d polls and %d actions", run.Status, polls, actions)
+	}
+
+	// Without a callback, the run is returned once it requires action.
+	polls = 0
+
+	run, err = openai.WaitForRun(testCtx(t), c, "thread_abc", "run_abc", &openai.WaitForRunOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if run.Status != openai.RunStatusRequiresAction {
+		t.Fatalf("expected the run to require action, got %q", run.Status)
+	}
+
+	// An incomplete run is returned with an error.
+	statuses = []string{"incomplete"}
+
+	run, err = openai.WaitForRun(testCtx(t), c, "thread_abc", "run_abc", &openai.WaitForRunOptions{Interval: time.Millisecond})
+	if err == nil || run == nil || run.Status != openai.RunStatusIncomplete {
+		t.Fatalf("expected an incomplete run with an error, got %+v, %v", run, err)
+	}
+
+	// The overall timeout stops waiting.
+	statuses = []string{"in_progress"}
+
+	_, err = openai.WaitForRun(testCtx(t), c, "thread_abc", "run_abc", &openai.WaitForRunOptions{
+		Interval: time.Millisecond,
+		Timeout:  20 * time.Millisecond,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline exceeded error, got %v", err)
+	}
+}
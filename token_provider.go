@@ -0,0 +1,55 @@
+package openai
+
+import "context"
+
+// TokenProvider provides the bearer token sent in the Authorization header of
+// each request, such as an Azure AD token, a key issued by a secrets manager, or
+// a rotating credential. It is called once per request, so it can refresh
+// expired credentials, and should cache them between calls.
+//
+// A TokenProvider must be safe for concurrent use.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenProviderFunc is a TokenProvider implemented by a function.
+type TokenProviderFunc func(ctx context.Context) (string, error)
+
+// Token implements the TokenProvider interface.
+func (f TokenProviderFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticToken is a TokenProvider that always provides the same API key.
+type StaticToken string
+
+// Token implements the TokenProvider interface.
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// WithTokenProvider is a ClientOption that sets the provider of the token sent
+// with each request, instead of the client's static API key. The admin key, if
+// any, is still used for organization management requests.
+//
+// # Example
+//
+//	c := openai.NewClient("", openai.WithTokenProvider(openai.TokenProviderFunc(
+//		func(ctx context.Context) (string, error) {
+//			return vault.Secret(ctx, "openai-api-key")
+//		},
+//	)))
+func WithTokenProvider(p TokenProvider) ClientOption {
+	return func(client *Client) {
+		client.TokenProvider = p
+	}
+}
+
+// token returns the token to authenticate non-admin requests with, from the
+// client's TokenProvider if it has one, or its API key otherwise.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.TokenProvider != nil {
+		return c.TokenProvider.Token(ctx)
+	}
+	return c.APIKey, nil
+}
@@ -0,0 +1,55 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestWithTokenProvider(t *testing.T) {
+	var tokens []string
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	var calls int
+
+	c := openai.NewClient("",
+		openai.WithTokenProvider(openai.TokenProviderFunc(func(ctx context.Context) (string, error) {
+			calls++
+			if calls > 2 {
+				return "", errors.New("vault unavailable")
+			}
+			return fmt.Sprintf("token-%d", calls), nil
+		})),
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+	)
+
+	ctx := testCtx(t)
+
+	for range 2 {
+		_, err := c.ListModels(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(tokens) != 2 || tokens[0] != "Bearer token-1" || tokens[1] != "Bearer token-2" {
+		t.Fatalf("unexpected authorization headers: %q", tokens)
+	}
+
+	_, err := c.ListModels(ctx)
+	if err == nil {
+		t.Fatal("expected token provider error")
+	}
+
+	if len(tokens) != 2 {
+		t.Fatalf("expected request without a token not to be sent")
+	}
+}
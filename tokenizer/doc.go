@@ -0,0 +1,9 @@
+// Package tokenizer counts the tokens of text using the byte pair encodings
+// (BPE) of OpenAI models, compatible with tiktoken.
+//
+// The BPE rank tables aren't bundled with this package. Load them from the
+// tiktoken files published by OpenAI with Load to count tokens exactly;
+// otherwise token counts are estimated from the encoding's pre-tokenizer.
+//
+// https://github.com/openai/tiktoken
+package tokenizer
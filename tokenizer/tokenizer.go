@@ -0,0 +1,347 @@
+package tokenizer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Names of the supported encodings.
+const (
+	// CL100kBase is the encoding used by GPT-4, GPT-3.5 and the
+	// text-embedding-3 and ada-002 embedding models.
+	CL100kBase = "cl100k_base"
+
+	// O200kBase is the encoding used by GPT-4o, GPT-4.1, and the o-series
+	// reasoning models.
+	O200kBase = "o200k_base"
+)
+
+// ErrNotLoaded is returned by Encoding.Encode and Encoding.Decode when the
+// encoding's BPE ranks haven't been loaded with Load.
+var ErrNotLoaded = errors.New("encoding ranks not loaded")
+
+// ws and notWS stand in for \s and [^\s] in the tiktoken patterns, which match
+// Unicode whitespace rather than only ASCII whitespace like RE2's \s.
+const (
+	ws    = `\t\n\v\f\r\x{85}\p{Z}`
+	space = `[` + ws + `]`
+)
+
+// The pre-tokenizer patterns of each encoding, which split text into pieces
+// before BPE is applied. The whitespace alternative "\s+(?!\S)" of the
+// original patterns uses a lookahead that RE2 doesn't support, and is handled
+// by split instead.
+//
+// https://github.com/openai/tiktoken/blob/main/tiktoken_ext/openai_public.py
+var patterns = map[string]*regexp.Regexp{
+	CL100kBase: regexp.MustCompile(
+		`(?i:'s|'t|'re|'ve|'m|'ll|'d)` +
+			`|[^\r\n\p{L}\p{N}]?\p{L}+` +
+			`|\p{N}{1,3}` +
+			`| ?[^` + ws + `\p{L}\p{N}]+[\r\n]*` +
+			`|` + space + `*[\r\n]+` +
+			`|` + space + `+`,
+	),
+	O200kBase: regexp.MustCompile(
+		`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?` +
+			`|[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?` +
+			`|\p{N}{1,3}` +
+			`| ?[^` + ws + `\p{L}\p{N}]+[\r\n/]*` +
+			`|` + space + `*[\r\n]+` +
+			`|` + space + `+`,
+	),
+}
+
+// Encoding is a byte pair encoding used by OpenAI models.
+//
+// An Encoding whose ranks haven't been loaded can still estimate token counts
+// with Count, but can't Encode or Decode text.
+type Encoding struct {
+	name    string
+	pattern *regexp.Regexp
+
+	// ranks maps each token's bytes to its rank, which is also its ID.
+	ranks map[string]int
+
+	// tokens maps each token ID back to its bytes.
+	tokens map[int]string
+}
+
+// Name returns the name of the encoding, e.g. "cl100k_base".
+func (e *Encoding) Name() string {
+	return e.name
+}
+
+// Exact reports whether the encoding's ranks are loaded, and so whether Count
+// returns exact token counts rather than estimates.
+func (e *Encoding) Exact() bool {
+	return e.ranks != nil
+}
+
+// Encode returns the tokens of the given text. Special tokens, such as
+// "<|endoftext|>", are encoded as ordinary text.
+func (e *Encoding) Encode(text string) ([]int, error) {
+	if !e.Exact() {
+		return nil, fmt.Errorf("%s: %w", e.name, ErrNotLoaded)
+	}
+
+	var tokens []int
+	for _, piece := range e.split(text) {
+		tokens = e.encodePiece(piece, tokens)
+	}
+	return tokens, nil
+}
+
+// Decode returns the text of the given tokens.
+func (e *Encoding) Decode(tokens []int) (string, error) {
+	if !e.Exact() {
+		return "", fmt.Errorf("%s: %w", e.name, ErrNotLoaded)
+	}
+
+	var b strings.Builder
+	for _, token := range tokens {
+		s, ok := e.tokens[token]
+		if !ok {
+			return "", fmt.Errorf("%s: invalid token %d", e.name, token)
+		}
+		b.WriteString(s)
+	}
+	return b.String(), nil
+}
+
+// Count returns the number of tokens in the given text, or an estimate of it
+// if the encoding's ranks aren't loaded.
+func (e *Encoding) Count(text string) int {
+	var n int
+	for _, piece := range e.split(text) {
+		if e.Exact() {
+			n += len(e.encodePiece(piece, nil))
+		} else {
+			n += estimate(piece)
+		}
+	}
+	return n
+}
+
+// split splits the text into pieces with the encoding's pre-tokenizer.
+func (e *Encoding) split(text string) []string {
+	var pieces []string
+
+	for len(text) > 0 {
+		loc := e.pattern.FindStringIndex(text)
+		if loc == nil || loc[0] != 0 || loc[1] == 0 {
+			// Unreachable, since the patterns match any character, but make
+			// sure the text is consumed regardless.
+			_, size := utf8.DecodeRuneInString(text)
+			loc = []int{0, size}
+		}
+
+		end := loc[1]
+
+		// Emulate "\s+(?!\S)": a run of whitespace followed by more text
+		// leaves its last character to be the prefix of the next piece.
+		// Runs ending in a newline are matched by "\s*[\r\n]+" instead.
+		if piece := text[:end]; end < len(text) && isSpace(piece) && !strings.HasSuffix(piece, "\n") && !strings.HasSuffix(piece, "\r") {
+			if _, size := utf8.DecodeLastRuneInString(piece); size < len(piece) {
+				end -= size
+			}
+		}
+
+		pieces = append(pieces, text[:end])
+		text = text[end:]
+	}
+
+	return pieces
+}
+
+var spaceRun = regexp.MustCompile(`^` + space + `+$`)
+
+func isSpace(s string) bool {
+	return spaceRun.MatchString(s)
+}
+
+// encodePiece appends the tokens of a single pre-tokenized piece to tokens,
+// repeatedly merging the adjacent pair of parts with the lowest rank.
+func (e *Encoding) encodePiece(piece string, tokens []int) []int {
+	if rank, ok := e.ranks[piece]; ok {
+		return append(tokens, rank)
+	}
+
+	// parts holds the start offset of each part, followed by len(piece).
+	parts := make([]int, len(piece)+1)
+	for i := range parts {
+		parts[i] = i
+	}
+
+	for len(parts) > 2 {
+		best, at := math.MaxInt, -1
+		for i := 0; i+2 < len(parts); i++ {
+			if rank, ok := e.ranks[piece[parts[i]:parts[i+2]]]; ok && rank < best {
+				best, at = rank, i
+			}
+		}
+		if at < 0 {
+			break
+		}
+		parts = append(parts[:at+1], parts[at+2:]...)
+	}
+
+	// Every part has a rank, since Load requires a rank for each byte.
+	for i := 0; i+1 < len(parts); i++ {
+		tokens = append(tokens, e.ranks[piece[parts[i]:parts[i+1]]])
+	}
+	return tokens
+}
+
+// estimate approximates the number of tokens in a piece without ranks. Short
+// ASCII words and numbers are usually a single token, while other scripts use
+// roughly one token per character.
+func estimate(piece string) int {
+	if n := utf8.RuneCountInString(piece); n != len(piece) {
+		return n
+	}
+	return max(1, len(piece)/6)
+}
+
+var (
+	mu     sync.RWMutex
+	loaded = map[string]*Encoding{}
+)
+
+// Load reads the BPE ranks of the named encoding from a tiktoken file, and
+// registers the encoding so it is returned by Get and ForModel.
+//
+// The files are published by OpenAI, e.g. for cl100k_base:
+//
+//	https://openaipublic.blob.core.windows.net/encodings/cl100k_base.tiktoken
+//
+// # Example
+//
+//	f, _ := os.Open("o200k_base.tiktoken")
+//	defer f.Close()
+//
+//	enc, _ := tokenizer.Load(tokenizer.O200kBase, f)
+func Load(name string, r io.Reader) (*Encoding, error) {
+	pattern, ok := patterns[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding %q", name)
+	}
+
+	enc := &Encoding{
+		name:    name,
+		pattern: pattern,
+		ranks:   map[string]int{},
+		tokens:  map[int]string{},
+	}
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		fields := bytes.Fields(s.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s: line %d: expected a token and a rank", name, line)
+		}
+
+		token, err := base64.StdEncoding.DecodeString(string(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: line %d: invalid token: %w", name, line, err)
+		}
+
+		rank, err := strconv.Atoi(string(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: line %d: invalid rank: %w", name, line, err)
+		}
+
+		enc.ranks[string(token)] = rank
+		enc.tokens[rank] = string(token)
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	for b := 0; b < 256; b++ {
+		if _, ok := enc.ranks[string([]byte{byte(b)})]; !ok {
+			return nil, fmt.Errorf("%s: no rank for byte %#x", name, b)
+		}
+	}
+
+	mu.Lock()
+	loaded[name] = enc
+	mu.Unlock()
+
+	return enc, nil
+}
+
+// Get returns the named encoding. If its ranks haven't been loaded with Load,
+// the returned encoding only estimates token counts.
+func Get(name string) (*Encoding, error) {
+	mu.RLock()
+	enc, ok := loaded[name]
+	mu.RUnlock()
+
+	if ok {
+		return enc, nil
+	}
+
+	pattern, ok := patterns[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown encoding %q", name)
+	}
+
+	return &Encoding{name: name, pattern: pattern}, nil
+}
+
+// modelPrefixes maps model name prefixes to their encoding, checked in order.
+var modelPrefixes = []struct {
+	prefix, encoding string
+}{
+	{"gpt-4o", O200kBase},
+	{"chatgpt-4o", O200kBase},
+	{"gpt-4.1", O200kBase},
+	{"gpt-4.5", O200kBase},
+	{"gpt-5", O200kBase},
+	{"gpt-image", O200kBase},
+	{"o1", O200kBase},
+	{"o3", O200kBase},
+	{"o4", O200kBase},
+	{"gpt-4", CL100kBase},
+	{"gpt-3.5", CL100kBase},
+	{"gpt-35", CL100kBase},
+	{"text-embedding-3", CL100kBase},
+	{"text-embedding-ada-002", CL100kBase},
+}
+
+// EncodingForModel returns the name of the encoding used by the given model,
+// including fine-tuned models such as "ft:gpt-4o-mini:org::id".
+func EncodingForModel(model string) (string, error) {
+	base := strings.TrimPrefix(model, "ft:")
+
+	for _, p := range modelPrefixes {
+		if strings.HasPrefix(base, p.prefix) {
+			return p.encoding, nil
+		}
+	}
+
+	return "", fmt.Errorf("no known encoding for model %q", model)
+}
+
+// ForModel returns the encoding used by the given model, as returned by Get.
+func ForModel(model string) (*Encoding, error) {
+	name, err := EncodingForModel(model)
+	if err != nil {
+		return nil, err
+	}
+	return Get(name)
+}
@@ -0,0 +1,163 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		encoding string
+		text     string
+		want     []string
+	}{
+		{CL100kBase, "Hello world", []string{"Hello", " world"}},
+		{CL100kBase, "I'm here", []string{"I", "'m", " here"}},
+		{CL100kBase, "12345", []string{"123", "45"}},
+		{CL100kBase, "a  b", []string{"a", " ", " b"}},
+		{CL100kBase, "a\n\nb", []string{"a", "\n\n", "b"}},
+		{CL100kBase, "hello!!\nworld", []string{"hello", "!!\n", "world"}},
+		{CL100kBase, "trailing  ", []string{"trailing", "  "}},
+		{CL100kBase, "x   42", []string{"x", "  ", " ", "42"}},
+		{O200kBase, "HelloWorld", []string{"Hello", "World"}},
+		{O200kBase, "path/to\nfile", []string{"path", "/to", "\n", "file"}},
+		{O200kBase, "don't", []string{"don't"}},
+	}
+
+	for _, tt := range tests {
+		enc, err := Get(tt.encoding)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := enc.split(tt.text)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: split(%q) = %q, want %q", tt.encoding, tt.text, got, tt.want)
+		}
+
+		if strings.Join(got, "") != tt.text {
+			t.Errorf("%s: split(%q) lost text: %q", tt.encoding, tt.text, got)
+		}
+	}
+}
+
+// testRanks returns a tiktoken file with a rank for every byte, followed by
+// the given merged tokens.
+func testRanks(merges ...string) string {
+	var b strings.Builder
+	for i := 0; i < 256; i++ {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), i)
+	}
+	for i, m := range merges {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(m)), 256+i)
+	}
+	return b.String()
+}
+
+func TestLoad(t *testing.T) {
+	t.Cleanup(func() {
+		mu.Lock()
+		delete(loaded, CL100kBase)
+		mu.Unlock()
+	})
+
+	enc, err := Load(CL100kBase, strings.NewReader(testRanks("he", "ll", "hell", "hello", " w", "or", " wor", "ld", " world")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !enc.Exact() {
+		t.Fatal("expected loaded encoding to be exact")
+	}
+
+	tokens, err := enc.Encode("hello world!")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{259, 264, '!'}; !reflect.DeepEqual(tokens, want) {
+		t.Fatalf("unexpected tokens: %v, want %v", tokens, want)
+	}
+
+	text, err := enc.Decode(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text != "hello world!" {
+		t.Fatalf("unexpected decoded text: %q", text)
+	}
+
+	if n := enc.Count("hello world!"); n != 3 {
+		t.Fatalf("expected 3 tokens, got %d", n)
+	}
+
+	// Pieces without merges fall back to byte tokens.
+	if n := enc.Count("xyz"); n != 3 {
+		t.Fatalf("expected 3 tokens, got %d", n)
+	}
+
+	got, err := ForModel("gpt-4-turbo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != enc {
+		t.Fatal("expected ForModel to return the loaded encoding")
+	}
+}
+
+func TestLoad_MissingByte(t *testing.T) {
+	_, err := Load(O200kBase, strings.NewReader(base64.StdEncoding.EncodeToString([]byte("a"))+" 0\n"))
+	if err == nil {
+		t.Fatal("expected error for missing byte ranks")
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	enc, err := Get(O200kBase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if enc.Exact() {
+		t.Fatal("expected unloaded encoding to estimate")
+	}
+
+	if _, err := enc.Encode("hello"); !errors.Is(err, ErrNotLoaded) {
+		t.Fatalf("expected ErrNotLoaded, got %v", err)
+	}
+
+	if n := enc.Count("The quick brown fox jumps over the lazy dog."); n != 10 {
+		t.Fatalf("expected an estimate of 10 tokens, got %d", n)
+	}
+}
+
+func TestEncodingForModel(t *testing.T) {
+	tests := map[string]string{
+		"gpt-4o-mini":                    O200kBase,
+		"ft:gpt-4o-mini:acemeco::abc123": O200kBase,
+		"o3-mini":                        O200kBase,
+		"gpt-4":                          CL100kBase,
+		"gpt-3.5-turbo":                  CL100kBase,
+		"text-embedding-3-small":         CL100kBase,
+	}
+
+	for model, want := range tests {
+		got, err := EncodingForModel(model)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("EncodingForModel(%q) = %q, want %q", model, got, want)
+		}
+	}
+
+	if _, err := EncodingForModel("davinci"); err == nil {
+		t.Fatal("expected error for model without a known encoding")
+	}
+}
@@ -0,0 +1,119 @@
+package openai
+
+import (
+	"encoding/json"
+
+	"github.com/picatz/openai/tokenizer"
+)
+
+// Token overheads of chat messages, as described in the OpenAI cookbook.
+//
+// https://cookbook.openai.com/examples/how_to_count_tokens_with_tiktoken
+const (
+	// tokensPerMessage wraps every message, e.g. "<|start|>role<|message|>".
+	tokensPerMessage = 3
+
+	// tokensPerName is added for messages with a Name.
+	tokensPerName = 1
+
+	// tokensPerReply primes the assistant's reply.
+	tokensPerReply = 3
+
+	// tokensPerImage is the cost of a low detail image. Higher detail images
+	// cost more depending on their size, which isn't known here.
+	tokensPerImage = 85
+)
+
+// CountTokens returns the number of tokens in the given text for the given model.
+//
+// Counts are exact once the model's encoding is loaded with tokenizer.Load,
+// and estimated otherwise. An error is returned for models without a known
+// encoding.
+//
+// # Example
+//
+//	n, _ := openai.CountTokens(openai.ModelGPT4, "Hello, world!")
+func CountTokens(model, text string) (int, error) {
+	enc, err := tokenizer.ForModel(model)
+	if err != nil {
+		return 0, err
+	}
+	return enc.Count(text), nil
+}
+
+// CountChatTokens returns the number of prompt tokens used by the given chat
+// messages for the given model, including the tokens that format each message
+// and prime the reply. Like CountTokens, the count is an estimate unless the
+// model's encoding is loaded.
+//
+// Images are counted as low detail images, and tool definitions sent with the
+// request aren't counted.
+//
+// # Example
+//
+//	n, _ := openai.CountChatTokens(openai.ModelGPT4, []openai.ChatMessage{
+//		openai.System("You are a helpful assistant."),
+//		openai.User("Hello!"),
+//	})
+func CountChatTokens(model string, messages []ChatMessage) (int, error) {
+	enc, err := tokenizer.ForModel(model)
+	if err != nil {
+		return 0, err
+	}
+
+	n := tokensPerReply
+
+	for _, m := range messages {
+		n += tokensPerMessage + enc.Count(m.Role)
+
+		if len(m.ContentParts) > 0 {
+			for _, part := range m.ContentParts {
+				switch part.Type {
+				case ChatContentPartText:
+					n += enc.Count(part.Text)
+				case ChatContentPartImageURL:
+					n += tokensPerImage
+				}
+			}
+		} else {
+			n += enc.Count(m.Content)
+		}
+
+		if m.Name != "" {
+			n += tokensPerName + enc.Count(m.Name)
+		}
+
+		if m.ToolCallID != "" {
+			n += enc.Count(m.ToolCallID)
+		}
+
+		for _, call := range m.ToolCalls {
+			if call.Function != nil {
+				c, err := countFunctionCall(enc, call.Function)
+				if err != nil {
+					return 0, err
+				}
+				n += c
+			}
+		}
+
+		if m.FunctionCall != nil {
+			c, err := countFunctionCall(enc, m.FunctionCall)
+			if err != nil {
+				return 0, err
+			}
+			n += c
+		}
+	}
+
+	return n, nil
+}
+
+// countFunctionCall counts the tokens of a function call's name and JSON arguments.
+func countFunctionCall(enc *tokenizer.Encoding, fn *FunctionCall) (int, error) {
+	args, err := json.Marshal(fn.Arguments)
+	if err != nil {
+		return 0, err
+	}
+	return enc.Count(fn.Name) + enc.Count(string(args)), nil
+}
@@ -0,0 +1,35 @@
+package openai_test
+
+import (
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestCountChatTokens(t *testing.T) {
+	text, err := openai.CountTokens(openai.ModelGPT4, "Hello!")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text != 2 {
+		t.Fatalf("expected 2 tokens, got %d", text)
+	}
+
+	n, err := openai.CountChatTokens(openai.ModelGPT4, []openai.ChatMessage{
+		openai.User("Hello!"),
+		openai.UserParts(openai.ImagePart("https://example.com/cat.jpg", openai.ImageDetailLow)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reply priming, two message wrappers and roles, the text, and the image.
+	if want := 3 + 2*(3+1) + text + 85; n != want {
+		t.Fatalf("expected %d tokens, got %d", want, n)
+	}
+
+	if _, err := openai.CountTokens("davinci", "Hello!"); err == nil {
+		t.Fatal("expected error for model without a known encoding")
+	}
+}
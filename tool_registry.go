@@ -0,0 +1,174 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// ToolHandler handles a call to a registered tool, decoding its JSON arguments
+// and returning its result.
+type ToolHandler func(ctx context.Context, args json.RawMessage) (any, error)
+
+// registeredTool is a tool in a ToolRegistry.
+type registeredTool struct {
+	fn      *Function
+	handler ToolHandler
+}
+
+// ToolRegistry is a set of function tools the model can call, each with a Go
+// function that handles its calls. Register tools with RegisterTool.
+//
+// A ToolRegistry is safe for concurrent use.
+//
+// # Example
+//
+//	type WeatherArgs struct {
+//		Location string `json:"location" description:"The city, e.g. Paris"`
+//	}
+//
+//	tools := openai.NewToolRegistry()
+//
+//	err := openai.RegisterTool(tools, "get_weather", "Get the current weather.",
+//		func(ctx context.Context, args WeatherArgs) (string, error) {
+//			return "Sunny, 20C in " + args.Location, nil
+//		},
+//	)
+//
+//	resp, _ := client.CreateChat(ctx, &openai.CreateChatRequest{
+//		Model:    openai.ModelGPT4,
+//		Messages: messages,
+//		Tools:    tools.Tools(),
+//	})
+//
+//	msg, _ := resp.FirstChoice()
+//
+//	for _, call := range msg.ToolCalls {
+//		result, _ := tools.Call(ctx, call)
+//		messages = append(messages, result)
+//	}
+type ToolRegistry struct {
+	mu    sync.RWMutex
+	tools map[string]*registeredTool
+
+	// order is the names of the tools in the order they were registered.
+	order []string
+}
+
+// NewToolRegistry returns an empty ToolRegistry.
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{tools: map[string]*registeredTool{}}
+}
+
+// RegisterTool registers a function tool with the given name and description,
+// whose calls are handled by fn. The tool's parameters are described by the
+// JSON schema of Args, as derived by JSONSchemaFor, and the arguments of each
+// call are decoded into an Args.
+//
+// The Result of fn is sent back to the model as-is if it is a string, and
+// encoded as JSON otherwise.
+func RegisterTool[Args, Result any](r *ToolRegistry, name, description string, fn func(ctx context.Context, args Args) (Result, error)) error {
+	params, err := JSONSchemaFor[Args]()
+	if err != nil {
+		return fmt.Errorf("tool %q: %w", name, err)
+	}
+
+	if params.Type != "object" || params.Properties == nil {
+		return fmt.Errorf("tool %q: arguments must be a struct, got %T", name, *new(Args))
+	}
+
+	return r.Register(&Function{Name: name, Description: description, Parameters: params}, func(ctx context.Context, raw json.RawMessage) (any, error) {
+		var args Args
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, fmt.Errorf("invalid arguments for tool %q: %w", name, err)
+		}
+		return fn(ctx, args)
+	})
+}
+
+// Register registers a function tool with a hand-written definition, whose
+// calls are handled by handler. Most tools should use RegisterTool instead.
+func (r *ToolRegistry) Register(fn *Function, handler ToolHandler) error {
+	if fn == nil || fn.Name == "" {
+		return fmt.Errorf("tool function must have a name")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tools[fn.Name]; ok {
+		return fmt.Errorf("duplicate tool %q", fn.Name)
+	}
+
+	r.tools[fn.Name] = &registeredTool{fn: fn, handler: handler}
+	r.order = append(r.order, fn.Name)
+	return nil
+}
+
+// Tools returns the registered tools, in the order they were registered, to
+// send with a chat request.
+func (r *ToolRegistry) Tools() []Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tools := make([]Tool, 0, len(r.order))
+	for _, name := range r.order {
+		tools = append(tools, FunctionTool(r.tools[name].fn))
+	}
+	return tools
+}
+
+// Call handles the given tool call with its registered function, and returns
+// a tool message with the result, to send back to the model.
+//
+// An error is returned if the tool isn't registered, its arguments can't be
+// decoded, or its function fails.
+func (r *ToolRegistry) Call(ctx context.Context, call ToolCall) (ChatMessage, error) {
+	if call.Function == nil {
+		return ChatMessage{}, fmt.Errorf("tool call %q has no function", call.ID)
+	}
+
+	r.mu.RLock()
+	tool, ok := r.tools[call.Function.Name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return ChatMessage{}, fmt.Errorf("unknown tool %q", call.Function.Name)
+	}
+
+	args, err := json.Marshal(call.Function.Arguments)
+	if err != nil {
+		return ChatMessage{}, err
+	}
+
+	// Calls without arguments are decoded as an empty object.
+	if call.Function.Arguments == nil {
+		args = []byte("{}")
+	}
+
+	result, err := tool.handler(ctx, args)
+	if err != nil {
+		return ChatMessage{}, err
+	}
+
+	content, err := toolResultContent(result)
+	if err != nil {
+		return ChatMessage{}, fmt.Errorf("invalid result of tool %q: %w", call.Function.Name, err)
+	}
+
+	return ToolResult(call.ID, content), nil
+}
+
+// toolResultContent returns the content of a tool message for the given result.
+func toolResultContent(result any) (string, error) {
+	if s, ok := result.(string); ok {
+		return s, nil
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
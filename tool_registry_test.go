@@ -0,0 +1,148 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+type weatherArgs struct {
+	Location string   `json:"location" description:"The city, e.g. Paris"`
+	Unit     string   `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+	Days     *int     `json:"days,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	internal string
+}
+
+func TestJSONSchemaFor(t *testing.T) {
+	type embedded struct {
+		Verbose bool `json:"verbose"`
+	}
+
+	type args struct {
+		weatherArgs
+		embedded
+		Ignored string             `json:"-"`
+		Scores  map[string]float64 `json:"scores"`
+	}
+
+	schema, err := openai.JSONSchemaFor[args]()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got, want any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"location": {"type": "string", "description": "The city, e.g. Paris"},
+			"unit": {"type": "string", "enum": ["celsius", "fahrenheit"]},
+			"days": {"type": "integer"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"verbose": {"type": "boolean"},
+			"scores": {"type": "object", "additionalProperties": {"type": "number"}}
+		},
+		"required": ["location", "verbose", "scores"]
+	}`), &want); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected schema: %s", b)
+	}
+
+	type node struct {
+		Children []node `json:"children"`
+	}
+
+	if _, err := openai.JSONSchemaFor[node](); err == nil {
+		t.Fatal("expected error for recursive type")
+	}
+
+	if _, err := openai.JSONSchemaFor[chan int](); err == nil {
+		t.Fatal("expected error for unsupported type")
+	}
+}
+
+func TestToolRegistry(t *testing.T) {
+	tools := openai.NewToolRegistry()
+
+	err := openai.RegisterTool(tools, "get_weather", "Get the current weather.", func(ctx context.Context, args weatherArgs) (string, error) {
+		if args.Location == "Atlantis" {
+			return "", errors.New("unknown location")
+		}
+		return "Sunny in " + args.Location, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = openai.RegisterTool(tools, "get_time", "Get the current time.", func(ctx context.Context, args struct{}) (map[string]int, error) {
+		return map[string]int{"hour": 12}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := openai.RegisterTool(tools, "get_time", "", func(ctx context.Context, args struct{}) (string, error) { return "", nil }); err == nil {
+		t.Fatal("expected error for duplicate tool")
+	}
+
+	if err := openai.RegisterTool(tools, "bad", "", func(ctx context.Context, args string) (string, error) { return "", nil }); err == nil {
+		t.Fatal("expected error for non-struct arguments")
+	}
+
+	defs := tools.Tools()
+	if len(defs) != 2 || defs[0].Function.Name != "get_weather" || defs[1].Function.Name != "get_time" {
+		t.Fatalf("unexpected tools: %#+v", defs)
+	}
+
+	if required := defs[0].Function.Parameters.Required; len(required) != 1 || required[0] != "location" {
+		t.Fatalf("unexpected required parameters: %v", required)
+	}
+
+	ctx := testCtx(t)
+
+	msg, err := tools.Call(ctx, openai.ToolCall{
+		ID:       "call_1",
+		Type:     openai.ToolTypeFunction,
+		Function: &openai.FunctionCall{Name: "get_weather", Arguments: openai.FunctionCallArguments{"location": "Paris"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Role != openai.ChatRoleTool || msg.ToolCallID != "call_1" || msg.Content != "Sunny in Paris" {
+		t.Fatalf("unexpected tool result: %#+v", msg)
+	}
+
+	msg, err = tools.Call(ctx, openai.ToolCall{ID: "call_2", Function: &openai.FunctionCall{Name: "get_time"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Content != `{"hour":12}` {
+		t.Fatalf("unexpected tool result: %q", msg.Content)
+	}
+
+	if _, err := tools.Call(ctx, openai.ToolCall{ID: "call_3", Function: &openai.FunctionCall{Name: "get_weather", Arguments: openai.FunctionCallArguments{"location": "Atlantis"}}}); err == nil {
+		t.Fatal("expected tool error")
+	}
+
+	if _, err := tools.Call(ctx, openai.ToolCall{ID: "call_4", Function: &openai.FunctionCall{Name: "missing"}}); err == nil {
+		t.Fatal("expected error for unknown tool")
+	}
+}
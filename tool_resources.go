@@ -0,0 +1,36 @@
+package openai
+
+// ToolResources are the resources made available to the code_interpreter and
+// file_search tools of an assistant or thread, replacing the file_ids of
+// assistants v1.
+//
+// https://platform.openai.com/docs/api-reference/assistants/object#assistants/object-tool_resources
+type ToolResources struct {
+	CodeInterpreter *CodeInterpreterResources `json:"code_interpreter,omitempty"`
+	FileSearch      *FileSearchResources      `json:"file_search,omitempty"`
+}
+
+// CodeInterpreterResources are the files available to the code_interpreter tool.
+type CodeInterpreterResources struct {
+	// FileIDs are the IDs of up to 20 files.
+	FileIDs []string `json:"file_ids,omitempty"`
+}
+
+// FileSearchResources are the vector stores searched by the file_search tool.
+type FileSearchResources struct {
+	// VectorStoreIDs are the IDs of the vector stores, currently at most one.
+	VectorStoreIDs []string `json:"vector_store_ids,omitempty"`
+}
+
+// MessageAttachment is a file attached to a message, and the tools it should
+// be added to, replacing the file_ids of assistants v1 messages.
+//
+// https://platform.openai.com/docs/api-reference/messages/createMessage#messages-createmessage-attachments
+type MessageAttachment struct {
+	// FileID is the ID of the attached file.
+	FileID string `json:"file_id"`
+
+	// Tools are the tools to add the file to, such as
+	// {"type": "code_interpreter"} or {"type": "file_search"}.
+	Tools []map[string]any `json:"tools,omitempty"`
+}
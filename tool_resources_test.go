@@ -0,0 +1,74 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestToolResources(t *testing.T) {
+	bodies := map[string]string{}
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		bodies[r.URL.Path] = string(body)
+
+		switch r.URL.Path {
+		case "/v1/assistants":
+			fmt.Fprint(w, `{"id":"asst_abc","tool_resources":{"code_interpreter":{"file_ids":["file-a"]}}}`)
+		case "/v1/threads/thread_abc/messages":
+			fmt.Fprint(w, `{"id":"msg_abc","attachments":[{"file_id":"file-b","tools":[{"type":"file_search"}]}]}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	assistant, err := c.CreateAssistant(ctx, &openai.CreateAssistantRequest{
+		Model: openai.ModelGPT4,
+		Tools: []map[string]any{{"type": "code_interpreter"}},
+		ToolResources: &openai.ToolResources{
+			CodeInterpreter: &openai.CodeInterpreterResources{FileIDs: []string{"file-a"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"model":"gpt-4","tools":[{"type":"code_interpreter"}],"tool_resources":{"code_interpreter":{"file_ids":["file-a"]}}}`
+	if got := bodies["/v1/assistants"]; got != want {
+		t.Fatalf("expected body %s, got %s", want, got)
+	}
+
+	if assistant.ToolResources.CodeInterpreter.FileIDs[0] != "file-a" {
+		t.Fatalf("unexpected tool resources: %+v", assistant.ToolResources)
+	}
+
+	msg, err := c.CreateMessage(ctx, &openai.CreateMessageRequest{
+		ThreadID: "thread_abc",
+		Role:     openai.ChatRoleUser,
+		Content:  "Summarize this file.",
+		Attachments: []openai.MessageAttachment{
+			{FileID: "file-b", Tools: []map[string]any{{"type": "file_search"}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want = `{"role":"user","content":"Summarize this file.","attachments":[{"file_id":"file-b","tools":[{"type":"file_search"}]}]}`
+	if got := bodies["/v1/threads/thread_abc/messages"]; got != want {
+		t.Fatalf("expected body %s, got %s", want, got)
+	}
+
+	if len(msg.Attachments) != 1 || msg.Attachments[0].FileID != "file-b" {
+		t.Fatalf("unexpected attachments: %+v", msg.Attachments)
+	}
+}
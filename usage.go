@@ -0,0 +1,384 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// UsageBucketWidth is the width of each time bucket returned by the Usage and
+// Costs APIs.
+//
+// https://platform.openai.com/docs/api-reference/usage/completions#usage-completions-bucket_width
+type UsageBucketWidth string
+
+const (
+	UsageBucketWidthMinute UsageBucketWidth = "1m"
+	UsageBucketWidthHour   UsageBucketWidth = "1h"
+	UsageBucketWidthDay    UsageBucketWidth = "1d"
+)
+
+// UsageGroupBy is a field that usage results can be grouped by. Not every
+// field is supported by every usage endpoint.
+//
+// https://platform.openai.com/docs/api-reference/usage/completions#usage-completions-group_by
+type UsageGroupBy string
+
+const (
+	UsageGroupByProjectID UsageGroupBy = "project_id"
+	UsageGroupByUserID    UsageGroupBy = "user_id"
+	UsageGroupByAPIKeyID  UsageGroupBy = "api_key_id"
+	UsageGroupByModel     UsageGroupBy = "model"
+	UsageGroupByBatch     UsageGroupBy = "batch"
+	UsageGroupBySource    UsageGroupBy = "source"
+	UsageGroupBySize      UsageGroupBy = "size"
+)
+
+// UsageRequest is the set of filters shared by the organization Usage API
+// endpoints. Fields that an endpoint does not support are ignored by it.
+//
+// https://platform.openai.com/docs/api-reference/usage
+type UsageRequest struct {
+	// Start time (Unix seconds) of the query time range, inclusive.
+	//
+	// Required.
+	StartTime int `json:"-"`
+
+	// End time (Unix seconds) of the query time range, exclusive.
+	//
+	// Optional.
+	EndTime int `json:"-"`
+
+	// Optional. Defaults to "1d".
+	BucketWidth UsageBucketWidth `json:"-"`
+
+	// Optional.
+	ProjectIDs []string `json:"-"`
+
+	// Optional.
+	UserIDs []string `json:"-"`
+
+	// Optional.
+	APIKeyIDs []string `json:"-"`
+
+	// Optional.
+	Models []string `json:"-"`
+
+	// Only return batch (true) or non-batch (false) completions usage.
+	//
+	// Optional. Completions only.
+	Batch *bool `json:"-"`
+
+	// Optional. Images only.
+	Sources []string `json:"-"`
+
+	// Optional. Images only.
+	Sizes []string `json:"-"`
+
+	// Optional.
+	GroupBy []UsageGroupBy `json:"-"`
+
+	// Number of buckets to return.
+	//
+	// Optional.
+	Limit int `json:"-"`
+
+	// Cursor for pagination, taken from NextPage of the previous response.
+	//
+	// Optional.
+	Page string `json:"-"`
+}
+
+func (r *UsageRequest) query() string {
+	q := url.Values{}
+
+	q.Set("start_time", strconv.Itoa(r.StartTime))
+
+	if r.EndTime != 0 {
+		q.Set("end_time", strconv.Itoa(r.EndTime))
+	}
+
+	if r.BucketWidth != "" {
+		q.Set("bucket_width", string(r.BucketWidth))
+	}
+
+	for key, values := range map[string][]string{
+		"project_ids[]": r.ProjectIDs,
+		"user_ids[]":    r.UserIDs,
+		"api_key_ids[]": r.APIKeyIDs,
+		"models[]":      r.Models,
+		"sources[]":     r.Sources,
+		"sizes[]":       r.Sizes,
+	} {
+		for _, v := range values {
+			q.Add(key, v)
+		}
+	}
+
+	for _, g := range r.GroupBy {
+		q.Add("group_by[]", string(g))
+	}
+
+	if r.Batch != nil {
+		q.Set("batch", strconv.FormatBool(*r.Batch))
+	}
+
+	if r.Limit != 0 {
+		q.Set("limit", strconv.Itoa(r.Limit))
+	}
+
+	if r.Page != "" {
+		q.Set("page", r.Page)
+	}
+
+	return "?" + q.Encode()
+}
+
+// UsageResult is a single usage result within a bucket. Which fields are set
+// depends on the endpoint that returned it and the requested grouping; the
+// grouping fields (ProjectID, Model, etc.) are empty unless grouped by.
+//
+// https://platform.openai.com/docs/api-reference/usage/completions_object
+type UsageResult struct {
+	Object string `json:"object"`
+
+	// Completions and embeddings.
+	InputTokens       int `json:"input_tokens,omitempty"`
+	OutputTokens      int `json:"output_tokens,omitempty"`
+	InputCachedTokens int `json:"input_cached_tokens,omitempty"`
+	InputAudioTokens  int `json:"input_audio_tokens,omitempty"`
+	OutputAudioTokens int `json:"output_audio_tokens,omitempty"`
+
+	// Images.
+	Images int    `json:"images,omitempty"`
+	Source string `json:"source,omitempty"`
+	Size   string `json:"size,omitempty"`
+
+	// Audio speeches.
+	Characters int `json:"characters,omitempty"`
+
+	// Audio transcriptions.
+	Seconds int `json:"seconds,omitempty"`
+
+	// Vector stores.
+	UsageBytes int `json:"usage_bytes,omitempty"`
+
+	NumModelRequests int    `json:"num_model_requests,omitempty"`
+	ProjectID        string `json:"project_id,omitempty"`
+	UserID           string `json:"user_id,omitempty"`
+	APIKeyID         string `json:"api_key_id,omitempty"`
+	Model            string `json:"model,omitempty"`
+	Batch            *bool  `json:"batch,omitempty"`
+}
+
+// UsageBucket is the usage within a single time bucket.
+//
+// https://platform.openai.com/docs/api-reference/usage/completions_object
+type UsageBucket struct {
+	Object    string        `json:"object"`
+	StartTime int           `json:"start_time"`
+	EndTime   int           `json:"end_time"`
+	Results   []UsageResult `json:"results"`
+}
+
+// https://platform.openai.com/docs/api-reference/usage
+type UsageResponse struct {
+	Object   string        `json:"object"`
+	Data     []UsageBucket `json:"data"`
+	HasMore  bool          `json:"has_more"`
+	NextPage string        `json:"next_page"`
+}
+
+func (c *Client) usage(ctx context.Context, kind string, req *UsageRequest) (*UsageResponse, error) {
+	var res UsageResponse
+	err := c.do(ctx, http.MethodGet, "/organization/usage/"+kind+req.query(), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// GetCompletionsUsage returns the organization's completions usage.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/usage/completions
+func (c *Client) GetCompletionsUsage(ctx context.Context, req *UsageRequest) (*UsageResponse, error) {
+	return c.usage(ctx, "completions", req)
+}
+
+// GetEmbeddingsUsage returns the organization's embeddings usage.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/usage/embeddings
+func (c *Client) GetEmbeddingsUsage(ctx context.Context, req *UsageRequest) (*UsageResponse, error) {
+	return c.usage(ctx, "embeddings", req)
+}
+
+// GetModerationsUsage returns the organization's moderations usage.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/usage/moderations
+func (c *Client) GetModerationsUsage(ctx context.Context, req *UsageRequest) (*UsageResponse, error) {
+	return c.usage(ctx, "moderations", req)
+}
+
+// GetImagesUsage returns the organization's images usage.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/usage/images
+func (c *Client) GetImagesUsage(ctx context.Context, req *UsageRequest) (*UsageResponse, error) {
+	return c.usage(ctx, "images", req)
+}
+
+// GetAudioSpeechesUsage returns the organization's text-to-speech usage.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/usage/audio_speeches
+func (c *Client) GetAudioSpeechesUsage(ctx context.Context, req *UsageRequest) (*UsageResponse, error) {
+	return c.usage(ctx, "audio_speeches", req)
+}
+
+// GetAudioTranscriptionsUsage returns the organization's transcription usage.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/usage/audio_transcriptions
+func (c *Client) GetAudioTranscriptionsUsage(ctx context.Context, req *UsageRequest) (*UsageResponse, error) {
+	return c.usage(ctx, "audio_transcriptions", req)
+}
+
+// GetVectorStoresUsage returns the organization's vector store storage usage.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/usage/vector_stores
+func (c *Client) GetVectorStoresUsage(ctx context.Context, req *UsageRequest) (*UsageResponse, error) {
+	return c.usage(ctx, "vector_stores", req)
+}
+
+// CostsGroupBy is a field that cost results can be grouped by.
+//
+// https://platform.openai.com/docs/api-reference/usage/costs#usage-costs-group_by
+type CostsGroupBy string
+
+const (
+	CostsGroupByProjectID CostsGroupBy = "project_id"
+	CostsGroupByLineItem  CostsGroupBy = "line_item"
+)
+
+// https://platform.openai.com/docs/api-reference/usage/costs
+type CostsRequest struct {
+	// Start time (Unix seconds) of the query time range, inclusive.
+	//
+	// Required.
+	StartTime int `json:"-"`
+
+	// End time (Unix seconds) of the query time range, exclusive.
+	//
+	// Optional.
+	EndTime int `json:"-"`
+
+	// Only "1d" is currently supported.
+	//
+	// Optional. Defaults to "1d".
+	BucketWidth UsageBucketWidth `json:"-"`
+
+	// Optional.
+	ProjectIDs []string `json:"-"`
+
+	// Optional.
+	GroupBy []CostsGroupBy `json:"-"`
+
+	// Number of buckets to return.
+	//
+	// Optional. Defaults to 7.
+	Limit int `json:"-"`
+
+	// Cursor for pagination, taken from NextPage of the previous response.
+	//
+	// Optional.
+	Page string `json:"-"`
+}
+
+// CostsAmount is a monetary amount.
+type CostsAmount struct {
+	Value    float64 `json:"value"`
+	Currency string  `json:"currency"`
+}
+
+// CostsResult is a single cost result within a bucket. LineItem and ProjectID
+// are only set when grouped by.
+//
+// https://platform.openai.com/docs/api-reference/usage/costs_object
+type CostsResult struct {
+	Object    string      `json:"object"`
+	Amount    CostsAmount `json:"amount"`
+	LineItem  string      `json:"line_item,omitempty"`
+	ProjectID string      `json:"project_id,omitempty"`
+}
+
+// CostsBucket is the spend within a single time bucket.
+//
+// https://platform.openai.com/docs/api-reference/usage/costs_object
+type CostsBucket struct {
+	Object    string        `json:"object"`
+	StartTime int           `json:"start_time"`
+	EndTime   int           `json:"end_time"`
+	Results   []CostsResult `json:"results"`
+}
+
+// https://platform.openai.com/docs/api-reference/usage/costs
+type CostsResponse struct {
+	Object   string        `json:"object"`
+	Data     []CostsBucket `json:"data"`
+	HasMore  bool          `json:"has_more"`
+	NextPage string        `json:"next_page"`
+}
+
+// GetCosts returns the organization's spend, bucketed by day.
+//
+// Requires an admin API key.
+//
+// https://platform.openai.com/docs/api-reference/usage/costs
+func (c *Client) GetCosts(ctx context.Context, req *CostsRequest) (*CostsResponse, error) {
+	q := url.Values{}
+
+	q.Set("start_time", strconv.Itoa(req.StartTime))
+
+	if req.EndTime != 0 {
+		q.Set("end_time", strconv.Itoa(req.EndTime))
+	}
+
+	if req.BucketWidth != "" {
+		q.Set("bucket_width", string(req.BucketWidth))
+	}
+
+	for _, id := range req.ProjectIDs {
+		q.Add("project_ids[]", id)
+	}
+
+	for _, g := range req.GroupBy {
+		q.Add("group_by[]", string(g))
+	}
+
+	if req.Limit != 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if req.Page != "" {
+		q.Set("page", req.Page)
+	}
+
+	var res CostsResponse
+	err := c.do(ctx, http.MethodGet, "/organization/costs?"+q.Encode(), nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
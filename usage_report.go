@@ -0,0 +1,342 @@
+package openai
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UsageReportDimension is a dimension that a UsageReport can be summarized by.
+type UsageReportDimension string
+
+const (
+	UsageReportByDay     UsageReportDimension = "day"
+	UsageReportByProject UsageReportDimension = "project"
+	UsageReportByModel   UsageReportDimension = "model"
+)
+
+// UsageReportRow is the total usage and spend for a single combination of the
+// report's dimensions. Dimensions the report isn't summarized by are empty.
+type UsageReportRow struct {
+	Day       string `json:"day,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+	Model     string `json:"model,omitempty"`
+
+	InputTokens       int `json:"input_tokens"`
+	OutputTokens      int `json:"output_tokens"`
+	InputCachedTokens int `json:"input_cached_tokens"`
+	NumModelRequests  int `json:"num_model_requests"`
+
+	// Cost is the spend in Currency.
+	Cost     float64 `json:"cost"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+func (r *UsageReportRow) add(o *UsageReportRow) {
+	r.InputTokens += o.InputTokens
+	r.OutputTokens += o.OutputTokens
+	r.InputCachedTokens += o.InputCachedTokens
+	r.NumModelRequests += o.NumModelRequests
+	r.Cost += o.Cost
+	if r.Currency == "" {
+		r.Currency = o.Currency
+	}
+}
+
+// UsageReport summarizes Usage and Costs API buckets by day, project, and/or model.
+type UsageReport struct {
+	By   []UsageReportDimension `json:"by"`
+	Rows []UsageReportRow       `json:"rows"`
+}
+
+// NewUsageReport merges the given usage and cost buckets into a report with one
+// row per combination of the given dimensions, sorted by day, project and model.
+// If no dimensions are given, the report is summarized by all of them.
+//
+// Costs are attributed to models by the model name prefix of their line item
+// (e.g. "gpt-4o-2024-08-06, input"), so costs should be grouped by line item
+// when summarizing by model, and by project when summarizing by project. Likewise,
+// usage must be grouped by the matching fields to be attributed.
+func NewUsageReport(usage []UsageBucket, costs []CostsBucket, by ...UsageReportDimension) *UsageReport {
+	if len(by) == 0 {
+		by = []UsageReportDimension{UsageReportByDay, UsageReportByProject, UsageReportByModel}
+	}
+
+	var (
+		rows  = map[UsageReportRow]*UsageReportRow{}
+		order []*UsageReportRow
+	)
+
+	add := func(startTime int, row UsageReportRow) {
+		key := UsageReportRow{}
+		for _, d := range by {
+			switch d {
+			case UsageReportByDay:
+				key.Day = time.Unix(int64(startTime), 0).UTC().Format("2006-01-02")
+			case UsageReportByProject:
+				key.ProjectID = row.ProjectID
+			case UsageReportByModel:
+				key.Model = row.Model
+			}
+		}
+
+		total, ok := rows[key]
+		if !ok {
+			total = &UsageReportRow{Day: key.Day, ProjectID: key.ProjectID, Model: key.Model}
+			rows[key] = total
+			order = append(order, total)
+		}
+		total.add(&row)
+	}
+
+	for _, bucket := range usage {
+		for _, result := range bucket.Results {
+			add(bucket.StartTime, UsageReportRow{
+				ProjectID:         result.ProjectID,
+				Model:             result.Model,
+				InputTokens:       result.InputTokens,
+				OutputTokens:      result.OutputTokens,
+				InputCachedTokens: result.InputCachedTokens,
+				NumModelRequests:  result.NumModelRequests,
+			})
+		}
+	}
+
+	for _, bucket := range costs {
+		for _, result := range bucket.Results {
+			model, _, _ := strings.Cut(result.LineItem, ",")
+			add(bucket.StartTime, UsageReportRow{
+				ProjectID: result.ProjectID,
+				Model:     model,
+				Cost:      result.Amount.Value,
+				Currency:  result.Amount.Currency,
+			})
+		}
+	}
+
+	report := &UsageReport{By: by, Rows: make([]UsageReportRow, 0, len(order))}
+	for _, row := range order {
+		report.Rows = append(report.Rows, *row)
+	}
+
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		return a.Model < b.Model
+	})
+
+	return report
+}
+
+// Total returns the sum of all rows in the report.
+func (r *UsageReport) Total() UsageReportRow {
+	var total UsageReportRow
+	for i := range r.Rows {
+		total.add(&r.Rows[i])
+	}
+	return total
+}
+
+// Delta returns the change in usage and spend of each project/model relative to
+// a prior period's report, as current minus prior. Rows are matched ignoring the
+// day, since the two periods cover different days, and rows present in only one
+// of the reports are compared against zero.
+func (r *UsageReport) Delta(prior *UsageReport) *UsageReport {
+	var by []UsageReportDimension
+	for _, d := range r.By {
+		if d != UsageReportByDay {
+			by = append(by, d)
+		}
+	}
+
+	delta := &UsageReport{By: by}
+
+	index := map[[2]string]int{}
+	row := func(projectID, model string) *UsageReportRow {
+		key := [2]string{projectID, model}
+		i, ok := index[key]
+		if !ok {
+			i = len(delta.Rows)
+			index[key] = i
+			delta.Rows = append(delta.Rows, UsageReportRow{ProjectID: projectID, Model: model})
+		}
+		return &delta.Rows[i]
+	}
+
+	for i := range r.Rows {
+		cur := r.Rows[i]
+		row(cur.ProjectID, cur.Model).add(&cur)
+	}
+
+	for i := range prior.Rows {
+		p := prior.Rows[i]
+		neg := UsageReportRow{
+			InputTokens:       -p.InputTokens,
+			OutputTokens:      -p.OutputTokens,
+			InputCachedTokens: -p.InputCachedTokens,
+			NumModelRequests:  -p.NumModelRequests,
+			Cost:              -p.Cost,
+			Currency:          p.Currency,
+		}
+		row(p.ProjectID, p.Model).add(&neg)
+	}
+
+	sort.SliceStable(delta.Rows, func(i, j int) bool {
+		a, b := delta.Rows[i], delta.Rows[j]
+		if a.ProjectID != b.ProjectID {
+			return a.ProjectID < b.ProjectID
+		}
+		return a.Model < b.Model
+	})
+
+	return delta
+}
+
+// WriteJSON writes the report to w as JSON.
+func (r *UsageReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes the report to w as CSV, with a header row followed by one
+// row per report row. Only the report's dimensions are included as columns.
+func (r *UsageReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	var header []string
+	for _, d := range r.By {
+		header = append(header, string(d))
+	}
+	header = append(header, "input_tokens", "output_tokens", "input_cached_tokens", "num_model_requests", "cost", "currency")
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range r.Rows {
+		var record []string
+		for _, d := range r.By {
+			switch d {
+			case UsageReportByDay:
+				record = append(record, row.Day)
+			case UsageReportByProject:
+				record = append(record, row.ProjectID)
+			case UsageReportByModel:
+				record = append(record, row.Model)
+			}
+		}
+
+		record = append(record,
+			strconv.Itoa(row.InputTokens),
+			strconv.Itoa(row.OutputTokens),
+			strconv.Itoa(row.InputCachedTokens),
+			strconv.Itoa(row.NumModelRequests),
+			strconv.FormatFloat(row.Cost, 'f', -1, 64),
+			row.Currency,
+		)
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// UsageReportRequest is the time range and filters for GetUsageReport.
+type UsageReportRequest struct {
+	// Start time (Unix seconds) of the report, inclusive.
+	//
+	// Required.
+	StartTime int
+
+	// End time (Unix seconds) of the report, exclusive.
+	//
+	// Optional.
+	EndTime int
+
+	// Optional.
+	ProjectIDs []string
+
+	// Optional. Defaults to all dimensions.
+	By []UsageReportDimension
+}
+
+// GetUsageReport fetches all completions usage and costs for the given time
+// range, following pagination, and summarizes them into a UsageReport.
+//
+// Requires an admin API key.
+//
+// # Example
+//
+//	report, err := c.GetUsageReport(ctx, &openai.UsageReportRequest{
+//		StartTime: int(time.Now().AddDate(0, 0, -30).Unix()),
+//		By:        []openai.UsageReportDimension{openai.UsageReportByProject},
+//	})
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	err = report.WriteCSV(os.Stdout)
+func (c *Client) GetUsageReport(ctx context.Context, req *UsageReportRequest) (*UsageReport, error) {
+	var usage []UsageBucket
+
+	usageReq := &UsageRequest{
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		BucketWidth: UsageBucketWidthDay,
+		ProjectIDs:  req.ProjectIDs,
+		GroupBy:     []UsageGroupBy{UsageGroupByProjectID, UsageGroupByModel},
+	}
+
+	for {
+		res, err := c.GetCompletionsUsage(ctx, usageReq)
+		if err != nil {
+			return nil, err
+		}
+
+		usage = append(usage, res.Data...)
+
+		if !res.HasMore || res.NextPage == "" {
+			break
+		}
+		usageReq.Page = res.NextPage
+	}
+
+	var costs []CostsBucket
+
+	costsReq := &CostsRequest{
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		ProjectIDs: req.ProjectIDs,
+		GroupBy:    []CostsGroupBy{CostsGroupByProjectID, CostsGroupByLineItem},
+	}
+
+	for {
+		res, err := c.GetCosts(ctx, costsReq)
+		if err != nil {
+			return nil, err
+		}
+
+		costs = append(costs, res.Data...)
+
+		if !res.HasMore || res.NextPage == "" {
+			break
+		}
+		costsReq.Page = res.NextPage
+	}
+
+	return NewUsageReport(usage, costs, req.By...), nil
+}
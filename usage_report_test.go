@@ -0,0 +1,68 @@
+package openai_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestUsageReport(t *testing.T) {
+	const day = 86400
+
+	usage := []openai.UsageBucket{
+		{StartTime: 0, Results: []openai.UsageResult{
+			{ProjectID: "proj_a", Model: "gpt-4o", InputTokens: 100, OutputTokens: 10, NumModelRequests: 1},
+			{ProjectID: "proj_b", Model: "gpt-4o", InputTokens: 50, OutputTokens: 5, NumModelRequests: 1},
+		}},
+		{StartTime: day, Results: []openai.UsageResult{
+			{ProjectID: "proj_a", Model: "gpt-4o", InputTokens: 200, OutputTokens: 20, NumModelRequests: 2},
+		}},
+	}
+
+	costs := []openai.CostsBucket{
+		{StartTime: 0, Results: []openai.CostsResult{
+			{ProjectID: "proj_a", LineItem: "gpt-4o, input", Amount: openai.CostsAmount{Value: 1, Currency: "usd"}},
+			{ProjectID: "proj_a", LineItem: "gpt-4o, output", Amount: openai.CostsAmount{Value: 2, Currency: "usd"}},
+		}},
+	}
+
+	daily := openai.NewUsageReport(usage, costs)
+	if len(daily.Rows) != 3 {
+		t.Fatalf("expected 3 rows, got %#+v", daily.Rows)
+	}
+
+	first := daily.Rows[0]
+	if first.Day != "1970-01-01" || first.ProjectID != "proj_a" || first.InputTokens != 100 || first.Cost != 3 {
+		t.Fatalf("unexpected first row: %#+v", first)
+	}
+
+	byProject := openai.NewUsageReport(usage, costs, openai.UsageReportByProject)
+	if len(byProject.Rows) != 2 || byProject.Rows[0].InputTokens != 300 || byProject.Rows[0].Model != "" {
+		t.Fatalf("unexpected project rows: %#+v", byProject.Rows)
+	}
+
+	if total := byProject.Total(); total.InputTokens != 350 || total.Cost != 3 {
+		t.Fatalf("unexpected total: %#+v", total)
+	}
+
+	prior := openai.NewUsageReport(usage[:1], nil, openai.UsageReportByProject)
+
+	delta := byProject.Delta(prior)
+	if len(delta.Rows) != 2 || delta.Rows[0].InputTokens != 200 || delta.Rows[0].Cost != 3 || delta.Rows[1].InputTokens != 0 {
+		t.Fatalf("unexpected delta rows: %#+v", delta.Rows)
+	}
+
+	var buf bytes.Buffer
+	if err := byProject.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "project,input_tokens,output_tokens,input_cached_tokens,num_model_requests,cost,currency\n" +
+		"proj_a,300,30,0,3,3,usd\n" +
+		"proj_b,50,5,0,1,0,\n"
+
+	if buf.String() != want {
+		t.Fatalf("unexpected CSV:\n%s", buf.String())
+	}
+}
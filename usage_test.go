@@ -0,0 +1,145 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestGetCompletionsUsage(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/organization/usage/completions" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		q := r.URL.Query()
+		if q.Get("start_time") != "1730419200" || q.Get("bucket_width") != "1d" || q.Get("models[]") != "gpt-4o" || len(q["group_by[]"]) != 2 {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{
+			"object": "page",
+			"data": [{
+				"object": "bucket",
+				"start_time": 1730419200,
+				"end_time": 1730505600,
+				"results": [{
+					"object": "organization.usage.completions.result",
+					"input_tokens": 1000,
+					"output_tokens": 500,
+					"input_cached_tokens": 800,
+					"num_model_requests": 5,
+					"project_id": "proj_abc",
+					"model": "gpt-4o"
+				}]
+			}],
+			"has_more": true,
+			"next_page": "page_AAAA"
+		}`)
+	})
+
+	resp, err := c.GetCompletionsUsage(testCtx(t), &openai.UsageRequest{
+		StartTime:   1730419200,
+		BucketWidth: openai.UsageBucketWidthDay,
+		Models:      []string{"gpt-4o"},
+		GroupBy:     []openai.UsageGroupBy{openai.UsageGroupByProjectID, openai.UsageGroupByModel},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !resp.HasMore || resp.NextPage != "page_AAAA" || len(resp.Data) != 1 {
+		t.Fatalf("unexpected response: %#+v", resp)
+	}
+
+	result := resp.Data[0].Results[0]
+	if result.InputTokens != 1000 || result.InputCachedTokens != 800 || result.ProjectID != "proj_abc" {
+		t.Fatalf("unexpected result: %#+v", result)
+	}
+}
+
+func TestGetCosts(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/organization/costs" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		q := r.URL.Query()
+		if q.Get("start_time") != "1730419200" || q.Get("end_time") != "1730592000" || len(q["group_by[]"]) != 2 {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{
+			"object": "page",
+			"data": [{
+				"object": "bucket",
+				"start_time": 1730419200,
+				"end_time": 1730505600,
+				"results": [
+					{"object": "organization.costs.result", "amount": {"value": 0.06, "currency": "usd"}, "line_item": "gpt-4o, input", "project_id": "proj_abc"},
+					{"object": "organization.costs.result", "amount": {"value": 0.12, "currency": "usd"}, "line_item": "gpt-4o, output", "project_id": "proj_abc"}
+				]
+			}],
+			"has_more": false
+		}`)
+	})
+
+	resp, err := c.GetCosts(testCtx(t), &openai.CostsRequest{
+		StartTime: 1730419200,
+		EndTime:   1730592000,
+		GroupBy:   []openai.CostsGroupBy{openai.CostsGroupByProjectID, openai.CostsGroupByLineItem},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total float64
+	for _, result := range resp.Data[0].Results {
+		total += result.Amount.Value
+	}
+
+	if total < 0.179 || total > 0.181 {
+		t.Fatalf("unexpected total: %f", total)
+	}
+}
+
+func TestGetImagesUsage(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/organization/usage/images" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		q := r.URL.Query()
+		if q.Get("bucket_width") != "1h" || q.Get("sources[]") != "image.generation" || q.Get("sizes[]") != "1024x1024" || q.Get("page") != "page_AAAA" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{
+			"object": "page",
+			"data": [{
+				"object": "bucket",
+				"start_time": 1730419200,
+				"end_time": 1730422800,
+				"results": [{"object": "organization.usage.images.result", "images": 3, "num_model_requests": 2, "source": "image.generation", "size": "1024x1024"}]
+			}],
+			"has_more": false
+		}`)
+	})
+
+	resp, err := c.GetImagesUsage(testCtx(t), &openai.UsageRequest{
+		StartTime:   1730419200,
+		BucketWidth: openai.UsageBucketWidthHour,
+		Sources:     []string{"image.generation"},
+		Sizes:       []string{"1024x1024"},
+		Page:        "page_AAAA",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := resp.Data[0].Results[0]; result.Images != 3 || result.Source != "image.generation" {
+		t.Fatalf("unexpected result: %#+v", result)
+	}
+}
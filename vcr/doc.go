@@ -0,0 +1,22 @@
+// Package vcr provides an http.RoundTripper that records API interactions to
+// fixture files, called cassettes, and replays them, so test suites using the
+// OpenAI API are deterministic, offline and free.
+//
+// Credentials are removed from recorded requests and responses before they are
+// saved. Streamed responses, such as server-sent events, are recorded in full
+// and replayed as a single body, which the client reads as a stream as usual.
+//
+// # Example
+//
+//	rec, err := vcr.New("testdata/chat.json", vcr.ModeAuto)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	t.Cleanup(func() {
+//		if err := rec.Save(); err != nil {
+//			t.Error(err)
+//		}
+//	})
+//
+//	c := openai.NewClient(os.Getenv("OPENAI_API_KEY"), openai.WithHTTPClient(rec.Client()))
+package vcr
@@ -0,0 +1,368 @@
+package vcr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ErrInteractionNotFound is returned when replaying a request that doesn't
+// match any unused interaction in the cassette.
+var ErrInteractionNotFound = errors.New("vcr: no recorded interaction matches the request")
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay replays recorded interactions, and fails requests without one.
+	ModeReplay Mode = iota
+
+	// ModeRecord sends every request, and records the interactions, replacing
+	// the cassette when saved.
+	ModeRecord
+
+	// ModeAuto replays the cassette if it exists, and records one otherwise.
+	ModeAuto
+)
+
+// sensitiveHeaders are removed from recorded interactions.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Api-Key",
+	"Cookie",
+	"Set-Cookie",
+	"OpenAI-Organization",
+	"OpenAI-Project",
+}
+
+// Body is a recorded request or response body. Bodies that aren't valid UTF-8,
+// such as audio, are encoded as base64.
+type Body []byte
+
+// MarshalJSON implements the json.Marshaler interface.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the contents of a fixture file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records interactions to a cassette, or
+// replays them from it, depending on its mode.
+//
+// When replaying, each request is served by the first unused interaction that
+// matches it, so repeated requests are replayed in the order they were recorded.
+//
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	// Transport sends requests while recording. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// Match reports whether a request, with the given body, matches a recorded
+	// one. If nil, requests match if their method, path, query and body are
+	// equal, ignoring the bodies of multipart requests, whose boundaries are random.
+	Match func(r *http.Request, body []byte, recorded *Request) bool
+
+	// Sanitize is called with each interaction before it is recorded, to remove
+	// sensitive data in addition to the credential headers removed by default.
+	Sanitize func(*Interaction)
+
+	path      string
+	recording bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     map[*Interaction]bool
+}
+
+// New returns a Recorder for the cassette at the given path, loading it unless
+// it is recording. In ModeReplay, the cassette must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	rec := &Recorder{path: path, used: map[*Interaction]bool{}}
+
+	switch mode {
+	case ModeRecord:
+		rec.recording = true
+		return rec, nil
+	case ModeReplay, ModeAuto:
+	default:
+		return nil, fmt.Errorf("vcr: invalid mode %d", mode)
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && mode == ModeAuto {
+		rec.recording = true
+		return rec, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("vcr: failed to read cassette: %w", err)
+	}
+
+	if err := json.Unmarshal(b, &rec.cassette); err != nil {
+		return nil, fmt.Errorf("vcr: failed to decode cassette %s: %w", path, err)
+	}
+
+	return rec, nil
+}
+
+// Recording reports whether the recorder is recording, rather than replaying.
+func (rec *Recorder) Recording() bool {
+	return rec.recording
+}
+
+// Client returns an HTTP client that sends requests through the recorder.
+func (rec *Recorder) Client() *http.Client {
+	return &http.Client{Transport: rec}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (rec *Recorder) RoundTrip(r *http.Request) (*http.Response, error) {
+	body, err := requestBody(r)
+	if err != nil {
+		return nil, fmt.Errorf("vcr: failed to read request body: %w", err)
+	}
+
+	if rec.recording {
+		return rec.record(r, body)
+	}
+
+	return rec.replay(r, body)
+}
+
+func (rec *Recorder) record(r *http.Request, body []byte) (*http.Response, error) {
+	transport := rec.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("vcr: failed to read response body: %w", err)
+	}
+
+	// Record the decoded body of gzip-encoded responses, which the client
+	// requests, so cassettes are readable and can be edited.
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		respBody, err = gunzip(respBody)
+		if err != nil {
+			return nil, fmt.Errorf("vcr: failed to decode gzip response body: %w", err)
+		}
+
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Set("Content-Length", strconv.Itoa(len(respBody)))
+		resp.ContentLength = int64(len(respBody))
+		resp.Uncompressed = true
+	}
+
+	i := &Interaction{
+		Request: Request{
+			Method: r.Method,
+			URL:    r.URL.String(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       respBody,
+		},
+	}
+
+	for _, h := range sensitiveHeaders {
+		i.Request.Header.Del(h)
+		i.Response.Header.Del(h)
+	}
+
+	if rec.Sanitize != nil {
+		rec.Sanitize(i)
+	}
+
+	rec.mu.Lock()
+	rec.cassette.Interactions = append(rec.cassette.Interactions, i)
+	rec.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+func (rec *Recorder) replay(r *http.Request, body []byte) (*http.Response, error) {
+	match := rec.Match
+	if match == nil {
+		match = defaultMatch
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	for _, i := range rec.cassette.Interactions {
+		if rec.used[i] || !match(r, body, &i.Request) {
+			continue
+		}
+		rec.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       r,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, r.Method, r.URL)
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory if needed. It does nothing when replaying.
+func (rec *Recorder) Save() error {
+	if !rec.recording {
+		return nil
+	}
+
+	rec.mu.Lock()
+	b, err := json.MarshalIndent(rec.cassette, "", "  ")
+	rec.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("vcr: failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(rec.path), 0o755); err != nil {
+		return fmt.Errorf("vcr: failed to create cassette directory: %w", err)
+	}
+
+	if err := os.WriteFile(rec.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("vcr: failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// requestBody reads the body of r, leaving it readable for the transport.
+func requestBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	b, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+// gunzip returns the decoded gzip data. Empty data is returned as is.
+func gunzip(b []byte) ([]byte, error) {
+	if len(b) == 0 {
+		return b, nil
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	return io.ReadAll(zr)
+}
+
+// defaultMatch matches requests by method, path, query and body, ignoring the
+// bodies of multipart requests.
+func defaultMatch(r *http.Request, body []byte, recorded *Request) bool {
+	if r.Method != recorded.Method {
+		return false
+	}
+
+	u, err := r.URL.Parse(recorded.URL)
+	if err != nil || u.Path != r.URL.Path || u.RawQuery != r.URL.RawQuery {
+		return false
+	}
+
+	if t, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); t == "multipart/form-data" {
+		return true
+	}
+
+	return bytes.Equal(body, recorded.Body)
+}
@@ -0,0 +1,140 @@
+package vcr_test
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/picatz/openai"
+	"github.com/picatz/openai/vcr"
+)
+
+func TestRecorder(t *testing.T) {
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		switch r.URL.Path {
+		case "/v1/models":
+			// The client asks for gzip-encoded responses.
+			if r.Header.Get("Accept-Encoding") != "gzip" {
+				t.Errorf("unexpected accept encoding: %q", r.Header.Get("Accept-Encoding"))
+			}
+			w.Header().Set("Content-Encoding", "gzip")
+			zw := gzip.NewWriter(w)
+			fmt.Fprint(zw, `{"object":"list","data":[{"id":"gpt-4o","object":"model"}]}`)
+			zw.Close()
+		case "/v1/chat/completions":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hello\"}}]}\n\n")
+			fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\" world\"}}]}\n\n")
+			fmt.Fprint(w, "data: [DONE]\n\n")
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	run := func(rec *vcr.Recorder) {
+		t.Helper()
+
+		c := openai.NewClient("sk-secret",
+			openai.WithBaseURL(srv.URL+"/v1"),
+			openai.WithHTTPClient(rec.Client()),
+		)
+
+		ctx := context.Background()
+
+		models, err := c.ListModels(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(models.Data) != 1 || models.Data[0].ID != "gpt-4o" {
+			t.Fatalf("unexpected models: %+v", models.Data)
+		}
+
+		stream, err := c.CreateChatStream(ctx, &openai.CreateChatRequest{
+			Model:    openai.ModelGPT4,
+			Messages: []openai.ChatMessage{openai.User("Hi")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stream.Close()
+
+		for {
+			_, err := stream.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if content := stream.Content(); content != "Hello world" {
+			t.Fatalf("unexpected streamed content: %q", content)
+		}
+	}
+
+	rec, err := vcr.New(path, vcr.ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rec.Recording() {
+		t.Fatal("expected recorder to record without a cassette")
+	}
+
+	run(rec)
+
+	if err := rec.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "sk-secret") {
+		t.Fatalf("expected credentials to be removed from the cassette:\n%s", b)
+	}
+	if !strings.Contains(string(b), `\"id\":\"gpt-4o\"`) || strings.Contains(string(b), "Content-Encoding") {
+		t.Fatalf("expected the decoded response body in the cassette:\n%s", b)
+	}
+
+	rec, err = vcr.New(path, vcr.ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Recording() {
+		t.Fatal("expected recorder to replay the cassette")
+	}
+
+	run(rec)
+
+	if requests != 2 {
+		t.Fatalf("expected 2 requests to reach the server, got %d", requests)
+	}
+
+	// Each interaction is only replayed once.
+	_, err = openai.NewClient("test", openai.WithBaseURL(srv.URL+"/v1"), openai.WithHTTPClient(rec.Client())).ListModels(context.Background())
+	if !errors.Is(err, vcr.ErrInteractionNotFound) {
+		t.Fatalf("expected ErrInteractionNotFound, got %v", err)
+	}
+}
+
+func TestNew_replayMissingCassette(t *testing.T) {
+	_, err := vcr.New(filepath.Join(t.TempDir(), "missing.json"), vcr.ModeReplay)
+	if err == nil {
+		t.Fatal("expected error for missing cassette")
+	}
+}
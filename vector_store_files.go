@@ -0,0 +1,392 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// VectorStoreFileStatus is the ingestion status of a vector store file or file
+// batch.
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-files/file-object#vector-stores-files/file-object-status
+type VectorStoreFileStatus string
+
+const (
+	VectorStoreFileStatusInProgress VectorStoreFileStatus = "in_progress"
+	VectorStoreFileStatusCompleted  VectorStoreFileStatus = "completed"
+	VectorStoreFileStatusCancelled  VectorStoreFileStatus = "cancelled"
+	VectorStoreFileStatusFailed     VectorStoreFileStatus = "failed"
+)
+
+// https://platform.openai.com/docs/api-reference/vector-stores-files/file-object
+type VectorStoreFile struct {
+	Object           string                `json:"object"`
+	ID               string                `json:"id"`
+	UsageBytes       int                   `json:"usage_bytes"`
+	CreatedAt        int                   `json:"created_at"`
+	VectorStoreID    string                `json:"vector_store_id"`
+	Status           VectorStoreFileStatus `json:"status"`
+	LastError        *VectorStoreFileError `json:"last_error,omitempty"`
+	ChunkingStrategy map[string]any        `json:"chunking_strategy,omitempty"`
+}
+
+// VectorStoreFileError is the reason a vector store file failed to be ingested.
+type VectorStoreFileError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-files/createFile
+type CreateVectorStoreFileRequest struct {
+	// Required.
+	VectorStoreID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/vector-stores-files/createFile#vector-stores-files-createfile-file_id
+	//
+	// Required.
+	FileID string `json:"file_id"`
+
+	// https://platform.openai.com/docs/api-reference/vector-stores-files/createFile#vector-stores-files-createfile-chunking_strategy
+	//
+	// Optional. Defaults to {"type": "auto"}.
+	ChunkingStrategy map[string]any `json:"chunking_strategy,omitempty"`
+}
+
+// CreateVectorStoreFile attaches a file to a vector store. The file is ingested
+// asynchronously; use WaitForVectorStoreFile to wait until it is searchable.
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-files/createFile
+func (c *Client) CreateVectorStoreFile(ctx context.Context, req *CreateVectorStoreFileRequest) (*VectorStoreFile, error) {
+	var res VectorStoreFile
+	err := c.do(ctx, http.MethodPost, "/vector_stores/"+req.VectorStoreID+"/files", req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-files/getFile
+type GetVectorStoreFileRequest struct {
+	// Required.
+	VectorStoreID string `json:"-"`
+
+	// Required.
+	FileID string `json:"-"`
+}
+
+// GetVectorStoreFile retrieves a vector store file.
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-files/getFile
+func (c *Client) GetVectorStoreFile(ctx context.Context, req *GetVectorStoreFileRequest) (*VectorStoreFile, error) {
+	var res VectorStoreFile
+	err := c.do(ctx, http.MethodGet, "/vector_stores/"+req.VectorStoreID+"/files/"+req.FileID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-files/deleteFile
+type DeleteVectorStoreFileRequest struct {
+	// Required.
+	VectorStoreID string `json:"-"`
+
+	// Required.
+	FileID string `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-files/deleteFile
+type DeleteVectorStoreFileResponse struct {
+	Object  string `json:"object"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// DeleteVectorStoreFile removes a file from a vector store. The file itself
+// isn't deleted; use DeleteFile for that.
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-files/deleteFile
+func (c *Client) DeleteVectorStoreFile(ctx context.Context, req *DeleteVectorStoreFileRequest) (*DeleteVectorStoreFileResponse, error) {
+	var res DeleteVectorStoreFileResponse
+	err := c.do(ctx, http.MethodDelete, "/vector_stores/"+req.VectorStoreID+"/files/"+req.FileID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-files/listFiles
+type ListVectorStoreFilesRequest struct {
+	// Required.
+	VectorStoreID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/vector-stores-files/listFiles#vector-stores-files-listfiles-limit
+	//
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/vector-stores-files/listFiles#vector-stores-files-listfiles-order
+	//
+	// Optional. Defaults to "desc".
+	Order SortOrder `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/vector-stores-files/listFiles#vector-stores-files-listfiles-after
+	//
+	// Optional.
+	After string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/vector-stores-files/listFiles#vector-stores-files-listfiles-before
+	//
+	// Optional.
+	Before string `json:"-"`
+
+	// Filter only lists files with the given status.
+	//
+	// https://platform.openai.com/docs/api-reference/vector-stores-files/listFiles#vector-stores-files-listfiles-filter
+	//
+	// Optional.
+	Filter VectorStoreFileStatus `json:"-"`
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-files/listFiles
+type ListVectorStoreFilesResponse = Page[VectorStoreFile]
+
+// ListVectorStoreFiles lists the files in a vector store.
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-files/listFiles
+func (c *Client) ListVectorStoreFiles(ctx context.Context, req *ListVectorStoreFilesRequest) (*ListVectorStoreFilesResponse, error) {
+	var res ListVectorStoreFilesResponse
+	path := "/vector_stores/" + req.VectorStoreID + "/files" + vectorStoreFilesQuery(req.Limit, req.Order, req.After, req.Before, req.Filter)
+	err := c.do(ctx, http.MethodGet, path, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListVectorStoreFilesRequest, after string) { r.After = after }, c.ListVectorStoreFiles)
+	return &res, nil
+}
+
+// vectorStoreFilesQuery encodes the parameters of vector store file listings
+// as a query string, including the leading "?" if any parameters are set.
+func vectorStoreFilesQuery(limit int, order SortOrder, after, before string, filter VectorStoreFileStatus) string {
+	q := listQuery(limit, order, after, before)
+
+	switch {
+	case filter == "":
+		return q
+	case q == "":
+		return "?filter=" + url.QueryEscape(string(filter))
+	default:
+		return q + "&filter=" + url.QueryEscape(string(filter))
+	}
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/batch-object
+type VectorStoreFileBatch struct {
+	Object        string                `json:"object"`
+	ID            string                `json:"id"`
+	CreatedAt     int                   `json:"created_at"`
+	VectorStoreID string                `json:"vector_store_id"`
+	Status        VectorStoreFileStatus `json:"status"`
+	FileCounts    VectorStoreFileCounts `json:"file_counts"`
+}
+
+// VectorStoreFileCounts are the number of files in each status.
+type VectorStoreFileCounts struct {
+	InProgress int `json:"in_progress"`
+	Completed  int `json:"completed"`
+	Failed     int `json:"failed"`
+	Cancelled  int `json:"cancelled"`
+	Total      int `json:"total"`
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/createBatch
+type CreateVectorStoreFileBatchRequest struct {
+	// Required.
+	VectorStoreID string `json:"-"`
+
+	// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/createBatch#vector-stores-file-batches-createbatch-file_ids
+	//
+	// Required.
+	FileIDs []string `json:"file_ids"`
+
+	// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/createBatch#vector-stores-file-batches-createbatch-chunking_strategy
+	//
+	// Optional. Defaults to {"type": "auto"}.
+	ChunkingStrategy map[string]any `json:"chunking_strategy,omitempty"`
+}
+
+// CreateVectorStoreFileBatch attaches several files to a vector store at once.
+// The files are ingested asynchronously; use WaitForVectorStoreFileBatch to
+// wait until they are searchable.
+//
+// # Example
+//
+//	batch, _ := c.CreateVectorStoreFileBatch(ctx, &openai.CreateVectorStoreFileBatchRequest{
+//		VectorStoreID: "vs_abc123",
+//		FileIDs:       []string{"file-abc123", "file-def456"},
+//	})
+//
+//	batch, err := c.WaitForVectorStoreFileBatch(ctx, batch.VectorStoreID, batch.ID, time.Second)
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/createBatch
+func (c *Client) CreateVectorStoreFileBatch(ctx context.Context, req *CreateVectorStoreFileBatchRequest) (*VectorStoreFileBatch, error) {
+	var res VectorStoreFileBatch
+	err := c.do(ctx, http.MethodPost, "/vector_stores/"+req.VectorStoreID+"/file_batches", req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/getBatch
+type GetVectorStoreFileBatchRequest struct {
+	// Required.
+	VectorStoreID string `json:"-"`
+
+	// Required.
+	BatchID string `json:"-"`
+}
+
+// GetVectorStoreFileBatch retrieves a vector store file batch.
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/getBatch
+func (c *Client) GetVectorStoreFileBatch(ctx context.Context, req *GetVectorStoreFileBatchRequest) (*VectorStoreFileBatch, error) {
+	var res VectorStoreFileBatch
+	err := c.do(ctx, http.MethodGet, "/vector_stores/"+req.VectorStoreID+"/file_batches/"+req.BatchID, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/cancelBatch
+type CancelVectorStoreFileBatchRequest struct {
+	// Required.
+	VectorStoreID string `json:"-"`
+
+	// Required.
+	BatchID string `json:"-"`
+}
+
+// CancelVectorStoreFileBatch cancels the ingestion of the files in a batch
+// that haven't been processed yet.
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/cancelBatch
+func (c *Client) CancelVectorStoreFileBatch(ctx context.Context, req *CancelVectorStoreFileBatchRequest) (*VectorStoreFileBatch, error) {
+	var res VectorStoreFileBatch
+	err := c.do(ctx, http.MethodPost, "/vector_stores/"+req.VectorStoreID+"/file_batches/"+req.BatchID+"/cancel", nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/listBatchFiles
+type ListVectorStoreFileBatchFilesRequest struct {
+	// Required.
+	VectorStoreID string `json:"-"`
+
+	// Required.
+	BatchID string `json:"-"`
+
+	// Optional. Defaults to 20.
+	Limit int `json:"-"`
+
+	// Optional. Defaults to "desc".
+	Order SortOrder `json:"-"`
+
+	// Optional.
+	After string `json:"-"`
+
+	// Optional.
+	Before string `json:"-"`
+
+	// Filter only lists files with the given status.
+	//
+	// Optional.
+	Filter VectorStoreFileStatus `json:"-"`
+}
+
+// ListVectorStoreFileBatchFiles lists the files in a vector store file batch.
+//
+// https://platform.openai.com/docs/api-reference/vector-stores-file-batches/listBatchFiles
+func (c *Client) ListVectorStoreFileBatchFiles(ctx context.Context, req *ListVectorStoreFileBatchFilesRequest) (*ListVectorStoreFilesResponse, error) {
+	var res ListVectorStoreFilesResponse
+	path := "/vector_stores/" + req.VectorStoreID + "/file_batches/" + req.BatchID + "/files" + vectorStoreFilesQuery(req.Limit, req.Order, req.After, req.Before, req.Filter)
+	err := c.do(ctx, http.MethodGet, path, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	paginate(&res, req, func(r *ListVectorStoreFileBatchFilesRequest, after string) { r.After = after }, c.ListVectorStoreFileBatchFiles)
+	return &res, nil
+}
+
+// WaitForVectorStoreFile polls the API at the given interval until the file has
+// been ingested, returning the file once its status is no longer "in_progress".
+// It returns an error along with the file if ingestion failed or was cancelled.
+func (c *Client) WaitForVectorStoreFile(ctx context.Context, vectorStoreID, fileID string, interval time.Duration) (*VectorStoreFile, error) {
+	return pollUntil(ctx, interval, func(ctx context.Context) (*VectorStoreFile, error) {
+		f, err := c.GetVectorStoreFile(ctx, &GetVectorStoreFileRequest{VectorStoreID: vectorStoreID, FileID: fileID})
+		if err != nil || f.Status == VectorStoreFileStatusInProgress {
+			return nil, err
+		}
+
+		switch f.Status {
+		case VectorStoreFileStatusFailed:
+			if f.LastError != nil {
+				return f, fmt.Errorf("vector store file %q failed: %s: %s", fileID, f.LastError.Code, f.LastError.Message)
+			}
+			return f, fmt.Errorf("vector store file %q failed", fileID)
+		case VectorStoreFileStatusCancelled:
+			return f, fmt.Errorf("vector store file %q cancelled", fileID)
+		}
+
+		return f, nil
+	})
+}
+
+// WaitForVectorStoreFileBatch polls the API at the given interval until every
+// file in the batch has been processed, returning the batch once its status is
+// no longer "in_progress". It returns an error along with the batch if the
+// batch failed or was cancelled; individual files may still have failed in a
+// completed batch, as reported by its FileCounts.
+func (c *Client) WaitForVectorStoreFileBatch(ctx context.Context, vectorStoreID, batchID string, interval time.Duration) (*VectorStoreFileBatch, error) {
+	return pollUntil(ctx, interval, func(ctx context.Context) (*VectorStoreFileBatch, error) {
+		b, err := c.GetVectorStoreFileBatch(ctx, &GetVectorStoreFileBatchRequest{VectorStoreID: vectorStoreID, BatchID: batchID})
+		if err != nil || b.Status == VectorStoreFileStatusInProgress {
+			return nil, err
+		}
+
+		switch b.Status {
+		case VectorStoreFileStatusFailed:
+			return b, fmt.Errorf("vector store file batch %q failed", batchID)
+		case VectorStoreFileStatusCancelled:
+			return b, fmt.Errorf("vector store file batch %q cancelled", batchID)
+		}
+
+		return b, nil
+	})
+}
+
+// pollUntil calls check immediately, and then at the given interval, until it
+// returns a non-nil result or an error.
+func pollUntil[T any](ctx context.Context, interval time.Duration, check func(context.Context) (*T, error)) (*T, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := check(ctx)
+		if res != nil || err != nil {
+			return res, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package openai_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/picatz/openai"
+)
+
+func TestVectorStoreFiles(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("OpenAI-Beta"); got != "assistants=v2" {
+			t.Errorf("unexpected beta header: %q", got)
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/vector_stores/vs_abc/files":
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			fmt.Fprintf(w, `{"object":"vector_store.file","id":%q,"vector_store_id":"vs_abc","status":"in_progress"}`, body["file_id"])
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/vector_stores/vs_abc/files":
+			if r.URL.Query().Get("filter") != "failed" || r.URL.Query().Get("limit") != "5" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"file-b","status":"failed","last_error":{"code":"invalid_file","message":"bad"}}],"has_more":false}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/vector_stores/vs_abc/files/file-a":
+			fmt.Fprint(w, `{"object":"vector_store.file.deleted","id":"file-a","deleted":true}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	f, err := c.CreateVectorStoreFile(ctx, &openai.CreateVectorStoreFileRequest{VectorStoreID: "vs_abc", FileID: "file-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.ID != "file-a" || f.Status != openai.VectorStoreFileStatusInProgress {
+		t.Fatalf("unexpected file: %+v", f)
+	}
+
+	list, err := c.ListVectorStoreFiles(ctx, &openai.ListVectorStoreFilesRequest{VectorStoreID: "vs_abc", Limit: 5, Filter: openai.VectorStoreFileStatusFailed})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.Data) != 1 || list.Data[0].LastError == nil || list.Data[0].LastError.Code != "invalid_file" {
+		t.Fatalf("unexpected files: %+v", list.Data)
+	}
+
+	deleted, err := c.DeleteVectorStoreFile(ctx, &openai.DeleteVectorStoreFileRequest{VectorStoreID: "vs_abc", FileID: "file-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !deleted.Deleted {
+		t.Fatal("expected file to be deleted")
+	}
+}
+
+func TestWaitForVectorStoreFileBatch(t *testing.T) {
+	var polls int
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/vector_stores/vs_abc/file_batches":
+			fmt.Fprint(w, `{"object":"vector_store.file_batch","id":"vsfb_abc","vector_store_id":"vs_abc","status":"in_progress","file_counts":{"in_progress":2,"total":2}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/vector_stores/vs_abc/file_batches/vsfb_abc":
+			polls++
+			if polls < 3 {
+				fmt.Fprint(w, `{"id":"vsfb_abc","status":"in_progress","file_counts":{"in_progress":1,"completed":1,"total":2}}`)
+				return
+			}
+			fmt.Fprint(w, `{"id":"vsfb_abc","status":"completed","file_counts":{"completed":2,"total":2}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/vector_stores/vs_abc/file_batches/vsfb_abc/cancel":
+			fmt.Fprint(w, `{"id":"vsfb_abc","status":"cancelled"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/vector_stores/vs_abc/file_batches/vsfb_abc/files":
+			fmt.Fprint(w, `{"object":"list","data":[{"id":"file-a"},{"id":"file-b"}],"has_more":false}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	ctx := testCtx(t)
+
+	batch, err := c.CreateVectorStoreFileBatch(ctx, &openai.CreateVectorStoreFileBatchRequest{VectorStoreID: "vs_abc", FileIDs: []string{"file-a", "file-b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	batch, err = c.WaitForVectorStoreFileBatch(ctx, batch.VectorStoreID, batch.ID, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if polls != 3 || batch.Status != openai.VectorStoreFileStatusCompleted || batch.FileCounts.Completed != 2 {
+		t.Fatalf("unexpected batch after %d polls: %+v", polls, batch)
+	}
+
+	files, err := c.ListVectorStoreFileBatchFiles(ctx, &openai.ListVectorStoreFileBatchFilesRequest{VectorStoreID: "vs_abc", BatchID: "vsfb_abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files.Data) != 2 {
+		t.Fatalf("unexpected files: %+v", files.Data)
+	}
+
+	cancelled, err := c.CancelVectorStoreFileBatch(ctx, &openai.CancelVectorStoreFileBatchRequest{VectorStoreID: "vs_abc", BatchID: "vsfb_abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cancelled.Status != openai.VectorStoreFileStatusCancelled {
+		t.Fatalf("unexpected status: %q", cancelled.Status)
+	}
+}
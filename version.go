@@ -0,0 +1,71 @@
+package openai
+
+import "strings"
+
+// betaFeature is a beta API feature that must be opted into with the
+// OpenAI-Beta header.
+type betaFeature struct {
+	// name is the name of the feature, as sent in the header.
+	name string
+
+	// prefixes are the API paths that belong to the feature.
+	prefixes []string
+
+	// versions maps the API version each beta version became the default
+	// for, in ascending order, to the beta version.
+	versions []betaVersion
+}
+
+type betaVersion struct {
+	since   string
+	version string
+}
+
+// betaFeatures are the beta features enabled by the client.
+var betaFeatures = []betaFeature{
+	{
+		name:     "assistants",
+		prefixes: []string{"/assistants", "/threads", "/messages", "/vector_stores"},
+		versions: []betaVersion{
+			{since: "", version: "v1"},
+			{since: "2024-04-17", version: "v2"},
+		},
+	},
+}
+
+// betaHeader returns the OpenAI-Beta header value for a request to the given
+// API path, with the given pinned API version and overridden feature versions,
+// or an empty string if the path doesn't belong to a beta feature. Dated
+// versions compare lexically, and no pinned version uses the latest.
+func betaHeader(apiVersion, path string, overrides map[string]string) string {
+	var flags []string
+
+	for _, feature := range betaFeatures {
+		if !hasAnyPrefix(path, feature.prefixes) {
+			continue
+		}
+
+		version, ok := overrides[feature.name]
+		if !ok {
+			for _, v := range feature.versions {
+				if apiVersion != "" && apiVersion < v.since {
+					break
+				}
+				version = v.version
+			}
+		}
+
+		flags = append(flags, feature.name+"="+version)
+	}
+
+	return strings.Join(flags, ",")
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if s == prefix || strings.HasPrefix(s, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package openai_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/picatz/openai"
+)
+
+func TestWithAPIVersion(t *testing.T) {
+	var headers = map[string]http.Header{}
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		headers[r.URL.Path] = r.Header
+		fmt.Fprint(w, `{"object":"list","data":[]}`)
+	}
+
+	c := openai.NewClient("test",
+		openai.WithAPIVersion("2024-05-01"),
+		openai.WithOrganization("org-test"),
+		openai.WithHTTPClient(testClient(t, h).HTTPClient),
+	)
+
+	ctx := testCtx(t)
+
+	_, err := c.ListModels(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.ListAssistants(ctx, &openai.ListAssistantsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, header := range headers {
+		if got := header.Get("OpenAI-Version"); got != "2024-05-01" {
+			t.Errorf("%s: unexpected version header: %q", path, got)
+		}
+
+		if got := header.Get("OpenAI-Organization"); got != "org-test" {
+			t.Errorf("%s: unexpected organization header: %q", path, got)
+		}
+	}
+
+	if got := headers["/v1/models"].Get("OpenAI-Beta"); got != "" {
+		t.Errorf("unexpected beta header for models: %q", got)
+	}
+
+	if got := headers["/v1/assistants"].Get("OpenAI-Beta"); got != "assistants=v2" {
+		t.Errorf("unexpected beta header for assistants: %q", got)
+	}
+}
+
+func TestBetaVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []openai.ClientOption
+		want string
+	}{
+		{"latest", nil, "assistants=v2"},
+		{"before v2", []openai.ClientOption{openai.WithAPIVersion("2024-01-01")}, "assistants=v1"},
+		{"override", []openai.ClientOption{openai.WithAPIVersion("2024-05-01"), openai.WithBetaVersion("assistants", "v1")}, "assistants=v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+
+			h := func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("OpenAI-Beta")
+				fmt.Fprint(w, `{"id":"vs_abc","object":"thread","tool_resources":{"file_search":{"vector_store_ids":["vs_abc"]}}}`)
+			}
+
+			opts := append([]openai.ClientOption{openai.WithHTTPClient(testClient(t, h).HTTPClient)}, tt.opts...)
+			c := openai.NewClient("test", opts...)
+
+			thread, err := c.GetThread(testCtx(t), &openai.GetThreadRequest{ID: "thread_abc"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("expected beta header %q, got %q", tt.want, got)
+			}
+
+			if thread.ToolResources == nil || thread.ToolResources.FileSearch.VectorStoreIDs[0] != "vs_abc" {
+				t.Fatalf("unexpected tool resources: %+v", thread.ToolResources)
+			}
+		})
+	}
+}